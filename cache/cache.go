@@ -0,0 +1,109 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// Package cache stores the Trust Authority resources which seldom change, e.g. the services of the tenant, as files
+// of a directory so that the next commands can reuse them until they expire.
+package cache
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// unsafeKeyChars are replaced in the segments of a key to name the cache files
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// Cache stores JSON values under keys made of segments separated by /, e.g. default/services. Each segment is a
+// directory or the file name of the value.
+type Cache struct {
+	dir string
+}
+
+// entry is the content of a cache file
+type entry struct {
+	CreatedAt time.Time       `json:"created_at"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// New creates a cache storing the values in the directory, which is created once a value is stored
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Get reads the value stored under the key into value, it tells whether a value younger than maxAge was found. Any
+// value is returned when maxAge is not positive. A cache file which cannot be read is ignored.
+func (c *Cache) Get(key string, maxAge time.Duration, value interface{}) bool {
+	content, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err = json.Unmarshal(content, &e); err != nil {
+		return false
+	}
+	if maxAge > 0 && time.Since(e.CreatedAt) > maxAge {
+		return false
+	}
+	return json.Unmarshal(e.Value, value) == nil
+}
+
+// Set stores the value under the key
+func (c *Cache) Set(key string, value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode cache value")
+	}
+	if content, err = json.Marshal(entry{CreatedAt: time.Now(), Value: content}); err != nil {
+		return errors.Wrap(err, "Failed to encode cache value")
+	}
+
+	path := c.path(key)
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "Failed to create cache directory")
+	}
+	// the file is replaced at once so that concurrent commands never read a partial value
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "Failed to write cache file")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "Failed to write cache file")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "Failed to write cache file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "Failed to write cache file")
+}
+
+// Delete removes the value stored under the key along with the values of the keys it prefixes, e.g. deleting
+// default removes default/services
+func (c *Cache) Delete(key string) error {
+	path := c.path(key)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "Failed to delete cache value")
+	}
+	return errors.Wrap(os.RemoveAll(strings.TrimSuffix(path, ".json")), "Failed to delete cache value")
+}
+
+// Clear removes all the values of the cache
+func (c *Cache) Clear() error {
+	return errors.Wrap(os.RemoveAll(c.dir), "Failed to clear cache")
+}
+
+// path returns the path of the cache file of the key, the segments of the key cannot escape the cache directory
+func (c *Cache) path(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = unsafeKeyChars.ReplaceAllString(segment, "_")
+	}
+	return filepath.Join(c.dir, filepath.Join(segments...)+".json")
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	c := New(dir)
+
+	var values []string
+	assert.False(t, c.Get("default/services", time.Minute, &values), "Test a value which is not cached")
+	assert.NoError(t, c.Set("default/services", []string{"Attestation"}))
+	assert.True(t, c.Get("default/services", time.Minute, &values))
+	assert.Equal(t, []string{"Attestation"}, values)
+	assert.False(t, c.Get("staging/services", time.Minute, &values), "Test the value of another profile")
+
+	assert.False(t, c.Get("default/services", time.Nanosecond, &values), "Test an expired value")
+	assert.True(t, c.Get("default/services", 0, &values), "Test reading an expired value without max age")
+
+	assert.NoError(t, c.Set("../services", []string{"escaped"}))
+	_, err := os.Stat(filepath.Join(dir, "__", "services.json"))
+	assert.NoError(t, err, "Test a key escaping the cache directory")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "default", "broken.json"), []byte("{"), 0600))
+	assert.False(t, c.Get("default/broken", 0, &values), "Test an invalid cache file")
+
+	assert.NoError(t, c.Set("default/products/offer", []string{"Developer"}))
+	assert.NoError(t, c.Delete("default/services"))
+	assert.False(t, c.Get("default/services", 0, &values), "Test deleting a value")
+	assert.True(t, c.Get("default/products/offer", 0, &values), "Test deleting a value keeps the others")
+	assert.NoError(t, c.Delete("default"))
+	assert.False(t, c.Get("default/products/offer", 0, &values), "Test deleting the values of a prefix")
+	assert.NoError(t, c.Delete("staging"), "Test deleting a value which is not cached")
+	assert.NoError(t, c.Set("default/services", []string{"Attestation"}))
+
+	assert.NoError(t, c.Clear())
+	assert.False(t, c.Get("default/services", 0, &values), "Test clearing the cache")
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"intel/tac/v1/constants"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const cassetteFileExtension = ".json"
+
+// cassetteNameRegex matches the characters replaced in the file names of the interactions
+var cassetteNameRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Interaction is a request sent to Trust Authority and the response received, as stored in a cassette. The API key
+// and the other secrets are redacted and the URL is stored without its host so that it can be replayed against any
+// Trust Authority base URL.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in a cassette
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"headers,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response stored in a cassette
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// recordTransport stores each request sent and the response received in a file of the cassette directory
+type recordTransport struct {
+	base  http.RoundTripper
+	dir   string
+	mutex sync.Mutex
+	next  int
+}
+
+// replayTransport answers the requests with the responses of a cassette without reaching the network
+type replayTransport struct {
+	dir          string
+	mutex        sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+}
+
+func newRecordTransport(base http.RoundTripper, dir string) (*recordTransport, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "Failed to create cassette directory")
+	}
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	// the interactions of successive commands are appended to the cassette
+	return &recordTransport{base: base, dir: dir, next: len(files) + 1}, nil
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the body is read from a copy of the request so that the request is not modified
+	req = req.Clone(req.Context())
+	requestBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Url:    req.URL.RequestURI(),
+			Header: redactHeader(req.Header),
+			Body:   string(RedactBody(requestBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       string(RedactBody(responseBody)),
+		},
+	}
+	if err = t.write(interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *recordTransport) write(interaction *Interaction) error {
+	content, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode cassette interaction")
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	name := fmt.Sprintf("%04d-%s-%s%s", t.next, strings.ToLower(interaction.Request.Method),
+		strings.Trim(cassetteNameRegex.ReplaceAllString(strings.SplitN(interaction.Request.Url, "?", 2)[0], "-"), "-"),
+		cassetteFileExtension)
+	t.next++
+	if err = os.WriteFile(filepath.Join(t.dir, name), content, 0600); err != nil {
+		return errors.Wrap(err, "Failed to write cassette interaction")
+	}
+	return nil
+}
+
+func newReplayTransport(dir string) (*replayTransport, error) {
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("No interaction found in cassette %s", dir)
+	}
+	t := &replayTransport{dir: dir, replayed: make([]bool, len(files))}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to read cassette interaction")
+		}
+		interaction := &Interaction{}
+		if err = json.Unmarshal(content, interaction); err != nil {
+			return nil, errors.Wrapf(err, "Invalid cassette interaction %s", filepath.Base(file))
+		}
+		t.interactions = append(t.interactions, interaction)
+	}
+	return t, nil
+}
+
+// RoundTrip answers with the first interaction of the cassette with the same method and URL which was not replayed
+// yet, so that the retries and the successive calls to the same URL are replayed in order
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for i, interaction := range t.interactions {
+		if t.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.Url != req.URL.RequestURI() {
+			continue
+		}
+		t.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, errors.Errorf("No recorded response left for %s %s in cassette %s", req.Method, req.URL.RequestURI(), t.dir)
+}
+
+// cassetteFiles returns the files of the interactions of the cassette in the order they were recorded
+func cassetteFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(filepath.Clean(dir), "*"+cassetteFileExtension))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list cassette interactions")
+	}
+	if _, err = os.Stat(dir); err != nil {
+		return nil, errors.Wrap(err, "Failed to open cassette")
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readBody reads the body and replaces it with a reader of the content read
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	content, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read body")
+	}
+	*body = io.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+// redactHeader returns a copy of the header with the values of the secret headers, e.g. the API key, redacted
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for name := range redactedHeader {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			redactedHeader[name] = []string{redacted}
+		}
+	}
+	// the trace context of the recording is not relevant to the replay
+	redactedHeader.Del(constants.HTTPHeaderKeyTraceParent)
+	return redactedHeader
+}
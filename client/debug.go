@@ -0,0 +1,215 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"intel/tac/v1/constants"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HTTP debug levels, each level logs the details of the previous ones
+const (
+	// DebugHTTPOff disables the HTTP debug logs
+	DebugHTTPOff = iota
+	// DebugHTTPRequests logs the method, URL, attempt, status and duration of each request
+	DebugHTTPRequests
+	// DebugHTTPHeaders logs the request and response headers
+	DebugHTTPHeaders
+	// DebugHTTPBodies logs the request and response bodies
+	DebugHTTPBodies
+)
+
+const (
+	redacted = "REDACTED"
+	// maxDebugBodySize bounds the size of the bodies logged, larger bodies are truncated
+	maxDebugBodySize = 64 * 1024
+)
+
+// redactedHeaders are the headers whose values are never logged
+var redactedHeaders = map[string]bool{
+	http.CanonicalHeaderKey(constants.HTTPHeaderKeyApiKey): true,
+	http.CanonicalHeaderKey("Authorization"):               true,
+	http.CanonicalHeaderKey("Proxy-Authorization"):         true,
+	http.CanonicalHeaderKey("Cookie"):                      true,
+	http.CanonicalHeaderKey("Set-Cookie"):                  true,
+}
+
+// redactedFields are the JSON fields whose values are never logged, e.g. the API keys of an api client
+var redactedFields = map[string]bool{"keys": true}
+
+// DebugOptions selects the HTTP debug logs written for each attempt of a request
+type DebugOptions struct {
+	// Level is one of the DebugHTTP levels
+	Level int
+	// Output is where the logs are written
+	Output io.Writer
+}
+
+// debugTransport logs the requests sent and the responses received, with the API keys redacted
+type debugTransport struct {
+	base  http.RoundTripper
+	opts  DebugOptions
+	mutex sync.Mutex
+}
+
+func newDebugTransport(base http.RoundTripper, opts DebugOptions) http.RoundTripper {
+	if opts.Level <= DebugHTTPOff || opts.Output == nil {
+		return base
+	}
+	return &debugTransport{base: base, opts: opts}
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var log bytes.Buffer
+	attempt := ""
+	if attempts, ok := req.Context().Value(attemptsKey{}).(*int32); ok && atomic.LoadInt32(attempts) > 1 {
+		attempt = fmt.Sprintf(" (retry %d)", atomic.LoadInt32(attempts)-1)
+	}
+	fmt.Fprintf(&log, "> %s %s%s\n", req.Method, req.URL.String(), attempt)
+	if t.opts.Level >= DebugHTTPHeaders {
+		writeHeaders(&log, ">", req.Header)
+	}
+	if t.opts.Level >= DebugHTTPBodies && req.Body != nil && req.Body != http.NoBody {
+		requestBody, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		// the request is not modified, the body read is sent with a copy of the request
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+		writeBody(&log, ">", requestBody)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(&log, "! %s %s failed after %s: %s\n", req.Method, req.URL.String(), elapsed, err.Error())
+		t.write(log.Bytes())
+		return resp, err
+	}
+
+	fmt.Fprintf(&log, "< %s (%s)\n", resp.Status, elapsed)
+	if t.opts.Level >= DebugHTTPHeaders {
+		writeHeaders(&log, "<", resp.Header)
+	}
+	if t.opts.Level >= DebugHTTPBodies && resp.Body != nil {
+		responseBody, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		// the body read is handed back to the caller along with the read error, if any
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(responseBody), errReader{readErr}))
+		writeBody(&log, "<", responseBody)
+	}
+	t.write(log.Bytes())
+	return resp, nil
+}
+
+// write writes the logs of a request at once so that the logs of concurrent requests are not interleaved
+func (t *debugTransport) write(log []byte) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	_, _ = t.opts.Output.Write(log)
+}
+
+func writeHeaders(w io.Writer, prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", prefix, name, value)
+	}
+}
+
+func writeBody(w io.Writer, prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	body = RedactBody(body)
+	truncated := ""
+	if len(body) > maxDebugBodySize {
+		body = body[:maxDebugBodySize]
+		truncated = fmt.Sprintf("\n%s ... truncated to %d bytes", prefix, maxDebugBodySize)
+	}
+	fmt.Fprintf(w, "%s\n%s%s\n", prefix, body, truncated)
+}
+
+// RedactBody returns the JSON body with the values of the secret fields, e.g. the keys of an api client, redacted.
+// Bodies which are not JSON are returned as is.
+func RedactBody(body []byte) []byte {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+	var redactedBody bytes.Buffer
+	encoder := json.NewEncoder(&redactedBody)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redactValue(value)); err != nil {
+		return body
+	}
+	return bytes.TrimSuffix(redactedBody.Bytes(), []byte("\n"))
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			if redactedFields[field] {
+				v[field] = redactSecret(fieldValue)
+			} else {
+				v[field] = redactValue(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
+// redactSecret redacts each value of a secret, keeping the number of values so that they can still be counted
+func redactSecret(value interface{}) interface{} {
+	if values, ok := value.([]interface{}); ok {
+		for i := range values {
+			values[i] = redacted
+		}
+		return values
+	}
+	if value == nil {
+		return nil
+	}
+	return redacted
+}
+
+// errReader returns the error met while reading a body once the part read has been consumed
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
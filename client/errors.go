@@ -0,0 +1,113 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"intel/tac/v1/constants"
+	"net/http"
+)
+
+// APIError is returned when Trust Authority answers a request with an error status code
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	// Body is the raw error payload returned by the server
+	Body []byte
+	// Payload is the error payload parsed from Body, nil if it is not a JSON object
+	Payload   map[string]interface{}
+	RequestId string
+	TraceId   string
+}
+
+// errorMessageKeys are the fields of the error payload which may hold the error message
+var errorMessageKeys = []string{"message", "error", "detail"}
+
+// newAPIError creates the error of a response with an error status code
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+		RequestId:  resp.Header.Get(constants.HTTPHeaderKeyRequestId),
+		TraceId:    resp.Header.Get(constants.HTTPHeaderKeyTraceId),
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Payload = payload
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("The call to %q returned %q. Error: %s", e.URL, e.Status, e.Body)
+}
+
+// Message returns the error message provided by the server, the raw payload if it does not hold one
+func (e *APIError) Message() string {
+	for _, key := range errorMessageKeys {
+		if message, ok := e.Payload[key].(string); ok && message != "" {
+			return message
+		}
+	}
+	return string(e.Body)
+}
+
+// AsAPIError returns the APIError wrapped by the error, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// HasStatus checks if the error is an APIError with one of the provided status codes
+func HasStatus(err error, statusCodes ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, statusCode := range statusCodes {
+		if apiErr.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound checks if the error is caused by a resource which does not exist
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict checks if the error is caused by a resource which already exists or was modified concurrently
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized checks if the error is caused by a missing or invalid API key, or by an API key which is not allowed
+// to perform the request
+func IsUnauthorized(err error) bool {
+	return HasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsBadRequest checks if the error is caused by a request rejected by the server as invalid
+func IsBadRequest(err error) bool {
+	return HasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsServerError checks if the error is caused by a server side failure, including rate limiting
+func IsServerError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"context"
+	"intel/tac/v1/constants"
+	"net/http"
+)
+
+// RequestMetadata holds the request ID and W3C traceparent sent with a request and, once the response is received,
+// the request and trace IDs returned by Trust Authority. A RequestMetadata is meant to be used by a single request at
+// a time.
+type RequestMetadata struct {
+	RequestId   string
+	TraceParent string
+	TraceId     string
+	// Received is set once a response was received, the request was not sent when it is served from the cache or
+	// fails beforehand
+	Received bool
+}
+
+type requestMetadataKey struct{}
+
+// WithRequestMetadata returns a context carrying the metadata of the request sent with it. The request ID of the
+// metadata is sent with the request and the request and trace IDs returned by the server are stored in it.
+func WithRequestMetadata(ctx context.Context, md *RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, md)
+}
+
+// RequestMetadataFromContext returns the metadata carried by the context, nil if there is none
+func RequestMetadataFromContext(ctx context.Context) *RequestMetadata {
+	md, _ := ctx.Value(requestMetadataKey{}).(*RequestMetadata)
+	return md
+}
+
+// setRequestHeaders sets the request ID and traceparent headers of the request from the metadata carried by its
+// context
+func setRequestHeaders(req *http.Request) {
+	md := RequestMetadataFromContext(req.Context())
+	if md == nil {
+		return
+	}
+	if md.RequestId != "" {
+		req.Header.Set(constants.HTTPHeaderKeyRequestId, md.RequestId)
+	}
+	if md.TraceParent != "" {
+		req.Header.Set(constants.HTTPHeaderKeyTraceParent, md.TraceParent)
+	}
+}
+
+// storeResponseIds stores the request and trace IDs returned by the server in the metadata carried by the context of
+// the request
+func storeResponseIds(req *http.Request, resp *http.Response) {
+	md := RequestMetadataFromContext(req.Context())
+	if md == nil {
+		return
+	}
+	if requestId := resp.Header.Get(constants.HTTPHeaderKeyRequestId); requestId != "" {
+		md.RequestId = requestId
+	}
+	md.TraceId = resp.Header.Get(constants.HTTPHeaderKeyTraceId)
+	md.Received = true
+}
@@ -7,24 +7,31 @@ package pms
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	models2 "intel/tac/v1/internal/models"
 	"intel/tac/v1/models"
 	"net/http"
 	"net/url"
 )
 
+// PmsClient is the client of the Trust Authority PMS APIs. The WithContext variants of the methods send the request
+// with the provided context so that it can be cancelled, the other ones use the background context.
 type PmsClient interface {
 	CreatePolicy(policyRequest *models.PolicyRequest) (*models.PolicyResponse, error)
+	CreatePolicyWithContext(ctx context.Context, policyRequest *models.PolicyRequest) (*models.PolicyResponse, error)
 	DeletePolicy(policyID uuid.UUID) error
+	DeletePolicyWithContext(ctx context.Context, policyID uuid.UUID) error
 	GetPolicy(policyID uuid.UUID) (*models.PolicyResponse, error)
+	GetPolicyWithContext(ctx context.Context, policyID uuid.UUID) (*models.PolicyResponse, error)
 	UpdatePolicy(request *models.PolicyUpdateRequest) (*models.PolicyResponse, error)
+	UpdatePolicyWithContext(ctx context.Context, request *models.PolicyUpdateRequest) (*models.PolicyResponse, error)
 	SearchPolicy() ([]models.PolicyResponse, error)
+	SearchPolicyWithContext(ctx context.Context) ([]models.PolicyResponse, error)
 }
 
 // Client Details for PMS client
@@ -43,6 +50,10 @@ func NewPmsClient(client *http.Client, pmsURL *url.URL, apiKey string) PmsClient
 }
 
 func (pc pmsClient) CreatePolicy(request *models.PolicyRequest) (*models.PolicyResponse, error) {
+	return pc.CreatePolicyWithContext(context.Background(), request)
+}
+
+func (pc pmsClient) CreatePolicyWithContext(ctx context.Context, request *models.PolicyRequest) (*models.PolicyResponse, error) {
 	reqBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error marshalling request")
@@ -54,14 +65,13 @@ func (pc pmsClient) CreatePolicy(request *models.PolicyRequest) (*models.PolicyR
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, " Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -81,6 +91,10 @@ func (pc pmsClient) CreatePolicy(request *models.PolicyRequest) (*models.PolicyR
 }
 
 func (pc pmsClient) DeletePolicy(policyID uuid.UUID) error {
+	return pc.DeletePolicyWithContext(context.Background(), policyID)
+}
+
+func (pc pmsClient) DeletePolicyWithContext(ctx context.Context, policyID uuid.UUID) error {
 
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.PolicyApiEndpoint + "/" + policyID.String())
 	if err != nil {
@@ -88,12 +102,11 @@ func (pc pmsClient) DeletePolicy(policyID uuid.UUID) error {
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodDelete, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, reqURL.String(), nil)
 	if err != nil {
 		return errors.Wrap(err, " Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	_, err = client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -104,6 +117,10 @@ func (pc pmsClient) DeletePolicy(policyID uuid.UUID) error {
 }
 
 func (pc pmsClient) GetPolicy(policyID uuid.UUID) (*models.PolicyResponse, error) {
+	return pc.GetPolicyWithContext(context.Background(), policyID)
+}
+
+func (pc pmsClient) GetPolicyWithContext(ctx context.Context, policyID uuid.UUID) (*models.PolicyResponse, error) {
 
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.PolicyApiEndpoint + "/" + policyID.String())
 	if err != nil {
@@ -113,13 +130,12 @@ func (pc pmsClient) GetPolicy(policyID uuid.UUID) (*models.PolicyResponse, error
 	log.Debugf("PMS Request URL: %s", reqURL)
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -138,6 +154,10 @@ func (pc pmsClient) GetPolicy(policyID uuid.UUID) (*models.PolicyResponse, error
 }
 
 func (pc pmsClient) SearchPolicy() ([]models.PolicyResponse, error) {
+	return pc.SearchPolicyWithContext(context.Background())
+}
+
+func (pc pmsClient) SearchPolicyWithContext(ctx context.Context) ([]models.PolicyResponse, error) {
 
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.PolicyApiEndpoint)
 	if err != nil {
@@ -145,13 +165,12 @@ func (pc pmsClient) SearchPolicy() ([]models.PolicyResponse, error) {
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -170,6 +189,10 @@ func (pc pmsClient) SearchPolicy() ([]models.PolicyResponse, error) {
 }
 
 func (pc pmsClient) UpdatePolicy(request *models.PolicyUpdateRequest) (*models.PolicyResponse, error) {
+	return pc.UpdatePolicyWithContext(context.Background(), request)
+}
+
+func (pc pmsClient) UpdatePolicyWithContext(ctx context.Context, request *models.PolicyUpdateRequest) (*models.PolicyResponse, error) {
 	reqBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error marshalling policy update request")
@@ -183,14 +206,13 @@ func (pc pmsClient) UpdatePolicy(request *models.PolicyUpdateRequest) (*models.P
 	log.Debugf("PMS Request URL: %s", reqURL)
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, " Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	rClient "github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"intel/tac/v1/constants"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryOptions holds the retry policy applied to the requests sent to Trust Authority
+type RetryOptions struct {
+	// Max is the maximum number of retries, zero disables retries
+	Max int
+	// WaitMin and WaitMax bound the exponential backoff between retries
+	WaitMin time.Duration
+	WaitMax time.Duration
+	// Jitter randomizes the backoff to avoid retrying in lockstep with other clients
+	Jitter bool
+	// StatusCodes are the response status codes which are retried, rate limited (429) requests are retried in any case
+	StatusCodes []int
+	// NonIdempotent enables retrying POST and PATCH requests on errors which do not guarantee that the request
+	// was not processed. These are only retried without it when rate limited (429).
+	NonIdempotent bool
+}
+
+// retrySafeKey is the context key telling the retry policy if the request can be sent again on any error
+type retrySafeKey struct{}
+
+// retryTransport retries the requests with a retryablehttp client shared by all the requests of an HTTP client
+type retryTransport struct {
+	client *rClient.Client
+	opts   *RetryOptions
+}
+
+// ParseStatusCodes parses a comma separated list of HTTP status codes
+func ParseStatusCodes(statusCodes string) ([]int, error) {
+	var codes []int
+	for _, code := range strings.Split(statusCodes, ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		statusCode, err := strconv.Atoi(code)
+		if err != nil || statusCode < 100 || statusCode > 599 {
+			return nil, errors.Errorf("Invalid HTTP status code %q", code)
+		}
+		codes = append(codes, statusCode)
+	}
+	return codes, nil
+}
+
+// ValidateRetryOptions checks that the retry policy makes sense
+func ValidateRetryOptions(opts *RetryOptions) error {
+	if opts.Max < 0 {
+		return errors.Errorf("Invalid retry count %d, cannot be negative", opts.Max)
+	}
+	if opts.WaitMin < 0 || opts.WaitMax < 0 {
+		return errors.New("Retry wait durations cannot be negative")
+	}
+	if opts.WaitMax < opts.WaitMin {
+		return errors.Errorf("Maximum retry wait %s cannot be lower than the minimum retry wait %s", opts.WaitMax, opts.WaitMin)
+	}
+	return nil
+}
+
+func newRetryTransport(httpClient *http.Client, opts *RetryOptions) (*retryTransport, error) {
+	if err := ValidateRetryOptions(opts); err != nil {
+		return nil, err
+	}
+
+	retryableStatusCodes := map[int]bool{}
+	for _, statusCode := range opts.StatusCodes {
+		retryableStatusCodes[statusCode] = true
+	}
+
+	retryClient := rClient.NewClient()
+	retryClient.HTTPClient = httpClient
+	retryClient.RetryWaitMin = opts.WaitMin
+	retryClient.RetryWaitMax = opts.WaitMax
+	retryClient.RetryMax = opts.Max
+	retryClient.CheckRetry = newRetryPolicy(retryableStatusCodes)
+	retryClient.Backoff = newBackoff(opts.Jitter)
+	// the last response is returned as is once the retries are exhausted, so that the error returned by the API
+	// is reported
+	retryClient.ErrorHandler = rClient.PassthroughErrorHandler
+	retryClient.Logger = log.StandardLogger()
+
+	return &retryTransport{client: retryClient, opts: opts}, nil
+}
+
+func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retrySafe := rt.opts.NonIdempotent || (req.Method != http.MethodPost && req.Method != http.MethodPatch)
+	ctx := context.WithValue(req.Context(), retrySafeKey{}, retrySafe)
+	ctx = context.WithValue(ctx, attemptsKey{}, new(int32))
+
+	retryableReq, err := rClient.FromRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rt.client.Do(retryableReq)
+	if resp != nil && err != nil {
+		// the error only tells why the last response was retried, the response is returned so that the error
+		// reported by the API is not lost
+		return resp, nil
+	}
+	return resp, err
+}
+
+func newRetryPolicy(retryableStatusCodes map[int]bool) rClient.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		// Do not retry once the request is cancelled or the overall deadline is exceeded
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		// Rate limited requests were not processed, they are always retried whatever the retried status codes
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			return true, nil
+		}
+
+		if retrySafe, _ := ctx.Value(retrySafeKey{}).(bool); !retrySafe {
+			return false, nil
+		}
+
+		//Retry if the request did not reach the API gateway or timed out
+		if err != nil {
+			if v, ok := err.(*url.Error); ok {
+				if strings.ToLower(v.Error()) == constants.ServiceUnavailableError {
+					return true, v
+				}
+				if netErr, ok := v.Err.(net.Error); ok && netErr.Timeout() {
+					return true, v
+				}
+			}
+			return false, nil
+		}
+
+		// Check the response code. We retry on 500, 503 and 504 responses by default to allow
+		// the server time to recover, as these are typically not permanent
+		// errors and may relate to outages on the server side.
+		if ok := retryableStatusCodes[resp.StatusCode]; ok {
+			return true, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+		}
+		return false, nil
+	}
+}
+
+// newBackoff returns an exponential backoff honoring the Retry-After header of 429 and 503 responses. The wait
+// requested by the server is bounded by the maximum retry wait so that a command does not hang for hours.
+func newBackoff(jitter bool) rClient.Backoff {
+	return func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get(constants.HTTPHeaderKeyRetryAfter)); ok {
+				if retryAfter > max {
+					return max
+				}
+				return retryAfter
+			}
+		}
+
+		mult := math.Pow(2, float64(attemptNum)) * float64(min)
+		sleep := time.Duration(mult)
+		if float64(sleep) != mult || sleep > max {
+			sleep = max
+		}
+		if jitter && sleep > 0 {
+			// wait between half and the full backoff
+			sleep = sleep/2 + time.Duration(rand.Int63n(int64(sleep/2)+1))
+		}
+		return sleep
+	}
+}
+
+// parseRetryAfter parses the Retry-After header, either a number of seconds or an HTTP date
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"intel/tac/v1/constants"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// The spans and metrics are recorded with the global OpenTelemetry providers, they are not exported unless the
+// application installs providers, e.g. with the telemetry package
+const (
+	instrumentationName   = "intel/tac/v1/client"
+	requestDurationMetric = "trustauthority.http.client.duration"
+	requestRetriesMetric  = "trustauthority.http.client.retries"
+	requestIdAttributeKey = attribute.Key("trustauthority.request_id")
+	traceIdAttributeKey   = attribute.Key("trustauthority.trace_id")
+)
+
+// attemptsKey is the context key of the number of attempts made to send a request
+type attemptsKey struct{}
+
+// attemptTransport records a span for each attempt of a request, so that the retries show up as children of the
+// span of the request. The traceparent header of the attempt is sent when the span is recorded.
+type attemptTransport struct {
+	base http.RoundTripper
+}
+
+// startRequestSpan starts the span covering all the attempts of a request sent with SendRequest
+func startRequestSpan(req *http.Request) (*http.Request, trace.Span) {
+	ctx := req.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		// the request is part of the trace of the traceparent of its metadata when there is no span in progress
+		if md := RequestMetadataFromContext(ctx); md != nil && md.TraceParent != "" {
+			ctx = propagation.TraceContext{}.Extract(ctx,
+				propagation.MapCarrier{constants.HTTPHeaderKeyTraceParent: md.TraceParent})
+		}
+	}
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "HTTP "+req.Method,
+		trace.WithAttributes(requestAttributes(req)...))
+	if requestId := req.Header.Get(constants.HTTPHeaderKeyRequestId); requestId != "" {
+		span.SetAttributes(requestIdAttributeKey.String(requestId))
+	}
+	return req.WithContext(ctx), span
+}
+
+// endRequestSpan records the outcome of the request on its span and the request duration metric
+func endRequestSpan(req *http.Request, span trace.Span, start time.Time, statusCode int, err error) {
+	defer span.End()
+	attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(req.Method), semconv.ServerAddress(req.URL.Hostname())}
+	if statusCode != 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(statusCode))
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	}
+	if md := RequestMetadataFromContext(req.Context()); md != nil && md.TraceId != "" {
+		span.SetAttributes(traceIdAttributeKey.String(md.TraceId))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	histogram, histErr := otel.Meter(instrumentationName).Float64Histogram(requestDurationMetric,
+		metric.WithUnit("s"), metric.WithDescription("Duration of the requests sent to Trust Authority including their retries"))
+	if histErr == nil {
+		histogram.Record(req.Context(), time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	}
+}
+
+func (t *attemptTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempt := 0
+	if attempts, ok := req.Context().Value(attemptsKey{}).(*int32); ok {
+		attempt = int(atomic.AddInt32(attempts, 1)) - 1
+	}
+
+	ctx, span := otel.Tracer(instrumentationName).Start(req.Context(), "HTTP "+req.Method+" attempt",
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(requestAttributes(req)...))
+	defer span.End()
+	if attempt > 0 {
+		span.SetAttributes(semconv.HTTPResendCount(attempt))
+		recordRetry(ctx, req)
+	}
+	if span.IsRecording() {
+		// the request is not modified, the attempt is sent with its own traceparent
+		req = req.Clone(ctx)
+		propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+func recordRetry(ctx context.Context, req *http.Request) {
+	counter, err := otel.Meter(instrumentationName).Int64Counter(requestRetriesMetric,
+		metric.WithDescription("Number of retries of the requests sent to Trust Authority"))
+	if err == nil {
+		counter.Add(ctx, 1, metric.WithAttributes(semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname())))
+	}
+}
+
+func requestAttributes(req *http.Request) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.String()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+}
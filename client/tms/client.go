@@ -7,47 +7,71 @@ package tms
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	models2 "intel/tac/v1/internal/models"
 	"intel/tac/v1/models"
 	"net/http"
 	"net/url"
 )
 
+// TmsClient is the client of the Trust Authority TMS APIs. The WithContext variants of the methods send the request
+// with the provided context so that it can be cancelled, the other ones use the background context.
 type TmsClient interface {
 	CreateApiClient(request *models.CreateApiClient) (*models.ApiClientDetail, error)
+	CreateApiClientWithContext(ctx context.Context, request *models.CreateApiClient) (*models.ApiClientDetail, error)
 	UpdateApiClient(request *models.UpdateApiClient, apiClientid uuid.UUID) (*models.ApiClient, error)
+	UpdateApiClientWithContext(ctx context.Context, request *models.UpdateApiClient, apiClientid uuid.UUID) (*models.ApiClient, error)
 	GetApiClient(serviceId uuid.UUID) ([]models.ApiClient, error)
+	GetApiClientWithContext(ctx context.Context, serviceId uuid.UUID) ([]models.ApiClient, error)
 	RetrieveApiClient(serviceId uuid.UUID, apiClientId uuid.UUID) (*models.ApiClientDetail, error)
+	RetrieveApiClientWithContext(ctx context.Context, serviceId uuid.UUID, apiClientId uuid.UUID) (*models.ApiClientDetail, error)
 	GetApiClientPolicies(serviceId, apiClientId uuid.UUID) (*models.ApiClientPolicies, error)
+	GetApiClientPoliciesWithContext(ctx context.Context, serviceId, apiClientId uuid.UUID) (*models.ApiClientPolicies, error)
 	GetApiClientTagValues(serviceId, apiClientId uuid.UUID) (*models.ApiClientTags, error)
+	GetApiClientTagValuesWithContext(ctx context.Context, serviceId, apiClientId uuid.UUID) (*models.ApiClientTags, error)
 	DeleteApiClient(serviceId, apiClientId uuid.UUID) error
+	DeleteApiClientWithContext(ctx context.Context, serviceId, apiClientId uuid.UUID) error
 
 	GetServices() ([]models.Service, error)
+	GetServicesWithContext(ctx context.Context) ([]models.Service, error)
 	RetrieveService(serviceId uuid.UUID) (*models.ServiceDetail, error)
+	RetrieveServiceWithContext(ctx context.Context, serviceId uuid.UUID) (*models.ServiceDetail, error)
 
 	GetProducts(serviceOfferId uuid.UUID) ([]models.Product, error)
+	GetProductsWithContext(ctx context.Context, serviceOfferId uuid.UUID) ([]models.Product, error)
 
 	GetServiceOffers() ([]models.ServiceOffer, error)
+	GetServiceOffersWithContext(ctx context.Context) ([]models.ServiceOffer, error)
 
 	CreateUser(user *models.CreateTenantUser) (*models.TenantUser, error)
+	CreateUserWithContext(ctx context.Context, user *models.CreateTenantUser) (*models.TenantUser, error)
 	UpdateTenantUserRole(user *models.UpdateTenantUserRoles) (*models.TenantUser, error)
+	UpdateTenantUserRoleWithContext(ctx context.Context, user *models.UpdateTenantUserRoles) (*models.TenantUser, error)
 	GetUsers() ([]models.TenantUser, error)
+	GetUsersWithContext(ctx context.Context) ([]models.TenantUser, error)
 	DeleteUser(userId uuid.UUID) error
+	DeleteUserWithContext(ctx context.Context, userId uuid.UUID) error
 
 	CreateTenantTag(request *models.TagCreate) (*models.Tag, error)
+	CreateTenantTagWithContext(ctx context.Context, request *models.TagCreate) (*models.Tag, error)
 	GetTenantTags() (*models.Tags, error)
+	GetTenantTagsWithContext(ctx context.Context) (*models.Tags, error)
 	DeleteTenantTag(tagId uuid.UUID) error
+	DeleteTenantTagWithContext(ctx context.Context, tagId uuid.UUID) error
 
 	GetPlans(serviceOfferId uuid.UUID) ([]models.Plan, error)
+	GetPlansWithContext(ctx context.Context, serviceOfferId uuid.UUID) ([]models.Plan, error)
 	RetrievePlan(serviceOfferId, planId uuid.UUID) (*models.PlanProducts, error)
+	RetrievePlanWithContext(ctx context.Context, serviceOfferId, planId uuid.UUID) (*models.PlanProducts, error)
 
 	UpdateTenantSettings(settings *models.AttestationFailureEmail) (*models.AttestationFailureEmail, error)
+	UpdateTenantSettingsWithContext(ctx context.Context, settings *models.AttestationFailureEmail) (*models.AttestationFailureEmail, error)
 	GetTenantSettings() (*models.AttestationFailureEmail, error)
+	GetTenantSettingsWithContext(ctx context.Context) (*models.AttestationFailureEmail, error)
 }
 
 // Client Details for TMS client
@@ -66,6 +90,10 @@ func NewTmsClient(client *http.Client, tmsURL *url.URL, apiKey string) TmsClient
 }
 
 func (pc tmsClient) CreateApiClient(request *models.CreateApiClient) (*models.ApiClientDetail, error) {
+	return pc.CreateApiClientWithContext(context.Background(), request)
+}
+
+func (pc tmsClient) CreateApiClientWithContext(ctx context.Context, request *models.CreateApiClient) (*models.ApiClientDetail, error) {
 	reqBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error marshalling request")
@@ -78,14 +106,13 @@ func (pc tmsClient) CreateApiClient(request *models.CreateApiClient) (*models.Ap
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -104,6 +131,10 @@ func (pc tmsClient) CreateApiClient(request *models.CreateApiClient) (*models.Ap
 }
 
 func (pc tmsClient) UpdateApiClient(request *models.UpdateApiClient, apiClientId uuid.UUID) (*models.ApiClient, error) {
+	return pc.UpdateApiClientWithContext(context.Background(), request, apiClientId)
+}
+
+func (pc tmsClient) UpdateApiClientWithContext(ctx context.Context, request *models.UpdateApiClient, apiClientId uuid.UUID) (*models.ApiClient, error) {
 	reqBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error marshalling request")
@@ -116,14 +147,13 @@ func (pc tmsClient) UpdateApiClient(request *models.UpdateApiClient, apiClientId
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -142,6 +172,10 @@ func (pc tmsClient) UpdateApiClient(request *models.UpdateApiClient, apiClientId
 }
 
 func (pc tmsClient) GetApiClient(serviceId uuid.UUID) ([]models.ApiClient, error) {
+	return pc.GetApiClientWithContext(context.Background(), serviceId)
+}
+
+func (pc tmsClient) GetApiClientWithContext(ctx context.Context, serviceId uuid.UUID) ([]models.ApiClient, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceApiEndpoint + "/" +
 		serviceId.String() + constants.ApiClientResourceEndpoint)
 	if err != nil {
@@ -149,13 +183,12 @@ func (pc tmsClient) GetApiClient(serviceId uuid.UUID) ([]models.ApiClient, error
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -174,6 +207,10 @@ func (pc tmsClient) GetApiClient(serviceId uuid.UUID) ([]models.ApiClient, error
 }
 
 func (pc tmsClient) RetrieveApiClient(serviceId uuid.UUID, apiClientId uuid.UUID) (*models.ApiClientDetail, error) {
+	return pc.RetrieveApiClientWithContext(context.Background(), serviceId, apiClientId)
+}
+
+func (pc tmsClient) RetrieveApiClientWithContext(ctx context.Context, serviceId uuid.UUID, apiClientId uuid.UUID) (*models.ApiClientDetail, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceApiEndpoint + "/" +
 		serviceId.String() + constants.ApiClientResourceEndpoint + "/" + apiClientId.String())
 	if err != nil {
@@ -181,13 +218,12 @@ func (pc tmsClient) RetrieveApiClient(serviceId uuid.UUID, apiClientId uuid.UUID
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -206,6 +242,10 @@ func (pc tmsClient) RetrieveApiClient(serviceId uuid.UUID, apiClientId uuid.UUID
 }
 
 func (pc tmsClient) GetApiClientPolicies(serviceId, apiClientId uuid.UUID) (*models.ApiClientPolicies, error) {
+	return pc.GetApiClientPoliciesWithContext(context.Background(), serviceId, apiClientId)
+}
+
+func (pc tmsClient) GetApiClientPoliciesWithContext(ctx context.Context, serviceId, apiClientId uuid.UUID) (*models.ApiClientPolicies, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceApiEndpoint + "/" +
 		serviceId.String() + constants.ApiClientResourceEndpoint + "/" + apiClientId.String() + constants.PolicyApiEndpoint)
 	if err != nil {
@@ -213,13 +253,12 @@ func (pc tmsClient) GetApiClientPolicies(serviceId, apiClientId uuid.UUID) (*mod
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -238,6 +277,10 @@ func (pc tmsClient) GetApiClientPolicies(serviceId, apiClientId uuid.UUID) (*mod
 }
 
 func (pc tmsClient) GetApiClientTagValues(serviceId, apiClientId uuid.UUID) (*models.ApiClientTags, error) {
+	return pc.GetApiClientTagValuesWithContext(context.Background(), serviceId, apiClientId)
+}
+
+func (pc tmsClient) GetApiClientTagValuesWithContext(ctx context.Context, serviceId, apiClientId uuid.UUID) (*models.ApiClientTags, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceApiEndpoint + "/" +
 		serviceId.String() + constants.ApiClientResourceEndpoint + "/" + apiClientId.String() + constants.TagApiEndpoint)
 	if err != nil {
@@ -245,13 +288,12 @@ func (pc tmsClient) GetApiClientTagValues(serviceId, apiClientId uuid.UUID) (*mo
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -270,6 +312,10 @@ func (pc tmsClient) GetApiClientTagValues(serviceId, apiClientId uuid.UUID) (*mo
 }
 
 func (pc tmsClient) DeleteApiClient(serviceId, apiClientId uuid.UUID) error {
+	return pc.DeleteApiClientWithContext(context.Background(), serviceId, apiClientId)
+}
+
+func (pc tmsClient) DeleteApiClientWithContext(ctx context.Context, serviceId, apiClientId uuid.UUID) error {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceApiEndpoint + "/" +
 		serviceId.String() + constants.ApiClientResourceEndpoint + "/" + apiClientId.String())
 	if err != nil {
@@ -277,13 +323,12 @@ func (pc tmsClient) DeleteApiClient(serviceId, apiClientId uuid.UUID) error {
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodDelete, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, reqURL.String(), nil)
 	if err != nil {
 		return errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	_, err = client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -294,6 +339,10 @@ func (pc tmsClient) DeleteApiClient(serviceId, apiClientId uuid.UUID) error {
 }
 
 func (pc tmsClient) CreateUser(user *models.CreateTenantUser) (*models.TenantUser, error) {
+	return pc.CreateUserWithContext(context.Background(), user)
+}
+
+func (pc tmsClient) CreateUserWithContext(ctx context.Context, user *models.CreateTenantUser) (*models.TenantUser, error) {
 	reqBytes, err := json.Marshal(user)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error marshalling request")
@@ -305,14 +354,13 @@ func (pc tmsClient) CreateUser(user *models.CreateTenantUser) (*models.TenantUse
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -331,6 +379,10 @@ func (pc tmsClient) CreateUser(user *models.CreateTenantUser) (*models.TenantUse
 }
 
 func (pc tmsClient) UpdateTenantUserRole(request *models.UpdateTenantUserRoles) (*models.TenantUser, error) {
+	return pc.UpdateTenantUserRoleWithContext(context.Background(), request)
+}
+
+func (pc tmsClient) UpdateTenantUserRoleWithContext(ctx context.Context, request *models.UpdateTenantUserRoles) (*models.TenantUser, error) {
 	reqBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, errors.Wrap(err, " Error marshalling request")
@@ -343,14 +395,13 @@ func (pc tmsClient) UpdateTenantUserRole(request *models.UpdateTenantUserRoles)
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -369,19 +420,22 @@ func (pc tmsClient) UpdateTenantUserRole(request *models.UpdateTenantUserRoles)
 }
 
 func (pc tmsClient) GetUsers() ([]models.TenantUser, error) {
+	return pc.GetUsersWithContext(context.Background())
+}
+
+func (pc tmsClient) GetUsersWithContext(ctx context.Context) ([]models.TenantUser, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.UserApiEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -400,18 +454,21 @@ func (pc tmsClient) GetUsers() ([]models.TenantUser, error) {
 }
 
 func (pc tmsClient) DeleteUser(userId uuid.UUID) error {
+	return pc.DeleteUserWithContext(context.Background(), userId)
+}
+
+func (pc tmsClient) DeleteUserWithContext(ctx context.Context, userId uuid.UUID) error {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.UserApiEndpoint + "/" + userId.String())
 	if err != nil {
 		return errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodDelete, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, reqURL.String(), nil)
 	if err != nil {
 		return errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	_, err = client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -422,19 +479,22 @@ func (pc tmsClient) DeleteUser(userId uuid.UUID) error {
 }
 
 func (pc tmsClient) GetServices() ([]models.Service, error) {
+	return pc.GetServicesWithContext(context.Background())
+}
+
+func (pc tmsClient) GetServicesWithContext(ctx context.Context) ([]models.Service, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceApiEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -453,19 +513,22 @@ func (pc tmsClient) GetServices() ([]models.Service, error) {
 }
 
 func (pc tmsClient) RetrieveService(id uuid.UUID) (*models.ServiceDetail, error) {
+	return pc.RetrieveServiceWithContext(context.Background(), id)
+}
+
+func (pc tmsClient) RetrieveServiceWithContext(ctx context.Context, id uuid.UUID) (*models.ServiceDetail, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceApiEndpoint + "/" + id.String())
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -484,19 +547,22 @@ func (pc tmsClient) RetrieveService(id uuid.UUID) (*models.ServiceDetail, error)
 }
 
 func (pc tmsClient) GetProducts(serviceOfferId uuid.UUID) ([]models.Product, error) {
+	return pc.GetProductsWithContext(context.Background(), serviceOfferId)
+}
+
+func (pc tmsClient) GetProductsWithContext(ctx context.Context, serviceOfferId uuid.UUID) ([]models.Product, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceOfferApiEndpoint + "/" + serviceOfferId.String() + constants.ProductApiEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -515,19 +581,22 @@ func (pc tmsClient) GetProducts(serviceOfferId uuid.UUID) ([]models.Product, err
 }
 
 func (pc tmsClient) GetServiceOffers() ([]models.ServiceOffer, error) {
+	return pc.GetServiceOffersWithContext(context.Background())
+}
+
+func (pc tmsClient) GetServiceOffersWithContext(ctx context.Context) ([]models.ServiceOffer, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceOfferApiEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -546,6 +615,10 @@ func (pc tmsClient) GetServiceOffers() ([]models.ServiceOffer, error) {
 }
 
 func (pc tmsClient) CreateTenantTag(request *models.TagCreate) (*models.Tag, error) {
+	return pc.CreateTenantTagWithContext(context.Background(), request)
+}
+
+func (pc tmsClient) CreateTenantTagWithContext(ctx context.Context, request *models.TagCreate) (*models.Tag, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.TagApiEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
@@ -557,14 +630,13 @@ func (pc tmsClient) CreateTenantTag(request *models.TagCreate) (*models.Tag, err
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -583,19 +655,22 @@ func (pc tmsClient) CreateTenantTag(request *models.TagCreate) (*models.Tag, err
 }
 
 func (pc tmsClient) GetTenantTags() (*models.Tags, error) {
+	return pc.GetTenantTagsWithContext(context.Background())
+}
+
+func (pc tmsClient) GetTenantTagsWithContext(ctx context.Context) (*models.Tags, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.TagApiEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -614,18 +689,21 @@ func (pc tmsClient) GetTenantTags() (*models.Tags, error) {
 }
 
 func (pc tmsClient) DeleteTenantTag(tagId uuid.UUID) error {
+	return pc.DeleteTenantTagWithContext(context.Background(), tagId)
+}
+
+func (pc tmsClient) DeleteTenantTagWithContext(ctx context.Context, tagId uuid.UUID) error {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.TagApiEndpoint + "/" + tagId.String())
 	if err != nil {
 		return errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodDelete, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, reqURL.String(), nil)
 	if err != nil {
 		return errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	_, err = client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -636,6 +714,10 @@ func (pc tmsClient) DeleteTenantTag(tagId uuid.UUID) error {
 }
 
 func (pc tmsClient) GetPlans(serviceOfferId uuid.UUID) ([]models.Plan, error) {
+	return pc.GetPlansWithContext(context.Background(), serviceOfferId)
+}
+
+func (pc tmsClient) GetPlansWithContext(ctx context.Context, serviceOfferId uuid.UUID) ([]models.Plan, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceOfferApiEndpoint + "/" + serviceOfferId.String() +
 		constants.PlanApiEndpoint)
 	if err != nil {
@@ -643,13 +725,12 @@ func (pc tmsClient) GetPlans(serviceOfferId uuid.UUID) ([]models.Plan, error) {
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -668,6 +749,10 @@ func (pc tmsClient) GetPlans(serviceOfferId uuid.UUID) ([]models.Plan, error) {
 }
 
 func (pc tmsClient) RetrievePlan(serviceOfferId, planId uuid.UUID) (*models.PlanProducts, error) {
+	return pc.RetrievePlanWithContext(context.Background(), serviceOfferId, planId)
+}
+
+func (pc tmsClient) RetrievePlanWithContext(ctx context.Context, serviceOfferId, planId uuid.UUID) (*models.PlanProducts, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.ServiceOfferApiEndpoint + "/" + serviceOfferId.String() +
 		constants.PlanApiEndpoint + "/" + planId.String())
 	if err != nil {
@@ -675,13 +760,12 @@ func (pc tmsClient) RetrievePlan(serviceOfferId, planId uuid.UUID) (*models.Plan
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -700,6 +784,10 @@ func (pc tmsClient) RetrievePlan(serviceOfferId, planId uuid.UUID) (*models.Plan
 }
 
 func (pc tmsClient) UpdateTenantSettings(request *models.AttestationFailureEmail) (*models.AttestationFailureEmail, error) {
+	return pc.UpdateTenantSettingsWithContext(context.Background(), request)
+}
+
+func (pc tmsClient) UpdateTenantSettingsWithContext(ctx context.Context, request *models.AttestationFailureEmail) (*models.AttestationFailureEmail, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.TenantsApiEndpoint + constants.SettingsEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
@@ -711,14 +799,13 @@ func (pc tmsClient) UpdateTenantSettings(request *models.AttestationFailureEmail
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL.String(), bytes.NewBuffer(reqBytes))
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
@@ -737,19 +824,22 @@ func (pc tmsClient) UpdateTenantSettings(request *models.AttestationFailureEmail
 }
 
 func (pc tmsClient) GetTenantSettings() (*models.AttestationFailureEmail, error) {
+	return pc.GetTenantSettingsWithContext(context.Background())
+}
+
+func (pc tmsClient) GetTenantSettingsWithContext(ctx context.Context) (*models.AttestationFailureEmail, error) {
 	reqURL, err := url.Parse(pc.BaseURL.String() + constants.TenantsApiEndpoint + constants.SettingsEndpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Invalid URL %s", pc.BaseURL.String())
 	}
 
 	// Create a new request using http
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error forming request")
 	}
 	req.Header.Add(constants.HTTPHeaderKeyAccept, constants.HTTPMediaTypeJson)
 	req.Header.Add(constants.HTTPHeaderKeyApiKey, pc.ApiKey)
-	req.Header.Add(constants.HTTPHeaderKeyRequestId, models2.RespHeaderFields.RequestId)
 
 	response, err := client.SendRequest(pc.Client, req)
 	if err != nil {
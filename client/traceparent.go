@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+const (
+	traceParentVersion = "00"
+	// traceFlagSampled is the flag of the W3C trace context telling that the caller may record the trace
+	traceFlagSampled = 0x01
+)
+
+// TraceParent is the W3C trace context sent to Trust Authority with the traceparent header, see
+// https://www.w3.org/TR/trace-context/#traceparent-header
+type TraceParent struct {
+	TraceId  [16]byte
+	ParentId [8]byte
+	Flags    byte
+}
+
+// NewTraceParent starts a new sampled trace
+func NewTraceParent() TraceParent {
+	tp := TraceParent{Flags: traceFlagSampled}
+	_, _ = rand.Read(tp.TraceId[:])
+	_, _ = rand.Read(tp.ParentId[:])
+	return tp
+}
+
+// ParseTraceParent parses the value of a traceparent header, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func ParseTraceParent(traceParent string) (TraceParent, error) {
+	var tp TraceParent
+	fields := strings.Split(strings.TrimSpace(traceParent), "-")
+	// future versions may append fields, version ff is invalid
+	var version [1]byte
+	if len(fields) < 4 || decodeHex(fields[0], version[:]) != nil || version[0] == 0xff {
+		return tp, errors.Errorf("Invalid traceparent %q, should be in the version-traceid-parentid-flags format", traceParent)
+	}
+	if fields[0] == traceParentVersion && len(fields) != 4 {
+		return tp, errors.Errorf("Invalid traceparent %q, unexpected fields for version %s", traceParent, traceParentVersion)
+	}
+	if err := decodeHex(fields[1], tp.TraceId[:]); err != nil || tp.TraceId == [16]byte{} {
+		return tp, errors.Errorf("Invalid trace id in traceparent %q", traceParent)
+	}
+	if err := decodeHex(fields[2], tp.ParentId[:]); err != nil || tp.ParentId == [8]byte{} {
+		return tp, errors.Errorf("Invalid parent id in traceparent %q", traceParent)
+	}
+	var flags [1]byte
+	if err := decodeHex(fields[3], flags[:]); err != nil {
+		return tp, errors.Errorf("Invalid trace flags in traceparent %q", traceParent)
+	}
+	tp.Flags = flags[0]
+	return tp, nil
+}
+
+// NewChild returns the trace context of a span started by the holder of the trace context, in the same trace
+func (tp TraceParent) NewChild() TraceParent {
+	child := tp
+	_, _ = rand.Read(child.ParentId[:])
+	return child
+}
+
+// TraceIdString returns the hex encoded trace id
+func (tp TraceParent) TraceIdString() string {
+	return hex.EncodeToString(tp.TraceId[:])
+}
+
+func (tp TraceParent) String() string {
+	return traceParentVersion + "-" + hex.EncodeToString(tp.TraceId[:]) + "-" + hex.EncodeToString(tp.ParentId[:]) +
+		"-" + hex.EncodeToString([]byte{tp.Flags})
+}
+
+// decodeHex decodes the lowercase hex string into the buffer, the string has to fill the buffer exactly
+func decodeHex(s string, buf []byte) error {
+	if len(s) != hex.EncodedLen(len(buf)) || strings.ToLower(s) != s {
+		return errors.New("Invalid length or case")
+	}
+	_, err := hex.Decode(buf, []byte(s))
+	return err
+}
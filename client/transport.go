@@ -0,0 +1,195 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+	"intel/tac/v1/validation"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TransportOptions holds the TLS and proxy settings used to reach Trust Authority
+type TransportOptions struct {
+	// Timeout of each attempt of a request, no timeout is set when it is zero
+	Timeout time.Duration
+	// Deadline bounds the time spent on a request including all its retries, no deadline is set when it is zero
+	Deadline time.Duration
+	// Retry is the retry policy of the requests
+	Retry RetryOptions
+	// CaBundle is the path of a PEM file holding CA certificates trusted in addition to the system ones
+	CaBundle string
+	// ClientCert and ClientKey are the paths of the PEM encoded certificate and key used for mTLS
+	ClientCert string
+	ClientKey  string
+	// Proxy is the URL of the proxy used for all requests, the HTTPS_PROXY env variable is used when it is empty
+	Proxy string
+	// NoProxy is a comma separated list of hosts, domains and CIDRs which are reached directly
+	NoProxy string
+	// MinTLSVersion is the minimum TLS version accepted, one of 1.0, 1.1, 1.2 or 1.3
+	MinTLSVersion string
+	// Debug selects the HTTP debug logs written for each attempt of a request, they are disabled by default
+	Debug DebugOptions
+	// RecordDir is the cassette directory each request and its response are stored in, with the secrets redacted
+	RecordDir string
+	// ReplayDir is the cassette directory the responses are read from instead of sending the requests
+	ReplayDir string
+}
+
+// NewHTTPClient returns the HTTP client configured with the TLS, proxy and retry settings. It is the single place
+// where HTTP clients are built so that every request to Trust Authority is sent with the same settings. The client
+// is meant to be reused for all the requests of a command.
+func NewHTTPClient(opts *TransportOptions) (*http.Client, error) {
+	transport, err := NewTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := newCassetteTransport(transport, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	retry, err := newRetryTransport(&http.Client{
+		Timeout:   opts.Timeout,
+		Transport: &attemptTransport{base: newDebugTransport(base, opts.Debug)},
+	}, &opts.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Timeout:   opts.Deadline,
+		Transport: retry,
+	}, nil
+}
+
+// NewTransport returns the HTTP transport configured with the TLS and proxy settings
+func NewTransport(opts *TransportOptions) (*http.Transport, error) {
+	tlsConfig, err := newTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := newProxyFunc(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = proxy
+	return transport, nil
+}
+
+// newCassetteTransport returns the transport recording the requests to or replaying them from a cassette when
+// requested, otherwise the transport is returned as is
+func newCassetteTransport(transport http.RoundTripper, opts *TransportOptions) (http.RoundTripper, error) {
+	switch {
+	case opts.RecordDir != "" && opts.ReplayDir != "":
+		return nil, validation.NewInputError(errors.New("Requests cannot be recorded and replayed at the same time"))
+	case opts.RecordDir != "":
+		return newRecordTransport(transport, opts.RecordDir)
+	case opts.ReplayDir != "":
+		return newReplayTransport(opts.ReplayDir)
+	}
+	return transport, nil
+}
+
+// ValidateTLSVersion checks if the TLS version is supported
+func ValidateTLSVersion(version string) error {
+	if _, ok := tlsVersions[version]; !ok {
+		return errors.Errorf("Unsupported minimum TLS version %q, should be one of 1.0, 1.1, 1.2, 1.3", version)
+	}
+	return nil
+}
+
+func newTLSConfig(opts *TransportOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if opts.MinTLSVersion != "" {
+		if err := ValidateTLSVersion(opts.MinTLSVersion); err != nil {
+			return nil, err
+		}
+		tlsConfig.MinVersion = tlsVersions[opts.MinTLSVersion]
+	}
+
+	if opts.CaBundle != "" {
+		path, err := validation.ValidatePath(opts.CaBundle)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid CA bundle path provided")
+		}
+		caBundle, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading CA bundle")
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, errors.Errorf("No PEM encoded certificate found in CA bundle %s", opts.CaBundle)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		if opts.ClientCert == "" || opts.ClientKey == "" {
+			return nil, errors.New("Both the client certificate and key need to be provided for mTLS")
+		}
+		certPath, err := validation.ValidatePath(opts.ClientCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid client certificate path provided")
+		}
+		keyPath, err := validation.ValidatePath(opts.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid client key path provided")
+		}
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error loading client certificate and key")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// newProxyFunc returns the proxy selection function. The proxy and no proxy settings provided take precedence over
+// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY env variables.
+func newProxyFunc(opts *TransportOptions) (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if opts.Proxy != "" {
+		proxyUrl, err := url.Parse(opts.Proxy)
+		if err != nil || proxyUrl.Host == "" {
+			return nil, errors.Errorf("Invalid proxy URL %q provided", opts.Proxy)
+		}
+		proxyConfig.HTTPProxy = opts.Proxy
+		proxyConfig.HTTPSProxy = opts.Proxy
+	}
+	if opts.NoProxy != "" {
+		proxyConfig.NoProxy = strings.ReplaceAll(opts.NoProxy, " ", "")
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
@@ -7,44 +7,46 @@ package client
 
 import (
 	"context"
-	"fmt"
-	rClient "github.com/hashicorp/go-retryablehttp"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/internal/models"
 	"io"
 	"net/http"
-	"net/url"
-	"strings"
 	"time"
 )
 
-var (
-	retryableStatusCode = map[int]bool{
-		500: true,
-		503: true,
-		504: true,
-	}
-)
+// ErrCancelled is returned when the request is cancelled, e.g. with Ctrl-C, before it completed
+var ErrCancelled = errors.New("Request cancelled")
 
+// SendRequest sends the request with the HTTP client, retrying it according to the retry policy of the client, and
+// returns the response body when the request succeeded. An APIError is returned when the server answers with an error
+// status code. The request ID and traceparent of the RequestMetadata carried by the request context are sent and the
+// IDs returned by the server are stored in it. A span is recorded for the request, with a child span for each
+// attempt, when OpenTelemetry is set up.
 func SendRequest(client *http.Client, req *http.Request) ([]byte, error) {
-	var resp *http.Response
-	var err error
+	setRequestHeaders(req)
+	req, span := startRequestSpan(req)
+	start := time.Now()
 
-	// set the request Id to the provided in case there is an error while sending and receiving request
-	models.RespHeaderFields.RequestId = req.Header.Get(constants.HTTPHeaderKeyRequestId)
+	body, statusCode, err := sendRequest(client, req)
+	endRequestSpan(req, span, start, statusCode, err)
+	return body, err
+}
 
-	var retryClient = rClient.NewClient()
-	retryClient.HTTPClient = client
-	retryClient.RetryWaitMin = constants.DefaultRetryWaitMin * time.Second
-	retryClient.RetryWaitMax = constants.DefaultRetryWaitMax * time.Second
-	retryClient.RetryMax = constants.DefaultRetryCount
-	retryClient.CheckRetry = retryPolicy
-	retryClient.Logger = log.StandardLogger()
+// sendRequest sends the request and returns the response body and status code
+func sendRequest(client *http.Client, req *http.Request) ([]byte, int, error) {
+	var resp *http.Response
+	var err error
 
-	if resp, err = retryClient.StandardClient().Do(req); err != nil {
-		return nil, err
+	if resp, err = client.Do(req); err != nil {
+		// report cancellations clearly rather than as a failure of the last attempt
+		switch req.Context().Err() {
+		case context.Canceled:
+			return nil, 0, ErrCancelled
+		case context.DeadlineExceeded:
+			return nil, 0, errors.Wrap(err, "Request deadline exceeded")
+		}
+		return nil, 0, err
 	}
 
 	if resp != nil {
@@ -56,47 +58,16 @@ func SendRequest(client *http.Client, req *http.Request) ([]byte, error) {
 		}()
 	}
 
-	//Get the request and trace ID from response header
-	models.RespHeaderFields.RequestId = resp.Header.Get(constants.HTTPHeaderKeyRequestId)
-	models.RespHeaderFields.TraceId = resp.Header.Get(constants.HTTPHeaderKeyTraceId)
+	storeResponseIds(req, resp)
 
 	//create byte array of HTTP response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error reading response")
+		return nil, resp.StatusCode, errors.Wrap(err, "Error reading response")
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-		return nil, errors.Errorf("The call to %q returned %q. Error: %s", req.URL, resp.Status, body)
-	}
-	return body, nil
-}
-
-func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
-	// Do not retry on context.Canceled
-	if ctx.Err() != nil {
-		// If connection was closed due to client timeout retry again
-		if ctx.Err() == context.DeadlineExceeded {
-			return true, ctx.Err()
-		}
-		return false, nil
-	}
-
-	//Retry if the request did not reach the API gateway and the error is Service Unavailable
-	if err != nil {
-		if v, ok := err.(*url.Error); ok {
-			if strings.ToLower(v.Error()) == constants.ServiceUnavailableError {
-				return true, v
-			}
-		}
-		return false, nil
-	}
-
-	// Check the response code. We retry on 500, 503 and 504 responses to allow
-	// the server time to recover, as these are typically not permanent
-	// errors and may relate to outages on the server side.
-	if ok := retryableStatusCode[resp.StatusCode]; ok {
-		return true, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+		return nil, resp.StatusCode, newAPIError(req, resp, body)
 	}
-	return false, nil
+	return body, resp.StatusCode, nil
 }
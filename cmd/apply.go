@@ -0,0 +1,107 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/manifest"
+	"intel/tac/v1/sdk"
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   constants.ApplyCmd,
+	Short: "Create or update the tenant resources described by a manifest",
+	Long: `Create or update the tenant resources described by a YAML manifest, one resource per document:
+
+  kind: Policy
+  metadata:
+    name: sgx-policy
+  spec:
+    service_offer: Attestation
+    policy_type: Appraisal policy
+    attestation_type: SGX Attestation
+    policy_file: sgx-policy.rego
+  ---
+  kind: ApiClient
+  metadata:
+    name: ci-client
+  spec:
+    service: Attestation
+    product: Developer
+    policies: [sgx-policy]
+    tags: [{key: Workload, value: WorkloadAI}]
+
+The kinds are Policy, Tag, User (named by its email, with a role), ApiClient and TenantSettings (with an
+attest_failure_email). Resources reference each other by name, services, service offers and products may be
+referenced by ID as well. Missing resources are created and changed ones are updated, resources which are not in the
+manifest are left untouched. A result is printed per resource and the command fails when one of them failed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Info("apply called")
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := applyManifest(cmd, md)
+		if err != nil {
+			return err
+		}
+		if err = printResponse(cmd, response); err != nil {
+			return err
+		}
+		failed := 0
+		for _, result := range response {
+			if result.Result == manifest.ResultFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			// the results already tell which resources failed, the usage would only hide them
+			cmd.SilenceUsage = true
+			return errors.Errorf("%d of %d resources failed to apply", failed, len(response))
+		}
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringP(constants.FilenameParamName, "f", "", "Path of the manifest file describing the tenant resources")
+	applyCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
+	applyCmd.MarkFlagRequired(constants.FilenameParamName)
+}
+
+func applyManifest(cmd *cobra.Command, md *client.RequestMetadata) ([]manifest.Result, error) {
+	filename, err := cmd.Flags().GetString(constants.FilenameParamName)
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifest.Load(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	taClient, err := newTrustAuthorityClient()
+	if err != nil {
+		return nil, err
+	}
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
+	}
+
+	state, err := manifest.FetchState(cmd.Context(), taClient, sdk.Metadata(md))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to retrieve the tenant resources")
+	}
+	changes, err := manifest.Plan(m, state)
+	if err != nil {
+		return nil, err
+	}
+	return manifest.Apply(cmd.Context(), taClient, state, changes, sdk.Metadata(md)), nil
+}
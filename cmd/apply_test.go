@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/manifest"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const applyManifestFile = `
+kind: Policy
+metadata:
+  name: sgx-policy
+spec:
+  service_offer: Attestation
+  policy_type: Appraisal policy
+  attestation_type: SGX Attestation
+  policy: default matches_sgx_policy = false
+---
+kind: ApiClient
+metadata:
+  name: ci-client
+spec:
+  service: Attestation
+  product: Developer
+  policies: [sgx-policy]
+`
+
+func TestApplyCmd(t *testing.T) {
+	useStatefulMockServer(t, nil)
+
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "tenant.yaml")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(applyManifestFile), 0600))
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalidFile, []byte("kind: Secret\nmetadata:\n  name: secret"), 0600))
+	failingFile := filepath.Join(dir, "failing.yaml")
+	assert.NoError(t, os.WriteFile(failingFile, []byte("kind: User\nmetadata:\n  name: user@example.com\nspec:\n  role: Owner"), 0600))
+
+	tt := []struct {
+		args        []string
+		wantErr     bool
+		wantResults []string
+		description string
+	}{
+		{
+			args:        []string{constants.ApplyCmd, "-f", manifestFile, "-q", "apply-test", "-o", "json"},
+			wantResults: []string{manifest.ResultCreated, manifest.ResultCreated},
+			description: "Test applying a manifest",
+		},
+		{
+			args:        []string{constants.ApplyCmd, "-f", manifestFile, "-q", "apply-test", "-o", "json"},
+			wantResults: []string{manifest.ResultUnchanged, manifest.ResultUnchanged},
+			description: "Test applying a manifest again",
+		},
+		{
+			args:        []string{constants.ApplyCmd, "-f", failingFile, "-q", "apply-test", "-o", "json"},
+			wantErr:     true,
+			wantResults: []string{manifest.ResultFailed},
+			description: "Test applying a manifest with a resource failing",
+		},
+	}
+
+	for _, tc := range tt {
+		output, err := executeStdout(t, tenantCmd, tc.args)
+		if tc.wantErr {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+		var results []manifest.Result
+		assert.NoError(t, json.Unmarshal([]byte(output), &results), tc.description)
+		var actual []string
+		for _, result := range results {
+			actual = append(actual, result.Result)
+		}
+		assert.Equal(t, tc.wantResults, actual, tc.description)
+	}
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.ApplyCmd, "-f", invalidFile, "-q", "apply-test"})
+	assert.True(t, validation.IsInputError(err), "Test applying an invalid manifest")
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/cache"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// tenantKeyLength is the number of hex characters of the hash identifying the tenant in the cache keys
+const tenantKeyLength = 16
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   constants.CacheCmd,
+	Short: "Manages the local cache of the services, service offers, products, plans and tags",
+	Long: `The services, service offers, products, plans and tags seldom change, so they are cached under
+~/.config/trustauthorityctl/cache for each profile and reused until their TTL, set with the cache-ttl-* configuration
+keys, expires. The --no-cache flag skips the cache, --refresh fetches them again and --offline lists them from the
+cache without reaching Trust Authority.`,
+}
+
+// clearCacheCmd represents the cache clear command
+var clearCacheCmd = &cobra.Command{
+	Use:   constants.ClearCmd,
+	Short: "Removes the cached resources of the profile",
+	Long:  ``,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Info("cache clear called")
+		return clearCache(cmd)
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(clearCacheCmd)
+
+	clearCacheCmd.Flags().Bool(constants.AllParamName, false, "Remove the cached resources of all the profiles")
+}
+
+func clearCache(cmd *cobra.Command) error {
+	metadataCache, err := newCache()
+	if err != nil {
+		return err
+	}
+	all, err := cmd.Flags().GetBool(constants.AllParamName)
+	if err != nil {
+		return err
+	}
+	if all {
+		if err = metadataCache.Clear(); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "Cache of all the profiles cleared")
+		return nil
+	}
+
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	profileName := configFile.ActiveProfileName()
+	if err = metadataCache.Delete(profileName); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Cache of profile %q cleared\n", profileName)
+	return nil
+}
+
+// newCache returns the cache of the resources which seldom change, stored under the home directory
+func newCache() (*cache.Cache, error) {
+	userHomeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error fetching user home directory path")
+	}
+	return cache.New(filepath.Clean(userHomeDir + constants.CacheDir)), nil
+}
+
+// newCacheOptions returns the cache used by the Trust Authority client as selected with the --no-cache, --refresh
+// and --offline flags, or nil when nothing is cached
+func newCacheOptions(configValues *config.Configuration) (*sdk.CacheOptions, error) {
+	flags := tenantCmd.PersistentFlags()
+	noCache, _ := flags.GetBool(constants.NoCacheParamName)
+	refresh, _ := flags.GetBool(constants.RefreshParamName)
+	offline, _ := listCmd.PersistentFlags().GetBool(constants.OfflineParamName)
+	recordDir, _ := flags.GetString(constants.Record)
+	// the cassettes hold every request of the command, so the resources are not cached while recording or replaying
+	bypass := noCache || recordDir != "" || isReplaying()
+	if offline && (bypass || refresh) {
+		return nil, validation.NewInputError(errors.Errorf("--%s cannot be used with --%s, --%s, --%s or --%s",
+			constants.OfflineParamName, constants.NoCacheParamName, constants.RefreshParamName, constants.Record,
+			constants.Replay))
+	}
+	if bypass {
+		return nil, nil
+	}
+
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	metadataCache, err := newCache()
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.CacheOptions{
+		Cache:  metadataCache,
+		Prefix: configFile.ActiveProfileName() + "/" + tenantKey(configValues.TrustAuthorityBaseUrl, apiKey),
+		TTLs: map[string]time.Duration{
+			sdk.CachedServices:      configValues.CacheTTLServices,
+			sdk.CachedServiceOffers: configValues.CacheTTLServiceOffers,
+			sdk.CachedProducts:      configValues.CacheTTLProducts,
+			sdk.CachedPlans:         configValues.CacheTTLPlans,
+			sdk.CachedTags:          configValues.CacheTTLTags,
+		},
+		Refresh: refresh,
+		Offline: offline,
+	}, nil
+}
+
+// tenantKey identifies the tenant in the cache keys of a profile, so that overriding its URL or API key with the
+// flags or env variables never serves the resources of another tenant
+func tenantKey(baseUrl, apiKey string) string {
+	hash := sha256.Sum256([]byte(baseUrl + "\n" + apiKey))
+	return hex.EncodeToString(hash[:])[:tenantKeyLength]
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"strings"
+	"testing"
+)
+
+func TestCacheCmd(t *testing.T) {
+	server := useStatefulMockServer(t, nil)
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() {
+		assert.NoError(t, listCmd.PersistentFlags().Set(constants.OfflineParamName, "false"))
+		resetLocalFlags(t, clearCacheCmd)
+	})
+
+	listArgs := []string{constants.ListCmd, constants.ServiceOfferCmd, "-q", "cache-test"}
+	tt := []struct {
+		args         []string
+		flag         string
+		wantErr      bool
+		wantRequests int
+		description  string
+	}{
+		{
+			args:         listArgs,
+			wantRequests: 1,
+			description:  "Test listing the service offers which are not cached",
+		},
+		{
+			args:         listArgs,
+			wantRequests: 0,
+			description:  "Test listing the cached service offers",
+		},
+		{
+			args:         listArgs,
+			flag:         constants.RefreshParamName,
+			wantRequests: 1,
+			description:  "Test refreshing the cached service offers",
+		},
+		{
+			args:         listArgs,
+			flag:         constants.NoCacheParamName,
+			wantRequests: 1,
+			description:  "Test listing the service offers without cache",
+		},
+		{
+			args:         append(listArgs, "--"+constants.OfflineParamName),
+			wantRequests: 0,
+			description:  "Test listing the cached service offers offline",
+		},
+		{
+			args:         []string{constants.ListCmd, constants.PolicyCmd, "--" + constants.OfflineParamName},
+			wantErr:      true,
+			wantRequests: 0,
+			description:  "Test listing the policies offline",
+		},
+		{
+			args:         []string{constants.CacheCmd, constants.ClearCmd},
+			wantRequests: 0,
+			description:  "Test clearing the cache of the profile",
+		},
+		{
+			args:         append(listArgs, "--"+constants.OfflineParamName),
+			wantErr:      true,
+			wantRequests: 0,
+			description:  "Test listing the service offers offline once the cache is cleared",
+		},
+		{
+			args:         listArgs,
+			wantRequests: 1,
+			description:  "Test listing the service offers once the cache is cleared",
+		},
+		{
+			args:         []string{constants.CacheCmd, constants.ClearCmd, "--" + constants.AllParamName},
+			wantRequests: 0,
+			description:  "Test clearing the cache of all the profiles",
+		},
+	}
+
+	for _, tc := range tt {
+		server.requests.Store(0)
+		assert.NoError(t, listCmd.PersistentFlags().Set(constants.OfflineParamName, "false"))
+		if tc.flag != "" {
+			setGlobalFlag(t, tc.flag, "true")
+		}
+		_, err := executeStdout(t, tenantCmd, tc.args)
+		if tc.wantErr {
+			assert.True(t, errors.Is(err, sdk.ErrOffline), tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+		assert.Equal(t, tc.wantRequests, int(server.requests.Load()), tc.description)
+		if tc.flag != "" {
+			assert.NoError(t, tenantCmd.PersistentFlags().Set(tc.flag, "false"))
+		}
+	}
+
+	// the request ID is printed after the response, and only when a request was sent
+	output, err := execute(t, tenantCmd, listArgs)
+	assert.NoError(t, err)
+	assert.Greater(t, strings.Index(output, constants.HTTPHeaderKeyRequestId+":"), strings.Index(output, "Attestation"),
+		"Test printing the request ID after the response")
+	output, err = execute(t, tenantCmd, append(listArgs, "--"+constants.OfflineParamName))
+	assert.NoError(t, err)
+	assert.NotContains(t, output, constants.HTTPHeaderKeyRequestId+":", "Test listing offline without request ID")
+
+	setGlobalFlag(t, constants.NoCacheParamName, "true")
+	_, err = executeStdout(t, tenantCmd, append(listArgs, "--"+constants.OfflineParamName))
+	assert.True(t, validation.IsInputError(err), "Test listing offline without cache")
+}
@@ -0,0 +1,293 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"context"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"strings"
+	"time"
+)
+
+const (
+	bashShell = "bash"
+	zshShell  = "zsh"
+	fishShell = "fish"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   constants.CompletionCmd + " " + bashShell + "|" + zshShell + "|" + fishShell,
+	Short: "Generate the shell completion script",
+	Long: `Generate the completion script of the shell, which completes the commands and flags as well as the IDs of the
+services, service offers, products, plans, policies, users, tags and api clients of the tenant. The resources are
+listed with a short timeout and kept in a cache for a minute so that completing stays responsive.
+
+  bash: source <(trustauthorityctl completion bash)
+  zsh:  trustauthorityctl completion zsh > "${fpath[1]}/_trustauthorityctl"
+  fish: trustauthorityctl completion fish > ~/.config/fish/completions/trustauthorityctl.fish`,
+	ValidArgs: []string{bashShell, zshShell, fishShell},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case bashShell:
+			return tenantCmd.GenBashCompletionV2(cmd.OutOrStdout(), true)
+		case zshShell:
+			return tenantCmd.GenZshCompletion(cmd.OutOrStdout())
+		}
+		return tenantCmd.GenFishCompletion(cmd.OutOrStdout(), true)
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(completionCmd)
+	// the completion command above replaces the one generated by cobra
+	tenantCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+// completionFunc lists the completions of a flag, each one is a value optionally followed by a tab and its
+// description
+type completionFunc func(ctx context.Context, taClient *sdk.Client) ([]string, error)
+
+// complete returns the completions listed by the function, reusing the ones cached under the key for the active
+// profile when they are recent enough. No completion is returned when the tenant cannot be reached in time.
+func complete(cmd *cobra.Command, key string, list completionFunc) ([]string, cobra.ShellCompDirective) {
+	completions, err := cachedCompletions(cmd, key, list)
+	if err != nil {
+		log.WithError(err).Debug("Failed to list the completions of " + key)
+		cobra.CompDebugln(err.Error(), true)
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func cachedCompletions(cmd *cobra.Command, key string, list completionFunc) ([]string, error) {
+	// the flags are parsed by cobra once the completion command started, so the configuration is loaded again
+	config.SetActiveProfile(profile)
+	configFile, err := config.ReadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	configValues, err := config.LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	if err = validation.ValidateTrustAuthorityAPIKey(configValues.TrustAuthorityApiKey); err != nil {
+		return nil, err
+	}
+	apiKey = configValues.TrustAuthorityApiKey
+	completionCache, err := newCache()
+	if err != nil {
+		return nil, err
+	}
+	// the URL and API key can be overridden by flags and env variables, so the completions are cached per tenant
+	key = configFile.ActiveProfileName() + "/" + tenantKey(configValues.TrustAuthorityBaseUrl, apiKey) + "/completion/" + key
+
+	var completions []string
+	if completionCache.Get(key, constants.CompletionCacheTTL*time.Second, &completions) {
+		return completions, nil
+	}
+
+	taClient, err := newTrustAuthorityClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), constants.CompletionTimeout*time.Second)
+	defer cancel()
+	if completions, err = list(ctx, taClient); err != nil {
+		return nil, err
+	}
+	if err = completionCache.Set(key, completions); err != nil {
+		log.WithError(err).Debug("Failed to cache the completions of " + key)
+	}
+	return completions, nil
+}
+
+// completion formats the completion of a resource ID described by the name of the resource
+func completion(id uuid.UUID, name string) string {
+	return id.String() + "\t" + name
+}
+
+// completeServices completes the ID of a service of the tenant
+func completeServices(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete(cmd, "services", func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		services, err := taClient.ListServices(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var completions []string
+		for _, service := range services {
+			completions = append(completions, completion(service.ID, service.Name))
+		}
+		return completions, nil
+	})
+}
+
+// completeServiceOffers completes the ID of a service offer
+func completeServiceOffers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete(cmd, "service-offers", func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		serviceOffers, err := taClient.ListServiceOffers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var completions []string
+		for _, serviceOffer := range serviceOffers {
+			completions = append(completions, completion(serviceOffer.ID, serviceOffer.Name))
+		}
+		return completions, nil
+	})
+}
+
+// completeProducts completes the ID of a product of the service offer of the service provided with --service-id,
+// or of any service of the tenant
+func completeProducts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	serviceRef, _ := cmd.Flags().GetString(constants.ServiceIdParamName)
+	return complete(cmd, "products/"+serviceRef, func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		services, err := taClient.ListServices(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var serviceOfferIds []uuid.UUID
+		for _, service := range services {
+			if serviceRef == "" || serviceRef == service.ID.String() || serviceRef == service.Name {
+				serviceOfferIds = append(serviceOfferIds, service.ServiceOfferId)
+			}
+		}
+		return listProductCompletions(ctx, taClient, serviceOfferIds)
+	})
+}
+
+func listProductCompletions(ctx context.Context, taClient *sdk.Client, serviceOfferIds []uuid.UUID) ([]string, error) {
+	var completions []string
+	listed := map[uuid.UUID]bool{}
+	for _, serviceOfferId := range serviceOfferIds {
+		if listed[serviceOfferId] {
+			continue
+		}
+		listed[serviceOfferId] = true
+		products, err := taClient.ListProducts(ctx, serviceOfferId)
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range products {
+			completions = append(completions, completion(product.ID, product.Name))
+		}
+	}
+	return completions, nil
+}
+
+// completePlans completes the ID of a plan of the service offer provided with --service-offer-id, or of any
+// service offer
+func completePlans(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	serviceOfferRef, _ := cmd.Flags().GetString(constants.ServiceOfferIdParamName)
+	return complete(cmd, "plans/"+serviceOfferRef, func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		serviceOffers, err := taClient.ListServiceOffers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var completions []string
+		for _, serviceOffer := range serviceOffers {
+			if serviceOfferRef != "" && serviceOfferRef != serviceOffer.ID.String() {
+				continue
+			}
+			plans, err := taClient.ListPlans(ctx, serviceOffer.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, plan := range plans {
+				completions = append(completions, completion(plan.ID, plan.Name))
+			}
+		}
+		return completions, nil
+	})
+}
+
+// completePolicies completes the ID of a policy
+func completePolicies(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete(cmd, "policies", func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		policies, err := taClient.ListPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var completions []string
+		for _, policy := range policies {
+			completions = append(completions, completion(policy.PolicyId, policy.PolicyName))
+		}
+		return completions, nil
+	})
+}
+
+// completePolicyList completes the last ID of a comma separated list of policy IDs
+func completePolicyList(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	completions, directive := completePolicies(cmd, args, toComplete)
+	prefix := toComplete[:strings.LastIndex(toComplete, ",")+1]
+	for i := range completions {
+		completions[i] = prefix + completions[i]
+	}
+	return completions, directive
+}
+
+// completeUsers completes the ID of a user of the tenant
+func completeUsers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete(cmd, "users", func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		users, err := taClient.ListUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var completions []string
+		for _, user := range users {
+			completions = append(completions, completion(user.ID, user.Email))
+		}
+		return completions, nil
+	})
+}
+
+// completeTags completes the ID of a user defined tag of the tenant
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return complete(cmd, "tags", func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		tags, err := taClient.ListTags(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var completions []string
+		for _, tag := range tags.Tags {
+			if !tag.Predefined && tag.ID != nil {
+				completions = append(completions, completion(*tag.ID, tag.Name))
+			}
+		}
+		return completions, nil
+	})
+}
+
+// completeApiClients completes the ID of an api client of the service provided with --service-id
+func completeApiClients(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	serviceRef, _ := cmd.Flags().GetString(constants.ServiceIdParamName)
+	if serviceRef == "" {
+		cobra.CompDebugln("--"+constants.ServiceIdParamName+" is required to complete the api clients", true)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return complete(cmd, "api-clients/"+serviceRef, func(ctx context.Context, taClient *sdk.Client) ([]string, error) {
+		serviceId, err := sdk.NewResolver(taClient).ServiceId(ctx, serviceRef)
+		if err != nil {
+			return nil, err
+		}
+		apiClients, err := taClient.ListApiClients(ctx, serviceId)
+		if err != nil {
+			return nil, err
+		}
+		var completions []string
+		for _, apiClient := range apiClients {
+			completions = append(completions, completion(apiClient.ID, apiClient.Name))
+		}
+		return completions, nil
+	})
+}
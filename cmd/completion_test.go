@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/models"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCompletionCmd(t *testing.T) {
+	tt := []struct {
+		args        []string
+		wantErr     bool
+		wantOutput  string
+		description string
+	}{
+		{
+			args:        []string{constants.CompletionCmd, bashShell},
+			wantOutput:  "bash completion V2 for trustauthorityctl",
+			description: "Test generating the bash completion script",
+		},
+		{
+			args:        []string{constants.CompletionCmd, zshShell},
+			wantOutput:  "#compdef trustauthorityctl",
+			description: "Test generating the zsh completion script",
+		},
+		{
+			args:        []string{constants.CompletionCmd, fishShell},
+			wantOutput:  "fish completion for trustauthorityctl",
+			description: "Test generating the fish completion script",
+		},
+		{
+			args:        []string{constants.CompletionCmd, "powershell"},
+			wantErr:     true,
+			description: "Test a shell which is not supported",
+		},
+	}
+
+	for _, tc := range tt {
+		output, err := executeStdout(t, tenantCmd, tc.args)
+		if tc.wantErr {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+			assert.Contains(t, output, tc.wantOutput, tc.description)
+		}
+	}
+}
+
+func TestFlagCompletions(t *testing.T) {
+	seed := mockserver.DefaultSeed()
+	policyId := "4a1d3c1e-8a0e-4d3c-9b1e-2f5a6b7c8d9e"
+	seed.Policies = []models.PolicyResponse{{CommonPolicy: models.CommonPolicy{PolicyId: uuid.MustParse(policyId),
+		PolicyName: "sgx-policy", Policy: "default matches_sgx_policy = false", PolicyType: constants.AppraisalPolicyType,
+		ServiceOfferId: seed.ServiceOffers[0].ID, AttestationType: constants.SgxAttestationType}}}
+	server := useStatefulMockServer(t, seed)
+	// the completions are cached under the home directory
+	t.Setenv("HOME", t.TempDir())
+	// the completions load the API key from the configuration
+	t.Setenv(constants.ApiKeyEnvVar, mockServerApiKey)
+	t.Cleanup(func() {
+		resetLocalFlags(t, createApiClientCmd)
+	})
+
+	tt := []struct {
+		args        []string
+		wantOutput  []string
+		description string
+	}{
+		{
+			args:        []string{constants.CreateCmd, constants.ApiClientCmd, "--" + constants.ServiceIdParamName, ""},
+			wantOutput:  []string{seed.Services[0].ID.String() + "\tAttestation"},
+			description: "Test completing a service",
+		},
+		{
+			args: []string{constants.CreateCmd, constants.ApiClientCmd, "--" + constants.ServiceIdParamName, "Attestation",
+				"--" + constants.ProductIdParamName, ""},
+			wantOutput:  []string{seed.Products[0].ID.String() + "\tDeveloper"},
+			description: "Test completing a product of the service",
+		},
+		{
+			args:        []string{constants.CreateCmd, constants.ApiClientCmd, "--" + constants.PolicyIdsParamName, policyId + ","},
+			wantOutput:  []string{policyId + "," + policyId + "\tsgx-policy"},
+			description: "Test completing the next policy of a list",
+		},
+		{
+			args:        []string{constants.ListCmd, constants.PlanCmd, "--" + constants.PlanIdParamName, ""},
+			wantOutput:  []string{seed.Plans[0].ID.String() + "\t" + seed.Plans[0].Name},
+			description: "Test completing a plan",
+		},
+		{
+			args:        []string{constants.DeleteCmd, constants.TagCmd, "--" + constants.TagIdParamName, ""},
+			wantOutput:  []string{},
+			description: "Test completing a tag without user defined tags",
+		},
+	}
+
+	for _, tc := range tt {
+		output, err := executeStdout(t, tenantCmd, append([]string{cobra.ShellCompRequestCmd}, tc.args...))
+		assert.NoError(t, err, tc.description)
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		assert.Equal(t, tc.wantOutput, lines[:len(lines)-1], tc.description)
+		assert.Equal(t, ":"+strconv.Itoa(int(cobra.ShellCompDirectiveNoFileComp)), lines[len(lines)-1], tc.description)
+	}
+
+	// the completions of another tenant are not reused
+	otherServer := useStatefulMockServer(t, nil)
+	output, err := executeStdout(t, tenantCmd, []string{cobra.ShellCompRequestCmd, constants.CreateCmd,
+		constants.ApiClientCmd, "--" + constants.PolicyIdsParamName, ""})
+	assert.NoError(t, err)
+	assert.NotContains(t, output, policyId, "Test completing the policies of another tenant")
+	otherServer.Close()
+	useMockServer(t, server.URL)
+
+	// the completions are reused once the tenant cannot be reached
+	server.Close()
+	output, err = executeStdout(t, tenantCmd, []string{cobra.ShellCompRequestCmd, constants.CreateCmd,
+		constants.ApiClientCmd, "--" + constants.ServiceIdParamName, ""})
+	assert.NoError(t, err)
+	assert.Contains(t, output, seed.Services[0].ID.String(), "Test completing from the cache")
+	output, err = executeStdout(t, tenantCmd, []string{cobra.ShellCompRequestCmd, constants.DeleteCmd, constants.UserCmd,
+		"--" + constants.UserIdParamName, ""})
+	assert.NoError(t, err)
+	assert.Equal(t, ":"+strconv.Itoa(int(cobra.ShellCompDirectiveError)), strings.TrimSpace(output),
+		"Test completing when the tenant cannot be reached")
+}
@@ -6,22 +6,13 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	models2 "intel/tac/v1/internal/models"
 	"intel/tac/v1/models"
-	"intel/tac/v1/utils"
+	"intel/tac/v1/sdk"
 	"intel/tac/v1/validation"
-	"net/http"
-	"net/url"
-	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -33,14 +24,16 @@ var createApiClientCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("create apiClient called")
-		response, err := createApiClient(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := createApiClient(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("ApiClient: \n\n", response)
-		fmt.Println("\nNOTE: There may be a delay of up to two (2) minutes before a new attestation API key is active.")
-		fmt.Print("\n")
+		if err = printResponse(cmd, response); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "\nNOTE: There may be a delay of up to two (2) minutes before a new attestation API key is active.")
 		return nil
 	},
 }
@@ -48,132 +41,82 @@ var createApiClientCmd = &cobra.Command{
 func init() {
 	createCmd.AddCommand(createApiClientCmd)
 
-	createApiClientCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id of the Trust Authority service for which the api client needs to be created")
-	createApiClientCmd.Flags().StringP(constants.ProductIdParamName, "p", "", "Id of the Trust Authority Product for which the api client needs to be created")
+	createApiClientCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id or name of the Trust Authority service for which the api client needs to be created")
+	createApiClientCmd.Flags().StringP(constants.ProductIdParamName, "p", "", "Id or name of the Trust Authority Product for which the api client needs to be created")
 	createApiClientCmd.Flags().StringP(constants.ApiClientNameParamName, "n", "", "Name of the api client that needs to be created")
-	createApiClientCmd.Flags().StringSliceP(constants.PolicyIdsParamName, "i", []string{}, "List of comma separated policy IDs to be linked to the api client")
+	createApiClientCmd.Flags().StringSliceP(constants.PolicyIdsParamName, "i", []string{}, "List of comma separated policy IDs or names to be linked to the api client")
 	createApiClientCmd.Flags().StringSliceP(constants.TagKeyAndValuesParamName, "v", []string{}, "List of the comma separated tad Id and value pairs in the "+
 		"following format:\n Workload:WorkloadAI,Workload:WorkloadEXE etc.")
 	createApiClientCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	createApiClientCmd.MarkFlagRequired(constants.ServiceIdParamName)
 	createApiClientCmd.MarkFlagRequired(constants.ProductIdParamName)
 	createApiClientCmd.MarkFlagRequired(constants.ApiClientNameParamName)
+	createApiClientCmd.RegisterFlagCompletionFunc(constants.ServiceIdParamName, completeServices)
+	createApiClientCmd.RegisterFlagCompletionFunc(constants.ProductIdParamName, completeProducts)
+	createApiClientCmd.RegisterFlagCompletionFunc(constants.PolicyIdsParamName, completePolicyList)
+	promptMissingFlags(createApiClientCmd, servicePrompt(), productPrompt(),
+		typedPrompt(constants.ApiClientNameParamName, "Api client name", validation.ValidateApiClientName), policiesPrompt(),
+		tagValuesPrompt())
 }
 
-func createApiClient(cmd *cobra.Command) (string, error) {
-
-	configValues, err := config.LoadConfiguration()
+func createApiClient(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
+		return nil, err
 	}
 
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
-	}
-
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 	serviceIdString, err := cmd.Flags().GetString(constants.ServiceIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	serviceId, err := uuid.Parse(serviceIdString)
+	serviceId, err := resolver.ServiceId(cmd.Context(), serviceIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service id provided")
+		return nil, err
 	}
 
 	productIdString, err := cmd.Flags().GetString(constants.ProductIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	productId, err := uuid.Parse(productIdString)
+	productId, err := resolver.ProductId(cmd.Context(), serviceId, productIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid product id provided")
+		return nil, err
 	}
 
 	apiClientName, err := cmd.Flags().GetString(constants.ApiClientNameParamName)
 	if err != nil {
-		return "", err
-	}
-	err = validation.ValidateApiClientName(apiClientName)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	policyIdsString, err := cmd.Flags().GetStringSlice(constants.PolicyIdsParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	var policyIds []uuid.UUID
-	for _, policyId := range policyIdsString {
-		policyUUID, err := uuid.Parse(policyId)
-		if err != nil {
-			return "", errors.Wrap(err, "Invalid policy ID found "+policyId+". Should be UUID.")
-		}
-		policyIds = append(policyIds, policyUUID)
+	policyIds, err := resolver.PolicyIds(cmd.Context(), policyIdsString)
+	if err != nil {
+		return nil, err
 	}
 
 	tagKeyValuesString, err := cmd.Flags().GetStringSlice(constants.TagKeyAndValuesParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	var tagKeyValues []models.ApiClientTagIdValue
-	for _, tagIdValue := range tagKeyValuesString {
-		splitTag := strings.Split(tagIdValue, ":")
-		if len(splitTag) != 2 {
-			return "", errors.New("Tag Id value pairs are not provided in proper format, please check help section for more details")
-		}
-		if err = validation.ValidateTagName(splitTag[0]); err != nil {
-			return "", err
-		}
-		if err = validation.ValidateTagValue(splitTag[1]); err != nil {
-			return "", err
-		}
-		tagKeyValues = append(tagKeyValues, models.ApiClientTagIdValue{Key: splitTag[0], Value: splitTag[1]})
+	tagKeyValues, err := sdk.ParseTagValues(tagKeyValuesString)
+	if err != nil {
+		return nil, err
 	}
 
-	var apiClientInfo = models.CreateApiClient{
+	return taClient.CreateApiClient(cmd.Context(), &models.CreateApiClient{
 		ProductId:    productId,
 		Name:         apiClientName,
 		PolicyIds:    policyIds,
 		TagIdsValues: tagKeyValues,
 		ServiceId:    serviceId,
 		Status:       constants.ApiClientStatusActive,
-	}
-
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-	response, err := tmsClient.CreateApiClient(&apiClientInfo)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
-	}
-
-	return string(responseBytes), nil
-}
-
-func setRequestId(cmd *cobra.Command) error {
-	var err error
-	models2.RespHeaderFields.RequestId, err = cmd.Flags().GetString(constants.RequestIdParamName)
-	if err != nil {
-		return err
-	}
-
-	if err = validation.ValidateRequestId(models2.RespHeaderFields.RequestId); err != nil {
-		return err
-	}
-	return nil
+	}, sdk.Metadata(md))
 }
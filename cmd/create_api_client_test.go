@@ -6,12 +6,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"intel/tac/v1/config"
 	"intel/tac/v1/constants"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/models"
 	"intel/tac/v1/test"
+	"intel/tac/v1/validation"
 	"testing"
 )
 
@@ -130,3 +134,33 @@ func TestCreateApiClientCmd(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateApiClientCmdWithNames(t *testing.T) {
+	seed := mockserver.DefaultSeed()
+	seed.Policies = []models.PolicyResponse{{CommonPolicy: models.CommonPolicy{PolicyName: "sgx-policy",
+		Policy: "default matches_sgx_policy = false", PolicyType: constants.AppraisalPolicyType,
+		ServiceOfferId: seed.ServiceOffers[0].ID, AttestationType: constants.SgxAttestationType}}}
+	useStatefulMockServer(t, seed)
+	resetLocalFlags(t, createApiClientCmd)
+	resetLocalFlags(t, getApiClientsCmd)
+	t.Cleanup(func() {
+		resetLocalFlags(t, createApiClientCmd)
+		resetLocalFlags(t, getApiClientsCmd)
+	})
+
+	output, err := executeStdout(t, tenantCmd, []string{constants.CreateCmd, constants.ApiClientCmd, "-q", "names-test",
+		"-o", "json", "-n", "ci-client", "-r", "Attestation", "-p", "Developer", "-i", "sgx-policy"})
+	assert.NoError(t, err, "Test creating an api client referencing the service, product and policies by name")
+	var apiClient models.ApiClientDetail
+	assert.NoError(t, json.Unmarshal([]byte(output), &apiClient))
+	assert.Len(t, apiClient.PolicyIds, 1)
+
+	output, err = executeStdout(t, tenantCmd, []string{constants.ListCmd, constants.ApiClientCmd, "-q", "names-test",
+		"-o", "json", "-r", "Attestation", "-c", "ci-client"})
+	assert.NoError(t, err, "Test retrieving an api client by name")
+	assert.Contains(t, output, apiClient.ID.String())
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.CreateCmd, constants.ApiClientCmd, "-q", "names-test",
+		"-n", "other-client", "-r", "Attestation", "-p", "Developer", "-i", "unknown-policy"})
+	assert.True(t, validation.IsInputError(err), "Test a policy name which is not found")
+}
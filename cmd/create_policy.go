@@ -6,21 +6,12 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/pms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
 	"intel/tac/v1/models"
-	"intel/tac/v1/utils"
+	"intel/tac/v1/sdk"
 	"intel/tac/v1/validation"
-	"net/http"
-	"net/url"
-	"os"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -32,13 +23,13 @@ var createPolicyCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("create policy called")
-		response, err := createPolicy(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := createPolicy(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Policy: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -56,104 +47,63 @@ func init() {
 	createPolicyCmd.MarkFlagRequired(constants.ServiceOfferIdParamName)
 	createPolicyCmd.MarkFlagRequired(constants.AttestationTypeParamName)
 	createPolicyCmd.MarkFlagRequired(constants.PolicyFileParamName)
+	createPolicyCmd.RegisterFlagCompletionFunc(constants.ServiceOfferIdParamName, completeServiceOffers)
+	promptMissingFlags(createPolicyCmd,
+		typedPrompt(constants.PolicyNameParamName, "Policy name", validation.ValidatePolicyName),
+		choicePrompt(constants.PolicyTypeParamName, "Policy type", constants.AppraisalPolicyType, constants.TokenCustomizationPolicyType),
+		serviceOfferPrompt(),
+		choicePrompt(constants.AttestationTypeParamName, "Attestation type", constants.SgxAttestationType, constants.TdxAttestationType),
+		typedPrompt(constants.PolicyFileParamName, "Policy file", validatePolicyFile))
 }
 
-func createPolicy(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func createPolicy(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	pmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.PmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
 	policyName, err := cmd.Flags().GetString(constants.PolicyNameParamName)
 	if err != nil {
-		return "", err
-	}
-	if err = validation.ValidatePolicyName(policyName); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	policyType, err := cmd.Flags().GetString(constants.PolicyTypeParamName)
 	if err != nil {
-		return "", err
-	}
-	if policyType != constants.AppraisalPolicyType &&
-		policyType != constants.TokenCustomizationPolicyType {
-		return "", errors.Errorf("Invalid policy type provided in request, should be one of %s or %s",
-			constants.AppraisalPolicyType, constants.TokenCustomizationPolicyType)
+		return nil, err
 	}
 
 	soIdString, err := cmd.Flags().GetString(constants.ServiceOfferIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	soId, err := uuid.Parse(soIdString)
+	soId, err := sdk.ParseId("service offer", soIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service offer Id provided, should be in UUID format")
+		return nil, err
 	}
 
 	attestationType, err := cmd.Flags().GetString(constants.AttestationTypeParamName)
 	if err != nil {
-		return "", err
-	}
-	if attestationType != constants.TdxAttestationType &&
-		attestationType != constants.SgxAttestationType {
-		return "", errors.Errorf("Invalid attestation type provided in request, should be one of %s or %s",
-			constants.TdxAttestationType, constants.SgxAttestationType)
+		return nil, err
 	}
 
 	policyFilePath, err := cmd.Flags().GetString(constants.PolicyFileParamName)
 	if err != nil {
-		return "", err
-	}
-	if policyFilePath == "" {
-		return "", errors.New("Policy file path cannot be empty")
-	}
-
-	path, err := validation.ValidatePath(policyFilePath)
-	if err != nil {
-		return "", err
-	}
-
-	err = validation.ValidateSize(policyFilePath)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
-	policyBytes, err := os.ReadFile(path)
+	policy, err := sdk.ReadPolicyFile(policyFilePath)
 	if err != nil {
-		return "", errors.Wrap(err, "Error reading policy file")
+		return nil, err
 	}
 
-	var policyCreateReq = models.PolicyRequest{models.CommonPolicy{
-		Policy:          string(policyBytes),
+	return taClient.CreatePolicy(cmd.Context(), &models.PolicyRequest{CommonPolicy: models.CommonPolicy{
+		Policy:          policy,
 		PolicyName:      policyName,
 		PolicyType:      policyType,
 		ServiceOfferId:  soId,
 		AttestationType: attestationType,
-	}}
-
-	pmsClient := pms.NewPmsClient(client, pmsUrl, apiKey)
-	response, err := pmsClient.CreatePolicy(&policyCreateReq)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
-	}
-
-	return string(responseBytes), nil
+	}}, sdk.Metadata(md))
 }
@@ -6,19 +6,13 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
 	"intel/tac/v1/models"
-	"intel/tac/v1/utils"
+	"intel/tac/v1/sdk"
 	"intel/tac/v1/validation"
-	"net/http"
-	"net/url"
-	"time"
 )
 
 var createTagCmd = &cobra.Command{
@@ -27,13 +21,13 @@ var createTagCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("create tag called")
-		response, err := createTag(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := createTag(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Tag: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -43,48 +37,23 @@ func init() {
 	createTagCmd.Flags().StringP(constants.TagNameParamName, "n", "", "Name of the tag that needs to be created")
 	createTagCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	createTagCmd.MarkFlagRequired(constants.TagNameParamName)
+	promptMissingFlags(createTagCmd, typedPrompt(constants.TagNameParamName, "Tag name", validation.ValidateTagName))
 }
 
-func createTag(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func createTag(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
 	tagName, err := cmd.Flags().GetString(constants.TagNameParamName)
 	if err != nil {
-		return "", err
-	}
-	if err = validation.ValidateTagName(tagName); err != nil {
-		return "", err
-	}
-
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	createTagReq := &models.TagCreate{
-		Name: tagName,
-	}
-	response, err := tmsClient.CreateTenantTag(createTagReq)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(responseBytes), nil
+	return taClient.CreateTag(cmd.Context(), &models.TagCreate{Name: tagName}, sdk.Metadata(md))
 }
@@ -6,19 +6,12 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
 	"intel/tac/v1/models"
-	"intel/tac/v1/utils"
+	"intel/tac/v1/sdk"
 	"intel/tac/v1/validation"
-	"net/http"
-	"net/url"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -30,13 +23,13 @@ var createUserCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("create user called")
-		response, err := createUser(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := createUser(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("User: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -48,58 +41,32 @@ func init() {
 	createUserCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	createUserCmd.MarkFlagRequired(constants.EmailIdParamName)
 	createUserCmd.MarkFlagRequired(constants.UserRoleParamName)
+	promptMissingFlags(createUserCmd, typedPrompt(constants.EmailIdParamName, "Email", validation.ValidateEmailAddress),
+		choicePrompt(constants.UserRoleParamName, "Role", constants.TenantAdminRole, constants.UserRole))
 }
 
-func createUser(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func createUser(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
 	emailId, err := cmd.Flags().GetString(constants.EmailIdParamName)
 	if err != nil {
-		return "", err
-	}
-	if err = validation.ValidateEmailAddress(emailId); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	userRole, err := cmd.Flags().GetString(constants.UserRoleParamName)
 	if err != nil {
-		return "", err
-	}
-	if userRole != constants.TenantAdminRole && userRole != constants.UserRole {
-		return "", errors.Errorf("%s is not a valid user role. Roles should be either %s or %s", userRole,
-			constants.TenantAdminRole, constants.UserRole)
+		return nil, err
 	}
 
-	var createUserInfo = &models.CreateTenantUser{
+	return taClient.CreateUser(cmd.Context(), &models.CreateTenantUser{
 		Email: emailId,
 		Role:  userRole,
-	}
-
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-	response, err := tmsClient.CreateUser(createUserInfo)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
-	}
-
-	return string(responseBytes), nil
+	}, sdk.Metadata(md))
 }
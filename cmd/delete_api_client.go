@@ -9,17 +9,11 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 )
 
 var deleteApiClientCmd = &cobra.Command{
@@ -28,8 +22,9 @@ var deleteApiClientCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("delete apiClient called")
-		serviceId, err := deleteApiClient(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		serviceId, err := deleteApiClient(cmd, md)
 		if err != nil {
 			return err
 		}
@@ -43,57 +38,47 @@ var deleteApiClientCmd = &cobra.Command{
 func init() {
 	deleteCmd.AddCommand(deleteApiClientCmd)
 
-	deleteApiClientCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id of the Trust Authority service for which the api client needs to be created")
-	deleteApiClientCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id of the api client which needs to be fetched (optional)")
+	deleteApiClientCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id or name of the Trust Authority service for which the api client needs to be created")
+	deleteApiClientCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id or name of the api client which needs to be fetched (optional)")
 	deleteApiClientCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	deleteApiClientCmd.MarkFlagRequired(constants.ServiceIdParamName)
 	deleteApiClientCmd.MarkFlagRequired(constants.ApiClientIdParamName)
+	deleteApiClientCmd.RegisterFlagCompletionFunc(constants.ServiceIdParamName, completeServices)
+	deleteApiClientCmd.RegisterFlagCompletionFunc(constants.ApiClientIdParamName, completeApiClients)
+	promptMissingFlags(deleteApiClientCmd, servicePrompt(), apiClientPrompt())
 }
 
-func deleteApiClient(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
-	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
+func deleteApiClient(cmd *cobra.Command, md *client.RequestMetadata) (string, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
 		return "", err
 	}
 
-	if err = setRequestId(cmd); err != nil {
+	if err = setRequestId(cmd, md); err != nil {
 		return "", err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	serviceIdString, err := cmd.Flags().GetString(constants.ServiceIdParamName)
 	if err != nil {
 		return "", err
 	}
-
-	serviceId, err := uuid.Parse(serviceIdString)
+	serviceId, err := resolver.ServiceId(cmd.Context(), serviceIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service id provided")
+		return "", err
 	}
 
 	apiClientIdString, err := cmd.Flags().GetString(constants.ApiClientIdParamName)
 	if err != nil {
 		return "", err
 	}
-
-	apiClientId, err := uuid.Parse(apiClientIdString)
+	apiClientId, err := resolver.ApiClientId(cmd.Context(), serviceId, apiClientIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid api client id provided")
+		return "", err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	err = tmsClient.DeleteApiClient(serviceId, apiClientId)
-	if err != nil {
+	if err = taClient.DeleteApiClient(cmd.Context(), serviceId, apiClientId, sdk.Metadata(md)); err != nil {
 		return "", err
 	}
-
 	return apiClientIdString, nil
 }
@@ -7,16 +7,10 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/pms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -28,8 +22,9 @@ var deletePolicyCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("delete policy called")
-		policyId, err := deletePolicy(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		policyId, err := deletePolicy(cmd, md)
 		if err != nil {
 			return err
 		}
@@ -41,45 +36,35 @@ var deletePolicyCmd = &cobra.Command{
 func init() {
 	deleteCmd.AddCommand(deletePolicyCmd)
 
-	deletePolicyCmd.Flags().StringP(constants.PolicyIdParamName, "p", "", "Id of the policy to be deleted")
+	deletePolicyCmd.Flags().StringP(constants.PolicyIdParamName, "p", "", "Id or name of the policy to be deleted")
 	deletePolicyCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	deletePolicyCmd.MarkFlagRequired(constants.PolicyIdParamName)
+	deletePolicyCmd.RegisterFlagCompletionFunc(constants.PolicyIdParamName, completePolicies)
+	promptMissingFlags(deletePolicyCmd, policyPrompt(constants.PolicyIdParamName))
 }
 
-func deletePolicy(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
-	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	pmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.PmsBaseUrl)
+func deletePolicy(cmd *cobra.Command, md *client.RequestMetadata) (string, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
 		return "", err
 	}
 
-	if err = setRequestId(cmd); err != nil {
+	if err = setRequestId(cmd, md); err != nil {
 		return "", err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	policyIdString, err := cmd.Flags().GetString(constants.PolicyIdParamName)
 	if err != nil {
 		return "", err
 	}
-
-	policyId, err := uuid.Parse(policyIdString)
+	policyId, err := resolver.PolicyId(cmd.Context(), policyIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid policy id provided")
+		return "", err
 	}
 
-	pmsClient := pms.NewPmsClient(client, pmsUrl, apiKey)
-
-	err = pmsClient.DeletePolicy(policyId)
-	if err != nil {
+	if err = taClient.DeletePolicy(cmd.Context(), policyId, sdk.Metadata(md)); err != nil {
 		return "", err
 	}
-
 	return policyIdString, nil
 }
@@ -7,16 +7,10 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -28,8 +22,9 @@ var deleteTagCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("delete tag called")
-		tagId, err := deleteTag(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		tagId, err := deleteTag(cmd, md)
 		if err != nil {
 			return err
 		}
@@ -41,49 +36,35 @@ var deleteTagCmd = &cobra.Command{
 func init() {
 	deleteCmd.AddCommand(deleteTagCmd)
 
-	deleteTagCmd.Flags().StringP(constants.TagIdParamName, "t", "", "Id of the specific user defined tag which needs to be deleted")
+	deleteTagCmd.Flags().StringP(constants.TagIdParamName, "t", "", "Id or name of the specific user defined tag which needs to be deleted")
 	deleteTagCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	deleteTagCmd.MarkFlagRequired(constants.TagIdParamName)
+	deleteTagCmd.RegisterFlagCompletionFunc(constants.TagIdParamName, completeTags)
+	promptMissingFlags(deleteTagCmd, userTagPrompt())
 }
 
-func deleteTag(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
-	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
+func deleteTag(cmd *cobra.Command, md *client.RequestMetadata) (string, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
 		return "", err
 	}
 
-	if err = setRequestId(cmd); err != nil {
+	if err = setRequestId(cmd, md); err != nil {
 		return "", err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	tagIdString, err := cmd.Flags().GetString(constants.TagIdParamName)
 	if err != nil {
 		return "", err
 	}
-
-	if tagIdString == "" {
-		return "", errors.New("Tag Id cannot be empty")
-	}
-
-	tagId, err := uuid.Parse(tagIdString)
+	tagId, err := resolver.TagId(cmd.Context(), tagIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid tag id provided")
+		return "", err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	err = tmsClient.DeleteTenantTag(tagId)
-	if err != nil {
+	if err = taClient.DeleteTag(cmd.Context(), tagId, sdk.Metadata(md)); err != nil {
 		return "", err
 	}
-
 	return tagIdString, nil
 }
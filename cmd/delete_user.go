@@ -7,16 +7,10 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -28,8 +22,9 @@ var deleteUserCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("delete user called")
-		userId, err := deleteUser(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		userId, err := deleteUser(cmd, md)
 		if err != nil {
 			return err
 		}
@@ -40,45 +35,35 @@ var deleteUserCmd = &cobra.Command{
 
 func init() {
 	deleteCmd.AddCommand(deleteUserCmd)
-	deleteUserCmd.Flags().StringP(constants.UserIdParamName, "u", "", "Id of the specific user, the details for whom needs to be deleted")
+	deleteUserCmd.Flags().StringP(constants.UserIdParamName, "u", "", "Id or email of the specific user, the details for whom needs to be deleted")
 	deleteUserCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	deleteUserCmd.MarkFlagRequired(constants.UserIdParamName)
+	deleteUserCmd.RegisterFlagCompletionFunc(constants.UserIdParamName, completeUsers)
+	promptMissingFlags(deleteUserCmd, userPrompt())
 }
 
-func deleteUser(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
-	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
+func deleteUser(cmd *cobra.Command, md *client.RequestMetadata) (string, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
 		return "", err
 	}
 
-	if err = setRequestId(cmd); err != nil {
+	if err = setRequestId(cmd, md); err != nil {
 		return "", err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	userIdString, err := cmd.Flags().GetString(constants.UserIdParamName)
 	if err != nil {
 		return "", err
 	}
-
-	userId, err := uuid.Parse(userIdString)
+	userId, err := resolver.UserId(cmd.Context(), userIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid user id provided")
+		return "", err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	err = tmsClient.DeleteUser(userId)
-	if err != nil {
+	if err = taClient.DeleteUser(cmd.Context(), userId, sdk.Metadata(md)); err != nil {
 		return "", err
 	}
-
 	return userIdString, nil
 }
@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/manifest"
+	"intel/tac/v1/sdk"
+)
+
+// errDrift is returned by the diff command when the tenant differs from the manifest
+var errDrift = errors.New("The tenant differs from the manifest")
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   constants.DiffCmd,
+	Short: "Show the differences between a manifest and the tenant resources",
+	Long: `Show the differences between a manifest and the tenant resources as a unified diff, from the current
+resources of the tenant to the ones of the manifest, including the changes of the rego policies. A resource of the
+manifest which is missing from the tenant would be created by apply, and a resource of the tenant which is missing
+from the manifest is shown as deleted, for the kinds declared in the manifest only. The command exits with code 8
+when the tenant differs from the manifest, so that scheduled jobs can detect the changes made outside of git.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Info("diff called")
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		drift, err := diffManifest(cmd, md)
+		if err != nil {
+			return err
+		}
+		if drift {
+			cmd.SilenceUsage = true
+			return errDrift
+		}
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringP(constants.FilenameParamName, "f", "", "Path of the manifest file describing the tenant resources")
+	diffCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
+	diffCmd.MarkFlagRequired(constants.FilenameParamName)
+}
+
+func diffManifest(cmd *cobra.Command, md *client.RequestMetadata) (bool, error) {
+	filename, err := cmd.Flags().GetString(constants.FilenameParamName)
+	if err != nil {
+		return false, err
+	}
+	m, err := manifest.Load(filename)
+	if err != nil {
+		return false, err
+	}
+
+	taClient, err := newTrustAuthorityClient()
+	if err != nil {
+		return false, err
+	}
+	if err = setRequestId(cmd, md); err != nil {
+		return false, err
+	}
+
+	state, err := manifest.FetchState(cmd.Context(), taClient, sdk.Metadata(md))
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to retrieve the tenant resources")
+	}
+	changes, err := manifest.Plan(m, state)
+	if err != nil {
+		return false, err
+	}
+	return manifest.Diff(cmd.OutOrStdout(), changes)
+}
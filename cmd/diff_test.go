@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffCmd(t *testing.T) {
+	useStatefulMockServer(t, nil)
+
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "tenant.yaml")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(applyManifestFile), 0600))
+	changedFile := filepath.Join(dir, "changed.yaml")
+	assert.NoError(t, os.WriteFile(changedFile, []byte(strings.Replace(applyManifestFile, "= false", "= true", 1)), 0600))
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalidFile, []byte("kind: Secret\nmetadata:\n  name: secret"), 0600))
+
+	output, err := executeStdout(t, tenantCmd, []string{constants.DiffCmd, "-f", manifestFile, "-q", "diff-test"})
+	assert.Equal(t, constants.ExitCodeDrift, exitCode(err), "Test the resources to create")
+	assert.Contains(t, output, "+++ manifest/Policy/sgx-policy")
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.ApplyCmd, "-f", manifestFile, "-q", "diff-test"})
+	assert.NoError(t, err)
+	output, err = executeStdout(t, tenantCmd, []string{constants.DiffCmd, "-f", manifestFile, "-q", "diff-test"})
+	assert.NoError(t, err, "Test a tenant matching the manifest")
+	assert.Empty(t, output)
+
+	output, err = executeStdout(t, tenantCmd, []string{constants.DiffCmd, "-f", changedFile, "-q", "diff-test"})
+	assert.Equal(t, constants.ExitCodeDrift, exitCode(err), "Test a changed policy")
+	assert.Contains(t, output, "-  policy: default matches_sgx_policy = false\n+  policy: default matches_sgx_policy = true\n")
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.DiffCmd, "-f", invalidFile, "-q", "diff-test"})
+	assert.True(t, validation.IsInputError(err), "Test an invalid manifest")
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// authError is returned when the CLI cannot authenticate to Trust Authority, e.g. when the API key is missing
+type authError struct {
+	err error
+}
+
+func (e *authError) Error() string {
+	return e.err.Error()
+}
+
+func (e *authError) Unwrap() error {
+	return e.err
+}
+
+// cobraUsageErrors are the prefixes of the errors returned by cobra for an unknown command, invalid arguments or
+// missing required flags. cobra does not type these errors, the flag parsing ones being wrapped by the FlagErrorFunc.
+var cobraUsageErrors = []string{"unknown command ", "accepts ", "requires at least ", "invalid argument ",
+	"required flag(s) ", "if any flags in the group "}
+
+// isUsageError checks if the error was returned by cobra when validating the arguments and flags of the command
+func isUsageError(err error) bool {
+	for _, prefix := range cobraUsageErrors {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// exitCode maps the error returned by the command to the exit code of the CLI so that scripts can branch on it
+func exitCode(err error) int {
+	var authErr *authError
+	var urlErr *url.Error
+	var netErr net.Error
+
+	switch {
+	case err == nil:
+		return constants.ExitCodeOK
+	case errors.Is(err, client.ErrCancelled):
+		return constants.ExitCodeCancelled
+	case errors.Is(err, errDrift):
+		return constants.ExitCodeDrift
+	case errors.As(err, &authErr), client.IsUnauthorized(err):
+		return constants.ExitCodeAuth
+	case isUsageError(err), validation.IsInputError(err), client.IsBadRequest(err):
+		return constants.ExitCodeUsage
+	case client.IsNotFound(err):
+		return constants.ExitCodeNotFound
+	case client.IsConflict(err):
+		return constants.ExitCodeConflict
+	case client.IsServerError(err):
+		return constants.ExitCodeServer
+	case errors.Is(err, sdk.ErrOffline), errors.As(err, &urlErr), errors.As(err, &netErr):
+		return constants.ExitCodeNetwork
+	}
+	return constants.ExitCodeError
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/test"
+	"intel/tac/v1/validation"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestExitCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statusCode, _ := strconv.Atoi(r.Header.Get(constants.HTTPHeaderKeyRequestId))
+		w.Header().Set(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
+		w.Header().Set(constants.HTTPHeaderKeyRequestId, r.Header.Get(constants.HTTPHeaderKeyRequestId))
+		w.Header().Set(constants.HTTPHeaderKeyTraceId, "exit-code-trace")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(`{"code": ` + strconv.Itoa(statusCode) + `, "message": "` + http.StatusText(statusCode) + `"}`))
+	}))
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+	setGlobalFlag(t, constants.RetryMax, "0")
+
+	deleteCmd.AddCommand(deletePolicyCmd)
+	tenantCmd.AddCommand(deleteCmd)
+
+	tt := []struct {
+		requestId   string
+		policyId    string
+		exitCode    int
+		description string
+	}{
+		{
+			requestId:   "400",
+			exitCode:    constants.ExitCodeUsage,
+			description: "Test a request rejected by the server",
+		},
+		{
+			requestId:   "401",
+			exitCode:    constants.ExitCodeAuth,
+			description: "Test an invalid API key",
+		},
+		{
+			requestId:   "404",
+			exitCode:    constants.ExitCodeNotFound,
+			description: "Test a resource which does not exist",
+		},
+		{
+			requestId:   "409",
+			exitCode:    constants.ExitCodeConflict,
+			description: "Test a resource which already exists",
+		},
+		{
+			requestId:   "500",
+			exitCode:    constants.ExitCodeServer,
+			description: "Test a server failure",
+		},
+		{
+			requestId:   "404",
+			policyId:    " ",
+			exitCode:    constants.ExitCodeUsage,
+			description: "Test an empty policy name or id",
+		},
+	}
+
+	for _, tc := range tt {
+		policyId := tc.policyId
+		if policyId == "" {
+			policyId = "e48dabc5-9608-4ff3-aaed-f25909ab9de1"
+		}
+		_, err := execute(t, tenantCmd, []string{constants.DeleteCmd, constants.PolicyCmd, "-q", tc.requestId, "-p", policyId})
+		assert.Error(t, err, tc.description)
+		assert.Equal(t, tc.exitCode, exitCode(err), tc.description)
+
+		if tc.policyId == "" {
+			apiErr, ok := client.AsAPIError(err)
+			assert.True(t, ok, tc.description)
+			assert.Equal(t, tc.requestId, strconv.Itoa(apiErr.StatusCode), tc.description)
+			assert.Equal(t, http.StatusText(apiErr.StatusCode), apiErr.Message(), tc.description)
+			assert.Equal(t, tc.requestId, apiErr.RequestId, tc.description)
+			assert.Equal(t, "exit-code-trace", apiErr.TraceId, tc.description)
+		}
+	}
+
+	server.Close()
+	_, err := execute(t, tenantCmd, []string{constants.DeleteCmd, constants.PolicyCmd, "-q", "network", "-p", "e48dabc5-9608-4ff3-aaed-f25909ab9de1"})
+	assert.Equal(t, constants.ExitCodeNetwork, exitCode(err))
+	assert.Equal(t, constants.ExitCodeNetwork, exitCode(errors.Wrap(sdk.ErrOffline, "The tags are not cached")),
+		"Test a resource which is not cached offline")
+
+	_, err = execute(t, tenantCmd, []string{constants.DeleteCmd, constants.PolicyCmd, "--unknown-flag"})
+	assert.Equal(t, constants.ExitCodeUsage, exitCode(err))
+
+	_, err = execute(t, tenantCmd, []string{"unknown-command"})
+	assert.Equal(t, constants.ExitCodeUsage, exitCode(err), "Test an unknown command")
+
+	resetLocalFlags(t, deleteTagCmd)
+	_, err = execute(t, tenantCmd, []string{constants.DeleteCmd, constants.TagCmd})
+	assert.ErrorContains(t, err, "required flag(s)")
+	assert.Equal(t, constants.ExitCodeUsage, exitCode(err), "Test a missing required flag")
+
+	for _, key := range []string{"", "bad"} {
+		err = &authError{err: validation.ValidateTrustAuthorityAPIKey(key)}
+		assert.Equal(t, constants.ExitCodeAuth, exitCode(err), "Test a missing or malformed API key")
+	}
+	assert.Equal(t, constants.ExitCodeOK, exitCode(nil))
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/manifest"
+	"intel/tac/v1/printer"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+)
+
+// exportPolicyDir is the directory of the policy files, relative to the exported manifest
+const exportPolicyDir = "policies"
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   constants.ExportCmd,
+	Short: "Export the tenant configuration as a manifest",
+	Long: `Export the policies, tags, users, tenant settings and api clients of the services of the tenant as a
+manifest, which apply or import can recreate in another tenant. The manifest is written as YAML documents, or as a
+JSON List document with -o json, and holds the IDs of the exported resources in their metadata. With --split-policies
+the rego policies are written to individual .rego files of a policies directory next to the manifest.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Info("export called")
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		err := exportManifest(cmd, md)
+		return err
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP(constants.FilenameParamName, "f", "", "Path of the file the manifest is written to, the manifest is printed when it is not provided")
+	exportCmd.Flags().Bool(constants.SplitPoliciesParamName, false, "Write the rego policies to individual files of a "+exportPolicyDir+
+		" directory next to the manifest, requires --"+constants.FilenameParamName)
+	exportCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
+}
+
+func exportManifest(cmd *cobra.Command, md *client.RequestMetadata) error {
+	format := manifest.FormatYAML
+	switch outputFormat {
+	case printer.JSON:
+		format = manifest.FormatJSON
+	case printer.YAML, printer.Table:
+	default:
+		return validation.NewInputError(errors.Errorf("Output format %q is not supported by export, should be %s or %s",
+			outputFormat, printer.YAML, printer.JSON))
+	}
+	filename, err := cmd.Flags().GetString(constants.FilenameParamName)
+	if err != nil {
+		return err
+	}
+	splitPolicies, err := cmd.Flags().GetBool(constants.SplitPoliciesParamName)
+	if err != nil {
+		return err
+	}
+	if splitPolicies && filename == "" {
+		return validation.NewInputError(errors.Errorf("--%s requires --%s", constants.SplitPoliciesParamName,
+			constants.FilenameParamName))
+	}
+	if filename != "" {
+		// the manifest may not exist yet, its directory must
+		dir, err := validation.ValidatePath(filepath.Dir(filename))
+		if err != nil {
+			return err
+		}
+		filename = filepath.Join(dir, filepath.Base(filename))
+	}
+
+	taClient, err := newTrustAuthorityClient()
+	if err != nil {
+		return err
+	}
+	if err = setRequestId(cmd, md); err != nil {
+		return err
+	}
+
+	state, err := manifest.FetchState(cmd.Context(), taClient, sdk.Metadata(md))
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve the tenant resources")
+	}
+	m := manifest.FromState(state)
+	if filename == "" {
+		return m.Write(cmd.OutOrStdout(), format)
+	}
+
+	if splitPolicies {
+		dir := filepath.Dir(filename)
+		if err = m.SplitPolicies(dir, filepath.Join(dir, exportPolicyDir)); err != nil {
+			return err
+		}
+	}
+	var buf bytes.Buffer
+	if err = m.Write(&buf, format); err != nil {
+		return err
+	}
+	// the manifest holds the emails of the users
+	if err = os.WriteFile(filename, buf.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "Failed to write manifest")
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d policies, %d tags, %d users and %d api clients to %s\n", len(m.Policies),
+		len(m.Tags), len(m.Users), len(m.ApiClients), filename)
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/manifest"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportCmd(t *testing.T) {
+	useStatefulMockServer(t, nil)
+	t.Cleanup(func() {
+		resetLocalFlags(t, exportCmd)
+	})
+
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "tenant.yaml")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(applyManifestFile), 0600))
+	_, err = executeStdout(t, tenantCmd, []string{constants.ApplyCmd, "-f", manifestFile, "-q", "export-test"})
+	assert.NoError(t, err)
+
+	output, err := executeStdout(t, tenantCmd, []string{constants.ExportCmd, "-q", "export-test", "-o", "json"})
+	assert.NoError(t, err)
+	var bundle struct {
+		Kind  string `json:"kind"`
+		Items []struct {
+			Kind string `json:"kind"`
+		} `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(output), &bundle))
+	assert.Equal(t, manifest.KindList, bundle.Kind)
+	assert.Len(t, bundle.Items, 3, "Test exporting the policy, the tenant settings and the api client")
+
+	exportFile := filepath.Join(t.TempDir(), "bundle.yaml")
+	_, err = executeStdout(t, tenantCmd, []string{constants.ExportCmd, "-q", "export-test", "-o", "yaml", "-f", exportFile,
+		"--" + constants.SplitPoliciesParamName})
+	assert.NoError(t, err)
+	policy, err := os.ReadFile(filepath.Join(filepath.Dir(exportFile), "policies", "sgx-policy.rego"))
+	assert.NoError(t, err)
+	assert.Equal(t, "default matches_sgx_policy = false", string(policy))
+	content, err := os.ReadFile(exportFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "policy_file: policies/sgx-policy.rego")
+
+	// the exported manifest matches the tenant
+	_, err = executeStdout(t, tenantCmd, []string{constants.DiffCmd, "-f", exportFile, "-q", "export-test"})
+	assert.NoError(t, err)
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.ExportCmd, "-q", "export-test", "-o", "wide"})
+	assert.True(t, validation.IsInputError(err), "Test an output format not supported")
+	assert.NoError(t, exportCmd.Flags().Set(constants.FilenameParamName, ""))
+	_, err = executeStdout(t, tenantCmd, []string{constants.ExportCmd, "-q", "export-test", "-o", "yaml",
+		"--" + constants.SplitPoliciesParamName})
+	assert.True(t, validation.IsInputError(err), "Test splitting the policies without a file")
+}
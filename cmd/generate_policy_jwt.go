@@ -55,7 +55,7 @@ func generatePolicyJwt(cmd *cobra.Command) error {
 		return err
 	}
 	if policyFilePath == "" {
-		return errors.New("Policy file path cannot be empty")
+		return validation.NewInputError(errors.New("Policy file path cannot be empty"))
 	}
 
 	path, err := validation.ValidatePath(policyFilePath)
@@ -69,7 +69,7 @@ func generatePolicyJwt(cmd *cobra.Command) error {
 	}
 
 	if len(policyBytes) == 0 {
-		return errors.New("Policy file does not contain a rego policy")
+		return validation.NewInputError(errors.New("Policy file does not contain a rego policy"))
 	}
 	claims := models.PolicyClaims{
 		AttestationPolicy: string(policyBytes),
@@ -88,7 +88,7 @@ func generatePolicyJwt(cmd *cobra.Command) error {
 			return err
 		}
 		if !algorithms.Has(algorithm) {
-			return errors.New("Input algorithm is not supported")
+			return validation.NewInputError(errors.New("Input algorithm is not supported"))
 		}
 
 		privateKeyFilePath, err := cmd.Flags().GetString(constants.PrivateKeyFileParamName)
@@ -109,7 +109,7 @@ func generatePolicyJwt(cmd *cobra.Command) error {
 		// Check if provided algorithm makes sense
 		signMethod := utils.CheckSigningAlgorithm(privKeyFinal, algorithm)
 		if signMethod == nil {
-			return errors.New("Signing algorithm provided as input is not compatible with the private key type")
+			return validation.NewInputError(errors.New("Signing algorithm provided as input is not compatible with the private key type"))
 		}
 
 		signedToken := &jwt.Token{
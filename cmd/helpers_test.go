@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/mockserver"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// mockServerApiKey is the API key the commands send to the mock server, which accepts any valid key
+const mockServerApiKey = "mockserverapikeymockserverapikey"
+
+// statefulMockServer is the mock server of the Trust Authority API used by a test, counting the requests received
+type statefulMockServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+// useStatefulMockServer runs the mock server with the seed, the default one when nil, and points the CLI to it with a
+// valid API key for the duration of the test
+func useStatefulMockServer(t *testing.T, seed *mockserver.Seed) *statefulMockServer {
+	t.Helper()
+
+	server, err := mockserver.New(seed)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	handler, mockServer := server.Handler(), &statefulMockServer{}
+	mockServer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockServer.requests.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	useMockServer(t, mockServer.URL)
+	defaultApiKey := apiKey
+	apiKey = mockServerApiKey
+	t.Cleanup(func() {
+		apiKey = defaultApiKey
+		mockServer.Close()
+	})
+	return mockServer
+}
+
+// resetLocalFlags restores the default values of the flags of the command, which are kept from the previous tests
+func resetLocalFlags(t *testing.T, cmd *cobra.Command) {
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			assert.NoError(t, sliceValue.Replace(nil))
+		} else {
+			assert.NoError(t, flag.Value.Set(flag.DefValue))
+		}
+		flag.Changed = false
+	})
+}
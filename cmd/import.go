@@ -0,0 +1,98 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/manifest"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   constants.ImportCmd,
+	Short: "Recreate the resources of an exported manifest in the tenant",
+	Long: `Recreate the policies, tags, users, tenant settings and api clients of a manifest written by export in the
+tenant, e.g. to clone the setup of a staging tenant. The policies and tags the api clients reference by their IDs in
+the source tenant are remapped to the ones created. The resources which already exist in the tenant fail to import
+unless --skip-existing leaves them untouched or --overwrite updates them, the tenant settings are updated unless
+--skip-existing is set. The ID of each resource in the source tenant is printed along with its ID in the tenant.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.Info("import called")
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := importManifest(cmd, md)
+		if err != nil {
+			return err
+		}
+		if err = printResponse(cmd, response); err != nil {
+			return err
+		}
+		failed := 0
+		for _, result := range response {
+			if result.Result.Result == manifest.ResultFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			// the results already tell which resources failed, the usage would only hide them
+			cmd.SilenceUsage = true
+			return errors.Errorf("%d of %d resources failed to import", failed, len(response))
+		}
+		return nil
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringP(constants.FilenameParamName, "f", "", "Path of the manifest file written by export")
+	importCmd.Flags().Bool(constants.SkipExistingParamName, false, "Leave the resources which already exist in the tenant untouched")
+	importCmd.Flags().Bool(constants.OverwriteParamName, false, "Update the resources which already exist in the tenant")
+	importCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
+	importCmd.MarkFlagRequired(constants.FilenameParamName)
+}
+
+func importManifest(cmd *cobra.Command, md *client.RequestMetadata) ([]manifest.ImportResult, error) {
+	filename, err := cmd.Flags().GetString(constants.FilenameParamName)
+	if err != nil {
+		return nil, err
+	}
+	options := manifest.ImportOptions{}
+	if options.SkipExisting, err = cmd.Flags().GetBool(constants.SkipExistingParamName); err != nil {
+		return nil, err
+	}
+	if options.Overwrite, err = cmd.Flags().GetBool(constants.OverwriteParamName); err != nil {
+		return nil, err
+	}
+	if options.SkipExisting && options.Overwrite {
+		return nil, validation.NewInputError(errors.Errorf("--%s and --%s cannot be used together",
+			constants.SkipExistingParamName, constants.OverwriteParamName))
+	}
+	m, err := manifest.Load(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	taClient, err := newTrustAuthorityClient()
+	if err != nil {
+		return nil, err
+	}
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
+	}
+
+	state, err := manifest.FetchState(cmd.Context(), taClient, sdk.Metadata(md))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to retrieve the tenant resources")
+	}
+	return manifest.Import(cmd.Context(), taClient, state, m, options, sdk.Metadata(md))
+}
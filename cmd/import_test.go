@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/manifest"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const importManifestFile = `
+kind: Policy
+metadata:
+  name: sgx-policy
+  id: 4a1d3c1e-8a0e-4d3c-9b1e-2f5a6b7c8d9e
+spec:
+  service_offer: Attestation
+  policy_type: Appraisal policy
+  attestation_type: SGX Attestation
+  policy: default matches_sgx_policy = false
+---
+kind: ApiClient
+metadata:
+  name: ci-client
+  id: 0b6f2a9c-3d4e-4f5a-8b7c-1d2e3f4a5b6c
+spec:
+  service: Attestation
+  product: Developer
+  policies: [4a1d3c1e-8a0e-4d3c-9b1e-2f5a6b7c8d9e]
+`
+
+func TestImportCmd(t *testing.T) {
+	useStatefulMockServer(t, nil)
+	t.Cleanup(func() {
+		resetLocalFlags(t, importCmd)
+	})
+
+	manifestFile := filepath.Join(t.TempDir(), "bundle.yaml")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte(importManifestFile), 0600))
+
+	tt := []struct {
+		args        []string
+		wantErr     bool
+		wantResults []string
+		description string
+	}{
+		{
+			args:        []string{constants.ImportCmd, "-f", manifestFile, "-q", "import-test", "-o", "json"},
+			wantResults: []string{manifest.ResultCreated, manifest.ResultCreated},
+			description: "Test importing a bundle",
+		},
+		{
+			args:        []string{constants.ImportCmd, "-f", manifestFile, "-q", "import-test", "-o", "json"},
+			wantErr:     true,
+			wantResults: []string{manifest.ResultFailed, manifest.ResultFailed},
+			description: "Test importing a bundle already imported",
+		},
+		{
+			args: []string{constants.ImportCmd, "-f", manifestFile, "-q", "import-test", "-o", "json",
+				"--" + constants.SkipExistingParamName},
+			wantResults: []string{manifest.ResultSkipped, manifest.ResultSkipped},
+			description: "Test skipping the existing resources",
+		},
+	}
+
+	for _, tc := range tt {
+		output, err := executeStdout(t, tenantCmd, tc.args)
+		if tc.wantErr {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+		var results []manifest.ImportResult
+		assert.NoError(t, json.Unmarshal([]byte(output), &results), tc.description)
+		var actual []string
+		for _, result := range results {
+			actual = append(actual, result.Result.Result)
+			assert.NotEmpty(t, result.SourceId, tc.description)
+			assert.NotEqual(t, result.SourceId, result.Id, tc.description)
+		}
+		assert.Equal(t, tc.wantResults, actual, tc.description)
+	}
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.ImportCmd, "-f", manifestFile, "-q", "import-test",
+		"--" + constants.SkipExistingParamName, "--" + constants.OverwriteParamName})
+	assert.True(t, validation.IsInputError(err), "Test skipping and overwriting the existing resources")
+}
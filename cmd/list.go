@@ -19,4 +19,6 @@ var listCmd = &cobra.Command{
 
 func init() {
 	tenantCmd.AddCommand(listCmd)
+	listCmd.PersistentFlags().Bool(constants.OfflineParamName, false, "List the services, service offers, products, "+
+		"plans and tags from the local cache, whatever their age, without reaching Trust Authority")
 }
@@ -6,18 +6,10 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -28,72 +20,56 @@ var getApiClientPoliciesCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list apiClient policy called")
-		response, err := getApiClientPolicies(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getApiClientPolicies(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Policy IDs: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
 func init() {
 	getApiClientsCmd.AddCommand(getApiClientPoliciesCmd)
 
-	getApiClientPoliciesCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id of the Trust Authority service for which the apiClient policies are to be fetched")
-	getApiClientPoliciesCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id of the apiClient for which the policies are to be fetched")
+	getApiClientPoliciesCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id or name of the Trust Authority service for which the apiClient policies are to be fetched")
+	getApiClientPoliciesCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id or name of the apiClient for which the policies are to be fetched")
 	getApiClientPoliciesCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	getApiClientPoliciesCmd.MarkFlagRequired(constants.ServiceIdParamName)
 	getApiClientPoliciesCmd.MarkFlagRequired(constants.ApiClientIdParamName)
+	getApiClientPoliciesCmd.RegisterFlagCompletionFunc(constants.ServiceIdParamName, completeServices)
+	getApiClientPoliciesCmd.RegisterFlagCompletionFunc(constants.ApiClientIdParamName, completeApiClients)
 }
 
-func getApiClientPolicies(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getApiClientPolicies(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
+		return nil, err
 	}
 
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
-	}
-
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	serviceIdString, err := cmd.Flags().GetString(constants.ServiceIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	serviceId, err := uuid.Parse(serviceIdString)
+	serviceId, err := resolver.ServiceId(cmd.Context(), serviceIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service id provided")
+		return nil, err
 	}
 
 	apiClientIdString, err := cmd.Flags().GetString(constants.ApiClientIdParamName)
 	if err != nil {
-		return "", err
-	}
-	apiClientId, err := uuid.Parse(apiClientIdString)
-	if err != nil {
-		return "", errors.Wrap(err, "Invalid apiClient id provided")
+		return nil, err
 	}
-
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-	response, err := tmsClient.GetApiClientPolicies(serviceId, apiClientId)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
+	apiClientId, err := resolver.ApiClientId(cmd.Context(), serviceId, apiClientIdString)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(responseBytes), nil
+	return taClient.GetApiClientPolicies(cmd.Context(), serviceId, apiClientId, sdk.Metadata(md))
 }
@@ -6,18 +6,10 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -28,72 +20,56 @@ var getApiClientTagsValuesCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list apiClient tag called")
-		response, err := getApiClientTagsAndValues(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getApiClientTagsAndValues(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Tags: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
 func init() {
 	getApiClientsCmd.AddCommand(getApiClientTagsValuesCmd)
 
-	getApiClientTagsValuesCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id of the Trust Authority service for which the apiClient policies are to be fetched")
-	getApiClientTagsValuesCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id of the apiClient for which the policies are to be fetched")
+	getApiClientTagsValuesCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id or name of the Trust Authority service for which the apiClient policies are to be fetched")
+	getApiClientTagsValuesCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id or name of the apiClient for which the policies are to be fetched")
 	getApiClientTagsValuesCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	getApiClientTagsValuesCmd.MarkFlagRequired(constants.ServiceIdParamName)
 	getApiClientTagsValuesCmd.MarkFlagRequired(constants.ApiClientIdParamName)
+	getApiClientTagsValuesCmd.RegisterFlagCompletionFunc(constants.ServiceIdParamName, completeServices)
+	getApiClientTagsValuesCmd.RegisterFlagCompletionFunc(constants.ApiClientIdParamName, completeApiClients)
 }
 
-func getApiClientTagsAndValues(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getApiClientTagsAndValues(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
+		return nil, err
 	}
 
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
-	}
-
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	serviceIdString, err := cmd.Flags().GetString(constants.ServiceIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	serviceId, err := uuid.Parse(serviceIdString)
+	serviceId, err := resolver.ServiceId(cmd.Context(), serviceIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service id provided")
+		return nil, err
 	}
 
 	apiClientIdString, err := cmd.Flags().GetString(constants.ApiClientIdParamName)
 	if err != nil {
-		return "", err
-	}
-	apiClientId, err := uuid.Parse(apiClientIdString)
-	if err != nil {
-		return "", errors.Wrap(err, "Invalid apiClient id provided")
+		return nil, err
 	}
-
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-	response, err := tmsClient.GetApiClientTagValues(serviceId, apiClientId)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
+	apiClientId, err := resolver.ApiClientId(cmd.Context(), serviceId, apiClientIdString)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(responseBytes), nil
+	return taClient.GetApiClientTags(cmd.Context(), serviceId, apiClientId, sdk.Metadata(md))
 }
@@ -6,18 +6,11 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -29,88 +22,59 @@ var getApiClientsCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list apiClients called")
-		response, err := getApiClients(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getApiClients(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("ApiClients: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
 func init() {
 	listCmd.AddCommand(getApiClientsCmd)
 
-	getApiClientsCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id of the Trust Authority service for which the apiClient needs to be created")
-	getApiClientsCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id of the apiClient which needs to be fetched (optional)")
+	getApiClientsCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id or name of the Trust Authority service for which the apiClient needs to be created")
+	getApiClientsCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id or name of the apiClient which needs to be fetched (optional)")
 	getApiClientsCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	getApiClientsCmd.MarkFlagRequired(constants.ServiceIdParamName)
+	getApiClientsCmd.RegisterFlagCompletionFunc(constants.ServiceIdParamName, completeServices)
+	getApiClientsCmd.RegisterFlagCompletionFunc(constants.ApiClientIdParamName, completeApiClients)
 }
 
-func getApiClients(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getApiClients(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	serviceIdString, err := cmd.Flags().GetString(constants.ServiceIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	serviceId, err := uuid.Parse(serviceIdString)
+	serviceId, err := resolver.ServiceId(cmd.Context(), serviceIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service id provided")
+		return nil, err
 	}
 
 	apiClientIdString, err := cmd.Flags().GetString(constants.ApiClientIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	var responseBytes []byte
 	if apiClientIdString == "" {
-		fmt.Println("API client ID is not set, fetching all API clients ...")
-		response, err := tmsClient.GetApiClient(serviceId)
-		if err != nil {
-			return "", err
-		}
-
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
-	} else {
-		apiClientId, err := uuid.Parse(apiClientIdString)
-		if err != nil {
-			return "", errors.Wrap(err, "Invalid apiClient id provided")
-		}
-
-		response, err := tmsClient.RetrieveApiClient(serviceId, apiClientId)
-		if err != nil {
-			return "", err
-		}
-
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "API client ID is not set, fetching all API clients ...")
+		return taClient.ListApiClients(cmd.Context(), serviceId, sdk.Metadata(md))
 	}
-
-	return string(responseBytes), nil
+	apiClientId, err := resolver.ApiClientId(cmd.Context(), serviceId, apiClientIdString)
+	if err != nil {
+		return nil, err
+	}
+	return taClient.GetApiClient(cmd.Context(), serviceId, apiClientId, sdk.Metadata(md))
 }
@@ -8,19 +8,12 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 )
 
 // getPlansCmd represents the getServices command
@@ -30,13 +23,13 @@ var getPlansCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list plan called")
-		response, err := getPlans(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getPlans(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Plans: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -46,71 +39,41 @@ func init() {
 	getPlansCmd.Flags().StringP(constants.PlanIdParamName, "p", "", "Id of the Trust Authority plan which needs to be fetched")
 	getPlansCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	getPlansCmd.MarkFlagRequired(constants.ServiceOfferIdParamName)
+	getPlansCmd.RegisterFlagCompletionFunc(constants.ServiceOfferIdParamName, completeServiceOffers)
+	getPlansCmd.RegisterFlagCompletionFunc(constants.PlanIdParamName, completePlans)
 }
 
-func getPlans(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getPlans(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
 	serviceOfferIdString, err := cmd.Flags().GetString(constants.ServiceOfferIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	serviceOfferId, err := uuid.Parse(serviceOfferIdString)
+	serviceOfferId, err := sdk.ParseId("service offer", serviceOfferIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service offer id provided")
+		return nil, err
 	}
 
 	planIdString, err := cmd.Flags().GetString(constants.PlanIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	var responseBytes []byte
 	if planIdString == "" {
-		fmt.Println("Plan ID was not provided. Listing all plans....")
-		response, err := tmsClient.GetPlans(serviceOfferId)
-		if err != nil {
-			return "", err
-		}
-
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
-	} else {
-		planId, err := uuid.Parse(planIdString)
-		if err != nil {
-			return "", errors.Wrap(err, "Invalid plan id provided")
-		}
-
-		response, err := tmsClient.RetrievePlan(serviceOfferId, planId)
-		if err != nil {
-			return "", err
-		}
-
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "Plan ID was not provided. Listing all plans....")
+		return taClient.ListPlans(cmd.Context(), serviceOfferId, sdk.Metadata(md))
 	}
-
-	return string(responseBytes), nil
+	planId, err := sdk.ParseId("plan", planIdString)
+	if err != nil {
+		return nil, err
+	}
+	return taClient.GetPlan(cmd.Context(), serviceOfferId, planId, sdk.Metadata(md))
 }
@@ -6,18 +6,10 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/pms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -29,74 +21,46 @@ var getPoliciesCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list policies called")
-		response, err := getPolicies(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getPolicies(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Policies: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
 func init() {
 	listCmd.AddCommand(getPoliciesCmd)
 
-	getPoliciesCmd.Flags().StringP(constants.PolicyIdParamName, "p", "", "Path of the file containing the policy to be uploaded")
+	getPoliciesCmd.Flags().StringP(constants.PolicyIdParamName, "p", "", "Id or name of the policy which needs to be fetched (optional)")
 	getPoliciesCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
+	getPoliciesCmd.RegisterFlagCompletionFunc(constants.PolicyIdParamName, completePolicies)
 }
 
-func getPolicies(cmd *cobra.Command) (string, error) {
-
-	configValues, err := config.LoadConfiguration()
-	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	pmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.PmsBaseUrl)
+func getPolicies(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	policyIdString, err := cmd.Flags().GetString(constants.PolicyIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	pmsClient := pms.NewPmsClient(client, pmsUrl, apiKey)
-
-	var responseBytes []byte
 	if policyIdString == "" {
-		response, err := pmsClient.SearchPolicy()
-		if err != nil {
-			return "", err
-		}
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
-	} else {
-		policyId, err := uuid.Parse(policyIdString)
-		if err != nil {
-			return "", errors.Wrap(err, "Invalid policy id provided")
-		}
-		response, err := pmsClient.GetPolicy(policyId)
-		if err != nil {
-			return "", err
-		}
-
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
+		return taClient.ListPolicies(cmd.Context(), sdk.Metadata(md))
 	}
-
-	return string(responseBytes), nil
+	policyId, err := resolver.PolicyId(cmd.Context(), policyIdString)
+	if err != nil {
+		return nil, err
+	}
+	return taClient.GetPolicy(cmd.Context(), policyId, sdk.Metadata(md))
 }
@@ -6,18 +6,10 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -29,13 +21,13 @@ var getProductsCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list products called")
-		response, err := getProducts(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getProducts(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Products: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -46,47 +38,27 @@ func init() {
 		"service offer for which the product list needs to be fetched")
 	getProductsCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	getProductsCmd.MarkFlagRequired(constants.ServiceOfferIdParamName)
+	getProductsCmd.RegisterFlagCompletionFunc(constants.ServiceOfferIdParamName, completeServiceOffers)
 }
 
-func getProducts(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getProducts(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
 	serviceOfferIdString, err := cmd.Flags().GetString(constants.ServiceOfferIdParamName)
 	if err != nil {
-		return "", err
-	}
-
-	serviceOfferId, err := uuid.Parse(serviceOfferIdString)
-	if err != nil {
-		return "", errors.Wrap(err, "Invalid service offer id provided")
-	}
-
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	response, err := tmsClient.GetProducts(serviceOfferId)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
+	serviceOfferId, err := sdk.ParseId("service offer", serviceOfferIdString)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(responseBytes), nil
+	return taClient.ListProducts(cmd.Context(), serviceOfferId, sdk.Metadata(md))
 }
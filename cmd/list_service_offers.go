@@ -6,16 +6,10 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -27,13 +21,13 @@ var getServiceOffersCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list serviceOffers called")
-		response, err := getServiceOffers(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getServiceOffers(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Service offers: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -42,35 +36,15 @@ func init() {
 	getServiceOffersCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 }
 
-func getServiceOffers(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getServiceOffers(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
-	}
-
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	response, err := tmsClient.GetServiceOffers()
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
-	return string(responseBytes), nil
+	return taClient.ListServiceOffers(cmd.Context(), sdk.Metadata(md))
 }
@@ -6,18 +6,11 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -29,76 +22,47 @@ var getServicesCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list services called")
-		response, err := getServices(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getServices(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Services: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
 func init() {
 	listCmd.AddCommand(getServicesCmd)
 
-	getServicesCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id of the Trust Authority service which needs to be fetched")
+	getServicesCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id or name of the Trust Authority service which needs to be fetched")
 	getServicesCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
+	getServicesCmd.RegisterFlagCompletionFunc(constants.ServiceIdParamName, completeServices)
 }
 
-func getServices(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getServices(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	serviceIdString, err := cmd.Flags().GetString(constants.ServiceIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	var responseBytes []byte
 	if serviceIdString == "" {
-		fmt.Println("Service ID was not provided, listing all services....")
-		response, err := tmsClient.GetServices()
-		if err != nil {
-			return "", err
-		}
-
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
-	} else {
-		serviceId, err := uuid.Parse(serviceIdString)
-		if err != nil {
-			return "", errors.Wrap(err, "Invalid service id provided")
-		}
-
-		response, err := tmsClient.RetrieveService(serviceId)
-		if err != nil {
-			return "", err
-		}
-
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "Service ID was not provided, listing all services....")
+		return taClient.ListServices(cmd.Context(), sdk.Metadata(md))
 	}
-
-	return string(responseBytes), nil
+	serviceId, err := resolver.ServiceId(cmd.Context(), serviceIdString)
+	if err != nil {
+		return nil, err
+	}
+	return taClient.GetService(cmd.Context(), serviceId, sdk.Metadata(md))
 }
@@ -6,17 +6,11 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 )
 
 var listTagCmd = &cobra.Command{
@@ -25,13 +19,13 @@ var listTagCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list tag called")
-		response, err := getTag(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getTag(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Tags: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -40,35 +34,15 @@ func init() {
 	listTagCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 }
 
-func getTag(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getTag(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
-	}
-
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	response, err := tmsClient.GetTenantTags()
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
-	return string(responseBytes), nil
+	return taClient.ListTags(cmd.Context(), sdk.Metadata(md))
 }
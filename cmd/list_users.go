@@ -6,18 +6,11 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/utils"
-	"intel/tac/v1/validation"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -29,13 +22,13 @@ var getUsersCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("list users called")
-		response, err := getUsers(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := getUsers(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Users: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
@@ -46,63 +39,24 @@ func init() {
 	getUsersCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 }
 
-func getUsers(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func getUsers(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
 	emailIdString, err := cmd.Flags().GetString(constants.EmailIdParamName)
 	if err != nil {
-		return "", err
-	}
-
-	//Validate the email id before making a remote request
-	if emailIdString != "" {
-		err = validation.ValidateEmailAddress(emailIdString)
-		if err != nil {
-			return "", err
-		}
-	}
-
-	var responseBytes []byte
-	response, err := tmsClient.GetUsers()
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if emailIdString != "" {
-		for _, user := range response {
-			if user.Email == emailIdString {
-				responseBytes, err = json.MarshalIndent(user, "", "  ")
-				if err != nil {
-					return "", err
-				}
-				return string(responseBytes), nil
-			}
-		}
-		return "", errors.New("User associated with the email Id provided in input was not found")
-	} else {
-		fmt.Println("Email ID was not provided, listing all users....")
-		responseBytes, err = json.MarshalIndent(response, "", "  ")
-		if err != nil {
-			return "", err
-		}
-
-		return string(responseBytes), nil
+	if emailIdString == "" {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Email ID was not provided, listing all users....")
+		return taClient.ListUsers(cmd.Context(), sdk.Metadata(md))
 	}
+	return taClient.FindUserByEmail(cmd.Context(), emailIdString, sdk.Metadata(md))
 }
@@ -0,0 +1,96 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/validation"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// mockServerCmd represents the mock-server command
+var mockServerCmd = &cobra.Command{
+	Use:   constants.MockServerCmd,
+	Short: "Run a mock of the Trust Authority management API with an in-memory state",
+	Long: `Run a mock of the Trust Authority management API with an in-memory state, for testing scripts and CI
+pipelines without a live tenant. Created resources persist until they are deleted or the server stops, and the
+api client and policy limits of the plan of the services are enforced. Any API key is accepted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMockServer(cmd)
+	},
+}
+
+func init() {
+	tenantCmd.AddCommand(mockServerCmd)
+
+	mockServerCmd.Flags().String(constants.HostParamName, constants.DefaultMockServerHost, "Host or IP address the mock server listens on")
+	mockServerCmd.Flags().IntP(constants.PortParamName, "p", constants.DefaultMockServerPort, "Port the mock server listens on, 0 picks a free port")
+	mockServerCmd.Flags().StringP(constants.SeedFileParamName, "f", "", "Path of the YAML or JSON file holding the initial state "+
+		"of the mock server, a service of a Basic plan is created when it is not provided")
+}
+
+func runMockServer(cmd *cobra.Command) error {
+	host, err := cmd.Flags().GetString(constants.HostParamName)
+	if err != nil {
+		return err
+	}
+	port, err := cmd.Flags().GetInt(constants.PortParamName)
+	if err != nil {
+		return err
+	}
+	if port < 0 || port > 65535 {
+		return validation.NewInputError(errors.Errorf("Invalid port %d", port))
+	}
+	seedFile, err := cmd.Flags().GetString(constants.SeedFileParamName)
+	if err != nil {
+		return err
+	}
+
+	var seed *mockserver.Seed
+	if seedFile != "" {
+		if seed, err = mockserver.LoadSeed(seedFile); err != nil {
+			return validation.NewInputError(err)
+		}
+	}
+	server, err := mockserver.New(seed)
+	if err != nil {
+		return validation.NewInputError(err)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return errors.Wrap(err, "Failed to start mock server")
+	}
+	httpServer := &http.Server{Handler: server.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	fmt.Fprintf(cmd.OutOrStdout(), "Mock Trust Authority server listening on http://%s\n", listener.Addr().String())
+	log.Infof("Mock Trust Authority server listening on %s", listener.Addr().String())
+
+	// the server stops on SIGINT or SIGTERM, once the requests in progress are answered
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+	select {
+	case err = <-serveErr:
+		return errors.Wrap(err, "Mock server failed")
+	case <-cmd.Context().Done():
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = httpServer.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "Failed to stop mock server")
+	}
+	return nil
+}
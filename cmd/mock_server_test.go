@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMockServerCmd(t *testing.T) {
+	seedFile := filepath.Join(t.TempDir(), "seed.yaml")
+	assert.NoError(t, os.WriteFile(seedFile, []byte("services: [{name: Orphan}]"), 0600))
+
+	tt := []struct {
+		args        []string
+		description string
+	}{
+		{
+			args:        []string{constants.MockServerCmd, "--" + constants.PortParamName, "70000"},
+			description: "Test an invalid port",
+		},
+		{
+			args:        []string{constants.MockServerCmd, "--" + constants.SeedFileParamName, filepath.Join(t.TempDir(), "missing.yaml")},
+			description: "Test a missing seed file",
+		},
+		{
+			args:        []string{constants.MockServerCmd, "--" + constants.SeedFileParamName, seedFile},
+			description: "Test an invalid seed file",
+		},
+	}
+	t.Cleanup(func() {
+		assert.NoError(t, mockServerCmd.Flags().Set(constants.PortParamName, "8080"))
+		assert.NoError(t, mockServerCmd.Flags().Set(constants.SeedFileParamName, ""))
+	})
+
+	for _, tc := range tt {
+		_, err := execute(t, tenantCmd, tc.args)
+		assert.True(t, validation.IsInputError(err), tc.description)
+	}
+}
+
+func TestMockServerEndToEnd(t *testing.T) {
+	useStatefulMockServer(t, nil)
+
+	// the policies and tags of the api clients created by the previous tests are not linked to this one
+	resetLocalFlags(t, createApiClientCmd)
+
+	seed := mockserver.DefaultSeed()
+	serviceId, productId := seed.Services[0].ID.String(), seed.Products[0].ID.String()
+	tenantCmd.AddCommand(createCmd)
+	tenantCmd.AddCommand(listCmd)
+	tenantCmd.AddCommand(deleteCmd)
+
+	output, err := executeStdout(t, tenantCmd, []string{constants.CreateCmd, constants.ApiClientCmd, "-r", serviceId,
+		"-p", productId, "-n", "e2e-client", "-q", "e2e-test", "-o", "json"})
+	assert.NoError(t, err)
+	var apiClient models.ApiClientDetail
+	assert.NoError(t, json.Unmarshal([]byte(output), &apiClient))
+
+	output, err = executeStdout(t, tenantCmd, []string{constants.ListCmd, constants.ApiClientCmd, "-r", serviceId, "-c", "",
+		"-q", "e2e-test", "-o", "json"})
+	assert.NoError(t, err)
+	assert.Contains(t, output, apiClient.ID.String(), "Test the api client created is listed")
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.DeleteCmd, constants.ApiClientCmd, "-r", serviceId,
+		"-c", apiClient.ID.String(), "-q", "e2e-test"})
+	assert.NoError(t, err)
+
+	output, err = executeStdout(t, tenantCmd, []string{constants.ListCmd, constants.ApiClientCmd, "-r", serviceId, "-c", "",
+		"-q", "e2e-test", "-o", "json"})
+	assert.NoError(t, err)
+	assert.NotContains(t, output, apiClient.ID.String(), "Test the api client deleted is not listed")
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/printer"
+	"text/tabwriter"
+)
+
+var (
+	outputFormat string
+)
+
+// printResponse writes the response to the command output in the format selected with the --output flag
+func printResponse(cmd *cobra.Command, response interface{}) error {
+	return printer.Print(cmd.OutOrStdout(), outputFormat, response)
+}
+
+// printFooter writes the IDs correlating the command with the Trust Authority logs to stderr once the response is
+// printed, so that they do not mix with the response. The trace ID returned by Trust Authority is printed when
+// available, otherwise the one of the traceparent sent with the request.
+func printFooter(cmd *cobra.Command, md *client.RequestMetadata) {
+	if !md.Received {
+		// no request reached Trust Authority, e.g. the command failed beforehand or was served from the cache
+		return
+	}
+	traceId := md.TraceId
+	if traceId == "" {
+		if tp, err := client.ParseTraceParent(md.TraceParent); err == nil {
+			traceId = tp.TraceIdString()
+		}
+	}
+	w := tabwriter.NewWriter(cmd.ErrOrStderr(), 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%s:\t%s\n", constants.HTTPHeaderKeyRequestId, md.RequestId)
+	if traceId != "" {
+		fmt.Fprintf(w, "%s:\t%s\n", constants.HTTPHeaderKeyTraceId, traceId)
+	}
+	if md.TraceParent != "" {
+		fmt.Fprintf(w, "%s:\t%s\n", constants.HTTPHeaderKeyTraceParent, md.TraceParent)
+	}
+	_ = w.Flush()
+}
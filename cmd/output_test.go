@@ -0,0 +1,141 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/printer"
+	"intel/tac/v1/test"
+	"io"
+	"testing"
+)
+
+// useMockServer points the CLI to the mock server for the duration of the test and resets the output format
+func useMockServer(t *testing.T, serverUrl string) {
+	baseUrl := viper.GetString(constants.TrustAuthBaseUrl)
+	viper.Set(constants.TrustAuthBaseUrl, serverUrl)
+	t.Cleanup(func() {
+		if baseUrl == "" {
+			// no URL was configured yet, clearing the override lets the configuration written by the following tests apply
+			viper.Set(constants.TrustAuthBaseUrl, nil)
+		} else {
+			viper.Set(constants.TrustAuthBaseUrl, baseUrl)
+		}
+		outputFormat = printer.Table
+	})
+}
+
+// executeStdout runs the command and returns only what was written to the command output, leaving out the
+// informational messages written to stderr
+func executeStdout(t *testing.T, c *cobra.Command, args []string) (string, error) {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(io.Discard)
+	c.SetArgs(args)
+
+	err := c.Execute()
+	return buf.String(), err
+}
+
+func TestOutputFormats(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+
+	listCmd.AddCommand(getPlansCmd)
+	tenantCmd.AddCommand(listCmd)
+
+	args := []string{constants.ListCmd, constants.PlanCmd, "-q", "output-test", "-r", "ee28f3c2-6f58-489d-aa46-1140565d4718", "-p", ""}
+
+	output, err := executeStdout(t, tenantCmd, append(args, "-o", printer.JSON))
+	assert.NoError(t, err)
+	var plans []map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(output), &plans))
+	assert.Equal(t, "Basic", plans[0]["name"])
+
+	output, err = executeStdout(t, tenantCmd, append(args, "-o", printer.YAML))
+	assert.NoError(t, err)
+	plans = nil
+	assert.NoError(t, yaml.Unmarshal([]byte(output), &plans))
+	assert.Equal(t, "8f2a20fa-b08d-48a8-b2b4-2ebd1feb6f74", plans[0]["id"])
+
+	output, err = executeStdout(t, tenantCmd, append(args, "-o", printer.Table))
+	assert.NoError(t, err)
+	assert.Contains(t, output, "MAX KEYS")
+	assert.NotContains(t, output, "LEDGER")
+
+	output, err = executeStdout(t, tenantCmd, append(args, "-o", printer.Wide))
+	assert.NoError(t, err)
+	assert.Contains(t, output, "LEDGER")
+
+	_, err = executeStdout(t, tenantCmd, append(args, "-o", "xml"))
+	assert.Error(t, err)
+}
+
+func TestOutputTemplates(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+
+	listCmd.AddCommand(getApiClientsCmd)
+	listCmd.AddCommand(getPoliciesCmd)
+	tenantCmd.AddCommand(listCmd)
+
+	tt := []struct {
+		args        []string
+		want        string
+		wantErr     bool
+		description string
+	}{
+		{
+			args: []string{constants.ListCmd, constants.ApiClientCmd, "-q", "output-test", "-r", "5cfb6af4-59ac-4a14-8b83-bd65b1e11777", "-c",
+				"3780cc39-cce2-4ec2-a47f-03e55b12e259", "-o", "jsonpath={.keys[0]}"},
+			want:        "9dca50986c414304a4b1ffe202dcf2b0",
+			description: "Test JSONPath selecting the api client key",
+		},
+		{
+			args:        []string{constants.ListCmd, constants.PolicyCmd, "-q", "output-test", "-p", "", "-o", "jsonpath={.[*].policy_id}"},
+			want:        "e48dabc5-9608-4ff3-aaed-f25909ab9de1",
+			description: "Test JSONPath selecting the policy IDs",
+		},
+		{
+			args:        []string{constants.ListCmd, constants.PolicyCmd, "-q", "output-test", "-p", "", "-o", "go-template={{range .}}{{.policy_name}}{{end}}"},
+			want:        "Sample_Policy_SGX",
+			description: "Test go-template output",
+		},
+		{
+			args:        []string{constants.ListCmd, constants.PolicyCmd, "-q", "output-test", "-p", "", "-o", "jsonpath={.[*].unknown}"},
+			wantErr:     true,
+			description: "Test JSONPath with a missing field",
+		},
+		{
+			args:        []string{constants.ListCmd, constants.PolicyCmd, "-q", "output-test", "-p", "", "-o", "go-template={{.policy_name"},
+			wantErr:     true,
+			description: "Test invalid go-template",
+		},
+	}
+
+	for _, tc := range tt {
+		output, err := executeStdout(t, tenantCmd, tc.args)
+
+		if tc.wantErr == true {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, tc.want, output, tc.description)
+		}
+	}
+}
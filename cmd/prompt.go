@@ -0,0 +1,510 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// shellSafeValue matches the flag values which are printed without quotes in the equivalent command
+var shellSafeValue = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// stdinIsTerminal tells whether the missing flags can be prompted for, the tests replace it
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// option is an entry of a pick-list, the value set in the flag along with its description
+type option struct {
+	value       string
+	description string
+}
+
+// flagPrompt asks for the values of a flag missing from the command line, no value leaves the flag unset
+type flagPrompt struct {
+	flag string
+	ask  func(p *prompter) ([]string, error)
+}
+
+// prompter reads the answers to the prompts from the input of the command
+type prompter struct {
+	cmd      *cobra.Command
+	in       *bufio.Reader
+	out      io.Writer
+	taClient *sdk.Client
+	resolver *sdk.Resolver
+}
+
+// promptMissingFlags makes the command prompt for its missing required flags instead of failing when it runs on a
+// terminal. The prompts ask for the flags in order with pick-lists fetched from the tenant and validate the typed
+// values, the optional flags they ask for are only prompted for along with a required one. The required flags
+// without prompt are typed last.
+func promptMissingFlags(cmd *cobra.Command, prompts ...flagPrompt) {
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return runPrompts(cmd, prompts)
+	}
+}
+
+func runPrompts(cmd *cobra.Command, prompts []flagPrompt) error {
+	var missing []flagPrompt
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if isRequiredFlag(flag) && !flag.Changed {
+			missing = append(missing, typedPrompt(flag.Name, "", nil))
+		}
+	})
+	if len(missing) == 0 || !stdinIsTerminal() {
+		return nil
+	}
+
+	p := &prompter{cmd: cmd, in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+	prompted := map[string]bool{}
+	for _, prompt := range append(append([]flagPrompt{}, prompts...), missing...) {
+		if prompted[prompt.flag] || cmd.Flags().Changed(prompt.flag) {
+			continue
+		}
+		prompted[prompt.flag] = true
+		values, err := prompt.ask(p)
+		if err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if err = cmd.Flags().Set(prompt.flag, strings.Join(values, ",")); err != nil {
+			return validation.NewInputError(err)
+		}
+	}
+	fmt.Fprintf(p.out, "\nEquivalent command:\n  %s\n\n", equivalentCommand(cmd))
+	return nil
+}
+
+func isRequiredFlag(flag *pflag.Flag) bool {
+	required := flag.Annotations[cobra.BashCompOneRequiredFlag]
+	return len(required) == 1 && required[0] == "true"
+}
+
+// equivalentCommand returns the command line running the command with the flags provided or prompted for
+func equivalentCommand(cmd *cobra.Command) string {
+	args := []string{cmd.CommandPath()}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Changed {
+			return
+		}
+		value := flag.Value.String()
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			value = strings.Join(sliceValue.GetSlice(), ",")
+		}
+		args = append(args, "--"+flag.Name, shellQuote(value))
+	})
+	return strings.Join(args, " ")
+}
+
+func shellQuote(value string) string {
+	if shellSafeValue.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// client returns the Trust Authority client listing the pick-lists, it is created once a pick-list is needed
+func (p *prompter) client() (*sdk.Client, error) {
+	if p.taClient == nil {
+		taClient, err := newTrustAuthorityClient()
+		if err != nil {
+			return nil, err
+		}
+		p.taClient, p.resolver = taClient, sdk.NewResolver(taClient)
+	}
+	return p.taClient, nil
+}
+
+// readLine prints the question and returns the answer without the surrounding spaces
+func (p *prompter) readLine(question string) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", question)
+	answer, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		fmt.Fprintln(p.out)
+		return "", validation.NewInputError(errors.New("No answer provided to the prompt"))
+	}
+	return strings.TrimSpace(answer), nil
+}
+
+// text asks to type a value until it is accepted by validate
+func (p *prompter) text(label string, validate func(string) error) (string, error) {
+	for {
+		answer, err := p.readLine(label)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case answer == "":
+			fmt.Fprintln(p.out, "A value is required")
+		case validate == nil:
+			return answer, nil
+		default:
+			if err = validate(answer); err == nil {
+				return answer, nil
+			}
+			fmt.Fprintln(p.out, err.Error())
+		}
+	}
+}
+
+// pick asks to select one of the options, or several of them separated by commas when multiple is set, until the
+// selection is valid. An option is selected by its number, value or description. Selecting several options is
+// optional.
+func (p *prompter) pick(label string, options []option, multiple bool) ([]string, error) {
+	fmt.Fprintf(p.out, "%s:\n", label)
+	for i, o := range options {
+		if o.description == "" || o.description == o.value {
+			fmt.Fprintf(p.out, "  %d) %s\n", i+1, o.value)
+		} else {
+			fmt.Fprintf(p.out, "  %d) %s (%s)\n", i+1, o.description, o.value)
+		}
+	}
+	question := "Select a number"
+	if multiple {
+		question = "Select numbers separated by commas (press enter to skip)"
+	}
+	for {
+		answer, err := p.readLine(question)
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" && multiple {
+			return nil, nil
+		}
+		values, err := selectOptions(answer, options, multiple)
+		if err == nil {
+			return values, nil
+		}
+		fmt.Fprintln(p.out, err.Error())
+	}
+}
+
+func selectOptions(answer string, options []option, multiple bool) ([]string, error) {
+	selections := strings.Split(answer, ",")
+	if len(selections) > 1 && !multiple {
+		return nil, errors.New("Only one option can be selected")
+	}
+	var values []string
+	for _, selection := range selections {
+		selection = strings.TrimSpace(selection)
+		value, ok := "", false
+		if n, err := strconv.Atoi(selection); err == nil && n >= 1 && n <= len(options) {
+			value, ok = options[n-1].value, true
+		}
+		for _, o := range options {
+			if !ok && (selection == o.value || selection == o.description) {
+				value, ok = o.value, true
+			}
+		}
+		if !ok {
+			return nil, errors.Errorf("Invalid selection %q, should be a number between 1 and %d", selection, len(options))
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// typedPrompt asks to type the value of the flag, checked by validate when provided
+func typedPrompt(flag, label string, validate func(string) error) flagPrompt {
+	if label == "" {
+		label = "--" + flag
+	}
+	return flagPrompt{flag: flag, ask: func(p *prompter) ([]string, error) {
+		value, err := p.text(label, validate)
+		return []string{value}, err
+	}}
+}
+
+// choicePrompt asks to pick one of the values of the flag
+func choicePrompt(flag, label string, choices ...string) flagPrompt {
+	return flagPrompt{flag: flag, ask: func(p *prompter) ([]string, error) {
+		var options []option
+		for _, choice := range choices {
+			options = append(options, option{value: choice})
+		}
+		return p.pick(label, options, false)
+	}}
+}
+
+// listPrompt asks to pick the values of the flag among the options listed from the tenant, several values are
+// picked when multiple is set. The value is typed when no option is listed for a single value.
+func listPrompt(flag, label string, multiple bool, list func(p *prompter) ([]option, error)) flagPrompt {
+	return flagPrompt{flag: flag, ask: func(p *prompter) ([]string, error) {
+		options, err := list(p)
+		if err != nil {
+			return nil, err
+		}
+		if len(options) != 0 {
+			return p.pick(label, options, multiple)
+		}
+		if multiple {
+			return nil, nil
+		}
+		value, err := p.text(label, nil)
+		return []string{value}, err
+	}}
+}
+
+// servicePrompt picks a service of the tenant
+func servicePrompt() flagPrompt {
+	return listPrompt(constants.ServiceIdParamName, "Service", false, func(p *prompter) ([]option, error) {
+		taClient, err := p.client()
+		if err != nil {
+			return nil, err
+		}
+		services, err := taClient.ListServices(p.cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, service := range services {
+			options = append(options, option{value: service.ID.String(), description: service.Name})
+		}
+		return options, nil
+	})
+}
+
+// serviceOfferPrompt picks a service offer of Trust Authority
+func serviceOfferPrompt() flagPrompt {
+	return listPrompt(constants.ServiceOfferIdParamName, "Service offer", false, func(p *prompter) ([]option, error) {
+		taClient, err := p.client()
+		if err != nil {
+			return nil, err
+		}
+		serviceOffers, err := taClient.ListServiceOffers(p.cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, serviceOffer := range serviceOffers {
+			options = append(options, option{value: serviceOffer.ID.String(), description: serviceOffer.Name})
+		}
+		return options, nil
+	})
+}
+
+// productPrompt picks a product of the service offer of the service provided with --service-id
+func productPrompt() flagPrompt {
+	return listPrompt(constants.ProductIdParamName, "Product", false, func(p *prompter) ([]option, error) {
+		serviceOfferId, err := p.serviceOfferId()
+		if err != nil {
+			return nil, err
+		}
+		products, err := p.taClient.ListProducts(p.cmd.Context(), serviceOfferId)
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, product := range products {
+			options = append(options, option{value: product.ID.String(), description: product.Name})
+		}
+		return options, nil
+	})
+}
+
+// policiesPrompt picks the policies of the service offer of the service provided with --service-id. The
+// attestation type of the policies is picked first when they are of several types.
+func policiesPrompt() flagPrompt {
+	return listPrompt(constants.PolicyIdsParamName, "Policies", true, func(p *prompter) ([]option, error) {
+		serviceOfferId, err := p.serviceOfferId()
+		if err != nil {
+			return nil, err
+		}
+		policies, err := p.taClient.ListPolicies(p.cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var attestationTypes []option
+		listed := map[string]bool{}
+		for _, policy := range policies {
+			if policy.ServiceOfferId == serviceOfferId && !listed[policy.AttestationType] {
+				listed[policy.AttestationType] = true
+				attestationTypes = append(attestationTypes, option{value: policy.AttestationType})
+			}
+		}
+		attestationType := ""
+		if len(attestationTypes) > 1 {
+			selected, err := p.pick("Attestation type of the policies", attestationTypes, false)
+			if err != nil {
+				return nil, err
+			}
+			attestationType = selected[0]
+		}
+
+		var options []option
+		for _, policy := range policies {
+			if policy.ServiceOfferId == serviceOfferId && (attestationType == "" || policy.AttestationType == attestationType) {
+				options = append(options, option{value: policy.PolicyId.String(), description: policy.PolicyName})
+			}
+		}
+		return options, nil
+	})
+}
+
+// policyPrompt picks a policy of the tenant
+func policyPrompt(flag string) flagPrompt {
+	return listPrompt(flag, "Policy", false, func(p *prompter) ([]option, error) {
+		taClient, err := p.client()
+		if err != nil {
+			return nil, err
+		}
+		policies, err := taClient.ListPolicies(p.cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, policy := range policies {
+			options = append(options, option{value: policy.PolicyId.String(), description: policy.PolicyName})
+		}
+		return options, nil
+	})
+}
+
+// tagValuesPrompt picks the tags of an api client and asks for their values
+func tagValuesPrompt() flagPrompt {
+	return flagPrompt{flag: constants.TagKeyAndValuesParamName, ask: func(p *prompter) ([]string, error) {
+		taClient, err := p.client()
+		if err != nil {
+			return nil, err
+		}
+		tags, err := taClient.ListTags(p.cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, tag := range tags.Tags {
+			options = append(options, option{value: tag.Name})
+		}
+		if len(options) == 0 {
+			return nil, nil
+		}
+		names, err := p.pick("Tags", options, true)
+		if err != nil {
+			return nil, err
+		}
+		var tagValues []string
+		for _, name := range names {
+			value, err := p.text("Value of tag "+name, validation.ValidateTagValue)
+			if err != nil {
+				return nil, err
+			}
+			tagValues = append(tagValues, name+":"+value)
+		}
+		return tagValues, nil
+	}}
+}
+
+// userTagPrompt picks a user defined tag of the tenant
+func userTagPrompt() flagPrompt {
+	return listPrompt(constants.TagIdParamName, "Tag", false, func(p *prompter) ([]option, error) {
+		taClient, err := p.client()
+		if err != nil {
+			return nil, err
+		}
+		tags, err := taClient.ListTags(p.cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, tag := range tags.Tags {
+			if !tag.Predefined && tag.ID != nil {
+				options = append(options, option{value: tag.ID.String(), description: tag.Name})
+			}
+		}
+		return options, nil
+	})
+}
+
+// userPrompt picks a user of the tenant
+func userPrompt() flagPrompt {
+	return listPrompt(constants.UserIdParamName, "User", false, func(p *prompter) ([]option, error) {
+		taClient, err := p.client()
+		if err != nil {
+			return nil, err
+		}
+		users, err := taClient.ListUsers(p.cmd.Context())
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, user := range users {
+			options = append(options, option{value: user.ID.String(), description: user.Email})
+		}
+		return options, nil
+	})
+}
+
+// apiClientPrompt picks an api client of the service provided with --service-id
+func apiClientPrompt() flagPrompt {
+	return listPrompt(constants.ApiClientIdParamName, "Api client", false, func(p *prompter) ([]option, error) {
+		if _, err := p.client(); err != nil {
+			return nil, err
+		}
+		serviceRef, _ := p.cmd.Flags().GetString(constants.ServiceIdParamName)
+		serviceId, err := p.resolver.ServiceId(p.cmd.Context(), serviceRef)
+		if err != nil {
+			return nil, err
+		}
+		apiClients, err := p.taClient.ListApiClients(p.cmd.Context(), serviceId)
+		if err != nil {
+			return nil, err
+		}
+		var options []option
+		for _, apiClient := range apiClients {
+			options = append(options, option{value: apiClient.ID.String(), description: apiClient.Name})
+		}
+		return options, nil
+	})
+}
+
+// serviceOfferId returns the service offer of the service provided with --service-id
+func (p *prompter) serviceOfferId() (uuid.UUID, error) {
+	taClient, err := p.client()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	serviceRef, _ := p.cmd.Flags().GetString(constants.ServiceIdParamName)
+	serviceId, err := p.resolver.ServiceId(p.cmd.Context(), serviceRef)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	services, err := taClient.ListServices(p.cmd.Context())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, service := range services {
+		if service.ID == serviceId {
+			return service.ServiceOfferId, nil
+		}
+	}
+	return uuid.Nil, errors.Errorf("Service %s was not found", serviceId)
+}
+
+// validatePolicyFile checks the path and size of the rego policy file
+func validatePolicyFile(path string) error {
+	path, err := validation.ValidatePath(path)
+	if err != nil {
+		return err
+	}
+	return validation.ValidateSize(path)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+	"strings"
+	"testing"
+)
+
+func TestPromptMissingFlags(t *testing.T) {
+	seed := mockserver.DefaultSeed()
+	sgxPolicyId, tdxPolicyId := uuid.New(), uuid.New()
+	seed.Policies = []models.PolicyResponse{
+		{CommonPolicy: models.CommonPolicy{PolicyId: sgxPolicyId, PolicyName: "sgx-policy",
+			Policy: "default matches_sgx_policy = false", PolicyType: constants.AppraisalPolicyType,
+			ServiceOfferId: seed.ServiceOffers[0].ID, AttestationType: constants.SgxAttestationType}},
+		{CommonPolicy: models.CommonPolicy{PolicyId: tdxPolicyId, PolicyName: "tdx-policy",
+			Policy: "default matches_tdx_policy = false", PolicyType: constants.AppraisalPolicyType,
+			ServiceOfferId: seed.ServiceOffers[0].ID, AttestationType: constants.TdxAttestationType}},
+	}
+	useStatefulMockServer(t, seed)
+	t.Setenv("HOME", t.TempDir())
+	defaultStdinIsTerminal := stdinIsTerminal
+	stdinIsTerminal = func() bool { return true }
+	t.Cleanup(func() {
+		stdinIsTerminal = defaultStdinIsTerminal
+		tenantCmd.SetIn(nil)
+		resetLocalFlags(t, createApiClientCmd)
+		resetLocalFlags(t, createUserCmd)
+	})
+
+	tt := []struct {
+		cmd         *cobra.Command
+		args        []string
+		input       string
+		wantErr     bool
+		wantOutput  []string
+		description string
+	}{
+		{
+			cmd:   createApiClientCmd,
+			args:  []string{constants.CreateCmd, constants.ApiClientCmd, "-q", "prompt-test", "-o", "table"},
+			input: "1\n1\ninvalid client\nci-client\n" + constants.TdxAttestationType + "\n1\n1\nWorkloadAI\n",
+			wantOutput: []string{"Api client name: ApiClient name should be alphanumeric",
+				"1) tdx-policy (" + tdxPolicyId.String() + ")",
+				"Equivalent command:\n  trustauthorityctl create apiClient --api-client-name ci-client --output table --policy-ids " +
+					tdxPolicyId.String() + " --product-id " + seed.Products[0].ID.String() + " --request-id prompt-test" +
+					" --service-id " + seed.Services[0].ID.String() + " --tag-key-value Workload:WorkloadAI"},
+			description: "Test prompting for the flags of an api client",
+		},
+		{
+			cmd:   createApiClientCmd,
+			args:  []string{constants.CreateCmd, constants.ApiClientCmd, "-q", "prompt-test", "-o", "table", "-r", "Attestation", "-p", "Developer"},
+			input: "other-client\n1\n\n\n",
+			wantOutput: []string{"Equivalent command:\n  trustauthorityctl create apiClient --api-client-name other-client " +
+				"--output table --product-id Developer --request-id prompt-test --service-id Attestation\n"},
+			description: "Test prompting for the missing flags only",
+		},
+		{
+			cmd:         createUserCmd,
+			args:        []string{constants.CreateCmd, constants.UserCmd, "-q", "prompt-test"},
+			input:       "admin\nadmin@example.com\n3\n2\n",
+			wantOutput:  []string{"Invalid email id provided", "Invalid selection \"3\"", "--user-role User"},
+			description: "Test prompting for a user with a pick-list of roles",
+		},
+		{
+			cmd:         createUserCmd,
+			args:        []string{constants.CreateCmd, constants.UserCmd, "-q", "prompt-test"},
+			input:       "other@example.com\n",
+			wantErr:     true,
+			description: "Test running out of answers",
+		},
+	}
+
+	for _, tc := range tt {
+		resetLocalFlags(t, tc.cmd)
+		tenantCmd.SetIn(strings.NewReader(tc.input))
+		output, err := execute(t, tenantCmd, tc.args)
+		if tc.wantErr {
+			assert.True(t, validation.IsInputError(err), tc.description)
+			continue
+		}
+		assert.NoError(t, err, tc.description)
+		for _, wantOutput := range tc.wantOutput {
+			assert.Contains(t, output, wantOutput, tc.description)
+		}
+	}
+
+	stdinIsTerminal = func() bool { return false }
+	resetLocalFlags(t, createUserCmd)
+	_, err := execute(t, tenantCmd, []string{constants.CreateCmd, constants.UserCmd, "-q", "prompt-test"})
+	assert.ErrorContains(t, err, "required flag(s)", "Test missing flags without terminal")
+}
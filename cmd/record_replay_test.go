@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/test"
+	"intel/tac/v1/validation"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	server := test.MockServer(t)
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+	cassette := t.TempDir()
+	defaultApiKey := apiKey
+	apiKey = "recorded-api-key"
+	t.Cleanup(func() {
+		apiKey = defaultApiKey
+	})
+
+	tenantCmd.AddCommand(listCmd)
+	tenantCmd.AddCommand(createCmd)
+	serviceId, apiClientId := uuid.NewString(), uuid.NewString()
+	commands := [][]string{
+		{constants.ListCmd, constants.PolicyCmd, "-q", "record-test", "-o", "json"},
+		{constants.ListCmd, constants.ApiClientCmd, "-r", serviceId, "-c", apiClientId, "-q", "record-test", "-o", "json"},
+		{constants.CreateCmd, constants.TagCmd, "-n", "record-test", "-q", "record-test", "-o", "json"},
+	}
+	t.Cleanup(func() {
+		assert.NoError(t, getApiClientsCmd.Flags().Set(constants.ApiClientIdParamName, ""))
+	})
+
+	// record the responses of the mock server
+	setGlobalFlag(t, constants.Record, cassette)
+	var recorded []string
+	for _, args := range commands {
+		output, err := executeStdout(t, tenantCmd, args)
+		assert.NoError(t, err)
+		recorded = append(recorded, output)
+	}
+	assert.NoError(t, tenantCmd.PersistentFlags().Set(constants.Record, ""))
+	server.Close()
+
+	files, err := filepath.Glob(filepath.Join(cassette, "*.json"))
+	assert.NoError(t, err)
+	assert.Len(t, files, len(commands))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(content), "recorded-api-key", "Test API key is redacted")
+		assert.NotContains(t, string(content), "9dca50986c414304a4b1ffe202dcf2b0", "Test api client keys are redacted")
+	}
+
+	// replay them once the server is gone
+	setGlobalFlag(t, constants.Replay, cassette)
+	for i, args := range commands {
+		output, err := executeStdout(t, tenantCmd, args)
+		assert.NoError(t, err)
+		if i == 1 {
+			assert.Contains(t, output, "REDACTED")
+			continue
+		}
+		assert.Equal(t, recorded[i], output)
+	}
+
+	_, err = executeStdout(t, tenantCmd, []string{constants.ListCmd, constants.TagCmd, "-q", "record-test"})
+	if assert.Error(t, err, "Test request which was not recorded") {
+		assert.True(t, strings.Contains(err.Error(), "No recorded response left"))
+	}
+
+	setGlobalFlag(t, constants.Record, t.TempDir())
+	_, err = executeStdout(t, tenantCmd, commands[0])
+	assert.True(t, validation.IsInputError(err), "Test recording and replaying at the same time")
+}
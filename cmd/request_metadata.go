@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"context"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/trace"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/validation"
+	"os"
+)
+
+// setRequestId sets the request ID and traceparent of the request metadata. The request ID is read from the
+// request-id flag, a UUID prefixed with the configured request ID prefix is generated when the flag is not provided.
+func setRequestId(cmd *cobra.Command, md *client.RequestMetadata) error {
+	var err error
+	md.RequestId, err = cmd.Flags().GetString(constants.RequestIdParamName)
+	if err != nil {
+		return err
+	}
+
+	if md.RequestId == "" {
+		prefix := viper.GetString(constants.RequestIdPrefix)
+		if err = validation.ValidateRequestIdPrefix(prefix); err != nil {
+			return err
+		}
+		md.RequestId = prefix + uuid.NewString()
+		log.WithField(constants.HTTPHeaderKeyRequestId, md.RequestId).Info("Generated request ID")
+	} else if err = validation.ValidateRequestId(md.RequestId); err != nil {
+		return err
+	}
+	md.TraceParent = newTraceParent(cmd.Context()).String()
+	return nil
+}
+
+// newTraceParent returns the trace context of the command. The span of the command is used when the telemetry is
+// exported, otherwise the command joins the trace of the TRACEPARENT env variable when it is set, e.g. by a CI
+// pipeline, or a new trace is started.
+func newTraceParent(ctx context.Context) client.TraceParent {
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		sc := span.SpanContext()
+		return client.TraceParent{TraceId: sc.TraceID(), ParentId: sc.SpanID(), Flags: byte(sc.TraceFlags())}
+	}
+	inbound := os.Getenv(constants.TraceParentEnvVar)
+	if inbound == "" {
+		return client.NewTraceParent()
+	}
+	parent, err := client.ParseTraceParent(inbound)
+	if err != nil {
+		log.WithError(err).Warnf("Ignoring the %s env variable, starting a new trace", constants.TraceParentEnvVar)
+		return client.NewTraceParent()
+	}
+	return parent.NewChild()
+}
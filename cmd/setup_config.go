@@ -10,6 +10,8 @@ import (
 	"github.com/spf13/cobra"
 	"intel/tac/v1/config"
 	"intel/tac/v1/constants"
+	"intel/tac/v1/secrets"
+	"strings"
 )
 
 // setupConfigCmd represents the setup command
@@ -20,7 +22,7 @@ var (
 		Long:  ``,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Println("setup called")
-			if err := config.SetupConfig(envFilePath); err != nil {
+			if err := config.SetupConfig(envFilePath, secretStore); err != nil {
 				return err
 			}
 			return nil
@@ -28,11 +30,14 @@ var (
 	}
 
 	envFilePath string
+	secretStore string
 )
 
 func init() {
 	tenantCmd.AddCommand(setupConfigCmd)
 
 	setupConfigCmd.Flags().StringVarP(&envFilePath, constants.EnvFileParamName, "v", "", "Path for the env file to be used to update the configuration")
+	setupConfigCmd.Flags().StringVar(&secretStore, constants.SecretStoreParamName, "", "Secret store used to keep the API key. One of: "+
+		strings.Join(secrets.Names(), "|")+". Defaults to the store already used by the profile, else plaintext")
 	setupConfigCmd.MarkFlagRequired(constants.EnvFileParamName)
 }
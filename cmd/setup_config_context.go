@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/secrets"
+	"intel/tac/v1/validation"
+	"strings"
+)
+
+var (
+	useContextCmd = &cobra.Command{
+		Use:   constants.UseContextCmd + " <profile name>",
+		Short: "Sets the current profile in the configuration file",
+		Long:  ``,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config use-context called")
+			if err := config.UseProfile(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Switched to profile %q\n", args[0])
+			return nil
+		},
+	}
+
+	getContextsCmd = &cobra.Command{
+		Use:   constants.GetContextsCmd,
+		Short: "Lists the profiles of the configuration file",
+		Long:  ``,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config get-contexts called")
+			profiles, err := config.GetProfiles()
+			if err != nil {
+				return err
+			}
+			return printResponse(cmd, profiles)
+		},
+	}
+
+	setContextCmd = &cobra.Command{
+		Use:   constants.SetContextCmd + " <profile name>",
+		Short: "Creates or updates a profile in the configuration file",
+		Long:  ``,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config set-context called")
+			if err := setContext(cmd, args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Profile %q set\n", args[0])
+			return nil
+		},
+	}
+
+	setSecretStoreCmd = &cobra.Command{
+		Use:   constants.SetSecretStoreCmd + " <secret store>",
+		Short: "Moves the API key of the profile to another secret store",
+		Long: `Moves the API key of the current profile, or the one selected with --profile, to another secret store.
+Supported secret stores are ` + strings.Join(secrets.Names(), ", ") + `. This is used to migrate the plaintext API key
+of an existing configuration to the OS keyring or an encrypted file.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: secrets.Names(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config set-secret-store called")
+			if err := config.SetSecretStore(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "API key moved to the %s secret store\n", args[0])
+			return nil
+		},
+	}
+
+	deleteContextCmd = &cobra.Command{
+		Use:   constants.DeleteContextCmd + " <profile name>",
+		Short: "Deletes a profile from the configuration file",
+		Long:  ``,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config delete-context called")
+			if err := config.DeleteProfile(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Profile %q deleted\n", args[0])
+			return nil
+		},
+	}
+)
+
+func init() {
+	setupConfigCmd.AddCommand(useContextCmd)
+	setupConfigCmd.AddCommand(getContextsCmd)
+	setupConfigCmd.AddCommand(setContextCmd)
+	setupConfigCmd.AddCommand(deleteContextCmd)
+	setupConfigCmd.AddCommand(setSecretStoreCmd)
+
+	setContextCmd.Flags().StringP(constants.EnvFileParamName, "v", "", "Path for the env file holding the URL and API key of the profile")
+	setContextCmd.Flags().StringP(constants.UrlParamName, "u", "", "Trust Authority base URL of the profile")
+	setContextCmd.Flags().String(constants.Loglevel, "", "Log level of the profile")
+	setContextCmd.Flags().Int(constants.HttpClientTimeout, 0, "HTTP client timeout in seconds of the profile")
+	setContextCmd.Flags().String(constants.SecretStoreParamName, "", "Secret store used to keep the API key of the profile. One of: "+
+		strings.Join(secrets.Names(), "|"))
+}
+
+func setContext(cmd *cobra.Command, profileName string) error {
+	envFile, err := cmd.Flags().GetString(constants.EnvFileParamName)
+	if err != nil {
+		return err
+	}
+	store, err := cmd.Flags().GetString(constants.SecretStoreParamName)
+	if err != nil {
+		return err
+	}
+	if store != "" {
+		if err = secrets.Validate(store); err != nil {
+			return err
+		}
+	}
+	if envFile != "" {
+		config.SetActiveProfile(profileName)
+		if err = config.SetupConfig(envFile, store); err != nil {
+			return err
+		}
+	}
+
+	baseUrl, err := cmd.Flags().GetString(constants.UrlParamName)
+	if err != nil {
+		return err
+	}
+	if baseUrl != "" {
+		if err = validation.ValidateTrustAuthorityUrl(baseUrl); err != nil {
+			return err
+		}
+	}
+
+	logLevel, err := cmd.Flags().GetString(constants.Loglevel)
+	if err != nil {
+		return err
+	}
+	if logLevel != "" {
+		if err = validation.ValidateLogLevel(logLevel); err != nil {
+			return err
+		}
+	}
+
+	timeout, err := cmd.Flags().GetInt(constants.HttpClientTimeout)
+	if err != nil {
+		return err
+	}
+	if timeout < 0 {
+		return validation.NewInputError(errors.New("HTTP client timeout cannot be negative"))
+	}
+
+	return config.SetProfile(profileName, &config.Configuration{
+		TrustAuthorityBaseUrl: baseUrl,
+		LogLevel:              logLevel,
+		HTTPClientTimeout:     timeout,
+		SecretStore:           store,
+	})
+}
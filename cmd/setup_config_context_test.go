@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/test"
+	"os"
+	"testing"
+)
+
+// backupConfigFile restores the mock configuration file once the test modifying it has completed
+func backupConfigFile(t *testing.T) {
+	configBytes, err := os.ReadFile(tempConfigFile.Name())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		config.SetActiveProfile("")
+		assert.NoError(t, os.WriteFile(tempConfigFile.Name(), configBytes, constants.DefaultFilePermission))
+	})
+}
+
+func TestConfigContextCmds(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	// loading the configuration migrates the mock configuration to the default profile
+	_, err := config.LoadConfiguration()
+	assert.NoError(t, err)
+	backupConfigFile(t)
+
+	tt := []struct {
+		args        []string
+		wantErr     bool
+		description string
+	}{
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetContextCmd, "staging", "-u", "https://staging.example.com"},
+			wantErr:     false,
+			description: "Test creating a profile",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetContextCmd, "staging", "--log-level", "debug", "-u", ""},
+			wantErr:     false,
+			description: "Test updating a profile",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetContextCmd, "invalid.name", "-u", "https://staging.example.com"},
+			wantErr:     true,
+			description: "Test creating a profile with an invalid name",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetContextCmd, "staging", "-u", "invalid url", "--log-level", ""},
+			wantErr:     true,
+			description: "Test updating a profile with an invalid URL",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.GetContextsCmd},
+			wantErr:     false,
+			description: "Test listing the profiles",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.UseContextCmd, "staging"},
+			wantErr:     false,
+			description: "Test switching the current profile",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.UseContextCmd, "unknown"},
+			wantErr:     true,
+			description: "Test switching to an unknown profile",
+		},
+	}
+
+	for _, tc := range tt {
+		_, err := execute(t, tenantCmd, tc.args)
+
+		if tc.wantErr == true {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+	}
+
+	output, err := executeStdout(t, tenantCmd, []string{constants.SetupConfigCmd, constants.GetContextsCmd})
+	assert.NoError(t, err)
+	assert.Regexp(t, `^CURRENT\s+NAME\s+URL\s+SECRET STORE\n`, output, "Test the columns of the profiles")
+	assert.Regexp(t, `\*\s+staging\s+https://staging.example.com`, output, "Test marking the current profile")
+
+	configFile, err := config.ReadConfigFile()
+	assert.NoError(t, err)
+	assert.Equal(t, "staging", configFile.CurrentProfile)
+	assert.Equal(t, "https://staging.example.com", configFile.Profiles["staging"].TrustAuthorityBaseUrl)
+	assert.Equal(t, "debug", configFile.Profiles["staging"].LogLevel)
+	assert.Contains(t, configFile.Profiles, constants.DefaultProfileName)
+
+	config.SetActiveProfile(constants.DefaultProfileName)
+	assert.Equal(t, constants.DefaultProfileName, configFile.ActiveProfileName())
+	config.SetActiveProfile("unknown")
+	_, err = config.LoadConfiguration()
+	assert.Error(t, err)
+	config.SetActiveProfile("")
+
+	_, err = execute(t, tenantCmd, []string{constants.SetupConfigCmd, constants.DeleteContextCmd, "staging"})
+	assert.NoError(t, err)
+	configFile, err = config.ReadConfigFile()
+	assert.NoError(t, err)
+	assert.NotContains(t, configFile.Profiles, "staging")
+}
+
+func TestConfigMigration(t *testing.T) {
+	backupConfigFile(t)
+
+	legacyConfig := "trustauthority-url: https://legacy.example.com\nlog-level: info\nhttp-client-timeout: 10\n"
+	assert.NoError(t, os.WriteFile(tempConfigFile.Name(), []byte(legacyConfig), constants.DefaultFilePermission))
+
+	configFile, err := config.ReadConfigFile()
+	assert.NoError(t, err)
+	assert.Equal(t, constants.DefaultProfileName, configFile.CurrentProfile)
+	assert.Equal(t, "https://legacy.example.com", configFile.Profiles[constants.DefaultProfileName].TrustAuthorityBaseUrl)
+
+	// reading the configuration leaves the file untouched, it is migrated once the configuration is written
+	migratedConfig, err := os.ReadFile(tempConfigFile.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, legacyConfig, string(migratedConfig), "Test the configuration is migrated in memory")
+
+	_, err = execute(t, tenantCmd, []string{constants.SetupConfigCmd, constants.UseContextCmd, constants.DefaultProfileName})
+	assert.NoError(t, err)
+	migratedConfig, err = os.ReadFile(tempConfigFile.Name())
+	assert.NoError(t, err)
+	assert.Contains(t, string(migratedConfig), "profiles:", "Test the configuration is migrated once written")
+	assert.NotContains(t, string(migratedConfig), "\ntrustauthority-url:", "Test the legacy keys are removed")
+}
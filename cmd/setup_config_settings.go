@@ -0,0 +1,158 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"intel/tac/v1/client/tms"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/printer"
+	"net/url"
+	"strings"
+)
+
+const connectivityCheckName = "connectivity"
+
+var (
+	viewConfigCmd = &cobra.Command{
+		Use:   constants.ViewCmd,
+		Short: "Displays the configuration file with the API keys masked",
+		Long:  ``,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config view called")
+			configFile, err := config.View()
+			if err != nil {
+				return err
+			}
+			// the configuration file does not fit in a table, it is displayed as YAML unless another format is requested
+			if !cmd.Flags().Changed(constants.OutputParamName) {
+				return printer.Print(cmd.OutOrStdout(), printer.YAML, configFile)
+			}
+			return printResponse(cmd, configFile)
+		},
+	}
+
+	getConfigCmd = &cobra.Command{
+		Use:       constants.GetCmd + " <key>",
+		Short:     "Displays the value of a configuration key of the profile",
+		Long:      `Displays the value of a configuration key of the profile. Supported keys are ` + strings.Join(config.Keys(), ", ") + `.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: config.Keys(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config get called")
+			value, err := config.GetValue(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), value)
+			return nil
+		},
+	}
+
+	setConfigCmd = &cobra.Command{
+		Use:       constants.SetCmd + " <key> <value>",
+		Short:     "Sets the value of a configuration key of the profile",
+		Long:      `Sets the value of a configuration key of the profile. Supported keys are ` + strings.Join(config.Keys(), ", ") + `.`,
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: config.Keys(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config set called")
+			if err := config.SetValue(args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Property %q set\n", args[0])
+			return nil
+		},
+	}
+
+	unsetConfigCmd = &cobra.Command{
+		Use:       constants.UnsetCmd + " <key>",
+		Short:     "Removes the value of a configuration key from the profile",
+		Long:      `Removes the value of a configuration key from the profile. Supported keys are ` + strings.Join(config.Keys(), ", ") + `.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: config.Keys(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config unset called")
+			if err := config.UnsetValue(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Property %q unset\n", args[0])
+			return nil
+		},
+	}
+
+	validateConfigCmd = &cobra.Command{
+		Use:   constants.ValidateCmd,
+		Short: "Validates the configuration of the profile",
+		Long:  ``,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Info("config validate called")
+			checks, err := validateConfig(cmd)
+			if err != nil {
+				return err
+			}
+			if err = printResponse(cmd, checks); err != nil {
+				return err
+			}
+			return config.ChecksError(checks)
+		},
+	}
+)
+
+func init() {
+	setupConfigCmd.AddCommand(viewConfigCmd)
+	setupConfigCmd.AddCommand(getConfigCmd)
+	setupConfigCmd.AddCommand(setConfigCmd)
+	setupConfigCmd.AddCommand(unsetConfigCmd)
+	setupConfigCmd.AddCommand(validateConfigCmd)
+
+	validateConfigCmd.Flags().Bool(constants.CheckConnectivityParamName, false, "Check that Trust Authority can be reached "+
+		"with the configured URL and API key")
+}
+
+func validateConfig(cmd *cobra.Command) ([]config.ConfigCheck, error) {
+	configValues, err := config.LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	checks := configValues.Validate()
+
+	checkConnectivity, err := cmd.Flags().GetBool(constants.CheckConnectivityParamName)
+	if err != nil {
+		return nil, err
+	}
+	if checkConnectivity {
+		checks = append(checks, config.NewConfigCheck(connectivityCheckName, probeConnectivity(cmd.Context(), configValues)))
+	}
+	return checks, nil
+}
+
+// probeConnectivity lists the service offers, which only requires a valid API key, to check that Trust Authority
+// can be reached
+func probeConnectivity(ctx context.Context, configValues *config.Configuration) error {
+	if err := config.ChecksError(configValues.Validate()); err != nil {
+		return err
+	}
+	client, err := configValues.NewHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
+	if err != nil {
+		return err
+	}
+
+	tmsClient := tms.NewTmsClient(client, tmsUrl, configValues.TrustAuthorityApiKey)
+	_, err = tmsClient.GetServiceOffersWithContext(ctx)
+	return err
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/test"
+	"testing"
+)
+
+func TestConfigSettingsCmds(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	_, err := config.LoadConfiguration()
+	assert.NoError(t, err)
+	backupConfigFile(t)
+	useMockServer(t, server.URL)
+
+	tt := []struct {
+		args        []string
+		wantErr     bool
+		wantOutput  string
+		description string
+	}{
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.TrustAuthBaseUrl, "https://staging.example.com"},
+			wantErr:     false,
+			description: "Test setting the URL",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.TrustAuthBaseUrl, "staging.example.com"},
+			wantErr:     true,
+			description: "Test setting an invalid URL",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.Loglevel, "debug"},
+			wantErr:     false,
+			description: "Test setting the log level",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.Loglevel, "loud"},
+			wantErr:     true,
+			description: "Test setting an invalid log level",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.HttpClientTimeout, "-1"},
+			wantErr:     true,
+			description: "Test setting an invalid HTTP client timeout",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.TrustAuthApiKeyEnvVar, "short"},
+			wantErr:     true,
+			description: "Test setting an invalid API key",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, "unknown-key", "value"},
+			wantErr:     true,
+			description: "Test setting an unsupported key",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.ValidateCmd, "-o", "json"},
+			wantErr:     true,
+			wantOutput:  "FAILED",
+			description: "Test validating the configuration without API key",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.TrustAuthApiKeyEnvVar, testApiKey},
+			wantErr:     false,
+			description: "Test setting the API key",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.GetCmd, constants.Loglevel},
+			wantErr:     false,
+			wantOutput:  "debug",
+			description: "Test getting the log level",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.GetCmd, constants.TrustAuthApiKeyEnvVar},
+			wantErr:     false,
+			wantOutput:  config.MaskApiKey(testApiKey),
+			description: "Test getting the masked API key",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.ViewCmd, "-o", "yaml"},
+			wantErr:     false,
+			wantOutput:  config.MaskApiKey(testApiKey),
+			description: "Test viewing the configuration",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.ValidateCmd, "--" + constants.CheckConnectivityParamName},
+			wantErr:     false,
+			wantOutput:  "connectivity",
+			description: "Test validating the configuration and connectivity",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.CacheTTLTags, "5m"},
+			wantErr:     false,
+			description: "Test setting the TTL of the cached tags",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.GetCmd, constants.CacheTTLTags},
+			wantErr:     false,
+			wantOutput:  "5m0s",
+			description: "Test getting the TTL of the cached tags",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetCmd, constants.CacheTTLPlans, "-1h"},
+			wantErr:     true,
+			description: "Test setting a negative cache TTL",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.UnsetCmd, constants.Loglevel},
+			wantErr:     false,
+			description: "Test unsetting the log level",
+		},
+	}
+
+	for _, tc := range tt {
+		output, err := execute(t, tenantCmd, tc.args)
+
+		if tc.wantErr == true {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+		assert.Contains(t, output, tc.wantOutput, tc.description)
+		assert.NotContains(t, output, testApiKey, tc.description)
+	}
+
+	logLevel, err := config.GetValue(constants.Loglevel)
+	assert.NoError(t, err)
+	assert.Empty(t, logLevel)
+
+	output, err := executeStdout(t, tenantCmd, []string{constants.SetupConfigCmd, constants.ValidateCmd, "-o", "table"})
+	assert.NoError(t, err)
+	assert.Regexp(t, `^NAME\s+STATUS\s+MESSAGE\n`+constants.TrustAuthBaseUrl+`\s+`+config.CheckPassed, output,
+		"Test the columns of the configuration checks")
+
+	// the keys viewed are the ones of the config get/set commands
+	output, err = executeStdout(t, tenantCmd, []string{constants.SetupConfigCmd, constants.ViewCmd, "-o", "json"})
+	assert.NoError(t, err)
+	for _, key := range []string{`"current-profile"`, `"profiles"`, `"` + constants.TrustAuthBaseUrl + `"`,
+		`"` + constants.CacheTTLTags + `": "5m0s"`} {
+		assert.Contains(t, output, key, "Test viewing the configuration keys")
+	}
+	assert.NotContains(t, output, "TrustAuthorityBaseUrl", "Test viewing the configuration keys")
+	assert.NotContains(t, output, `"`+constants.Proxy+`"`, "Test viewing the configuration without the empty keys")
+
+	configValues, err := config.LoadConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, constants.DefaultLogLevel, configValues.LogLevel)
+	assert.Equal(t, testApiKey, configValues.TrustAuthorityApiKey)
+}
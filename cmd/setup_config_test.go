@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/secrets"
+	"intel/tac/v1/test"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testApiKey = "dGVzdGFwaWtleWZvcnRoZXNlY3JldHN0b3JldGVzdHM"
+
+func TestSetupConfigSecretStores(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	_, err := config.LoadConfiguration()
+	assert.NoError(t, err)
+	backupConfigFile(t)
+	t.Cleanup(func() { secretStore = "" })
+
+	keyring.MockInit()
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(constants.SecretPassphraseEnvVar, "test passphrase")
+	// the env file exports the URL and API key, they are restored once the test completes
+	t.Setenv("TRUSTAUTHORITY_URL", "")
+	t.Setenv(constants.ApiKeyEnvVar, "")
+
+	envFile := filepath.Join(t.TempDir(), "tac.env")
+	assert.NoError(t, os.WriteFile(envFile, []byte("TRUSTAUTHORITY_URL="+server.URL+"\nTRUSTAUTHORITY_API_KEY="+testApiKey+"\n"),
+		constants.DefaultFilePermission))
+
+	tt := []struct {
+		args        []string
+		wantErr     bool
+		wantStore   string
+		description string
+	}{
+		{
+			args:        []string{constants.SetupConfigCmd, "-v", envFile, "--" + constants.SecretStoreParamName, secrets.Keyring},
+			wantErr:     false,
+			wantStore:   secrets.Keyring,
+			description: "Test setting up configuration with the API key in the keyring",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetSecretStoreCmd, secrets.File},
+			wantErr:     false,
+			wantStore:   secrets.File,
+			description: "Test moving the API key to the encrypted file",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetSecretStoreCmd, "vault"},
+			wantErr:     true,
+			wantStore:   secrets.File,
+			description: "Test moving the API key to an unsupported secret store",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, constants.SetSecretStoreCmd, secrets.Plaintext},
+			wantErr:     false,
+			wantStore:   "",
+			description: "Test moving the API key back to the configuration file",
+		},
+		{
+			args:        []string{constants.SetupConfigCmd, "-v", envFile, "--" + constants.SecretStoreParamName, "vault"},
+			wantErr:     true,
+			wantStore:   "",
+			description: "Test setting up configuration with an unsupported secret store",
+		},
+	}
+
+	for _, tc := range tt {
+		_, err := execute(t, tenantCmd, tc.args)
+
+		if tc.wantErr == true {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+
+		configFile, err := config.ReadConfigFile()
+		assert.NoError(t, err)
+		profile := configFile.Profiles[configFile.ActiveProfileName()]
+		assert.Equal(t, tc.wantStore, profile.SecretStore, tc.description)
+		if secrets.IsExternal(tc.wantStore) {
+			assert.Empty(t, profile.TrustAuthorityApiKey, tc.description)
+		} else {
+			assert.Equal(t, testApiKey, profile.TrustAuthorityApiKey, tc.description)
+		}
+
+		configValues, err := config.LoadConfiguration()
+		assert.NoError(t, err)
+		assert.Equal(t, testApiKey, configValues.TrustAuthorityApiKey, tc.description)
+	}
+
+	// the encrypted file is removed once the API key has been moved out of it
+	_, err = os.Stat(filepath.Join(homeDir, constants.SecretsDir, constants.DefaultProfileName+constants.SecretFileExtension))
+	assert.True(t, os.IsNotExist(err))
+	_, err = keyring.Get(constants.SecretServiceName, constants.DefaultProfileName)
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+}
+
+func TestSetupConfigKeepsSettings(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	backupConfigFile(t)
+	useMockServer(t, server.URL)
+	t.Setenv("TRUSTAUTHORITY_URL", "")
+	t.Setenv(constants.ApiKeyEnvVar, "")
+
+	envFile := filepath.Join(t.TempDir(), "tac.env")
+	assert.NoError(t, os.WriteFile(envFile, []byte("TRUSTAUTHORITY_URL="+server.URL+"\nTRUSTAUTHORITY_API_KEY="+testApiKey+"\n"),
+		constants.DefaultFilePermission))
+
+	for _, args := range [][]string{
+		{constants.SetupConfigCmd, constants.SetCmd, constants.Proxy, "http://proxy.example.com:3128"},
+		{constants.SetupConfigCmd, constants.SetCmd, constants.RetryMax, "7"},
+		{constants.SetupConfigCmd, "-v", envFile},
+	} {
+		_, err := execute(t, tenantCmd, args)
+		assert.NoError(t, err)
+	}
+
+	configFile, err := config.ReadConfigFile()
+	assert.NoError(t, err)
+	profile := configFile.Profiles[configFile.ActiveProfileName()]
+	assert.Equal(t, server.URL, profile.TrustAuthorityBaseUrl)
+	assert.Equal(t, testApiKey, profile.TrustAuthorityApiKey)
+	assert.Equal(t, "http://proxy.example.com:3128", profile.Proxy, "Test the proxy set is kept")
+	if assert.NotNil(t, profile.RetryMax) {
+		assert.Equal(t, 7, *profile.RetryMax, "Test the retry maximum set is kept")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/telemetry"
+	"intel/tac/v1/utils"
+	"os"
+	"time"
+)
+
+const (
+	instrumentationName   = "intel/tac/v1/cmd"
+	commandDurationMetric = "trustauthority.cli.command.duration"
+	commandAttributeKey   = attribute.Key("trustauthority.cli.command")
+	exitCodeAttributeKey  = attribute.Key("trustauthority.cli.exit_code")
+	// telemetryExportTimeout bounds the time spent exporting the telemetry before exiting
+	telemetryExportTimeout = 5 * time.Second
+)
+
+var (
+	shutdownTelemetry telemetry.ShutdownFunc
+	commandSpan       trace.Span
+	commandName       string
+	commandStart      time.Time
+)
+
+// startTelemetry sets up the export of the telemetry configured and starts the span of the command. The command is
+// part of the trace of the TRACEPARENT env variable when it is set.
+func startTelemetry(cmd *cobra.Command, configValues *config.Configuration) error {
+	opts := &telemetry.Options{
+		Endpoint:       configValues.OtlpEndpoint,
+		File:           configValues.TelemetryFile,
+		ServiceVersion: utils.Version,
+	}
+	if !opts.Enabled() {
+		return nil
+	}
+	shutdown, err := telemetry.Setup(cmd.Context(), opts)
+	if err != nil {
+		return err
+	}
+	shutdownTelemetry = shutdown
+
+	ctx := cmd.Context()
+	if inbound := os.Getenv(constants.TraceParentEnvVar); inbound != "" {
+		ctx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{constants.HTTPHeaderKeyTraceParent: inbound})
+	}
+	commandName = cmd.CommandPath()
+	commandStart = time.Now()
+	ctx, commandSpan = otel.Tracer(instrumentationName).Start(ctx, commandName,
+		trace.WithAttributes(commandAttributeKey.String(commandName)))
+	cmd.SetContext(ctx)
+	return nil
+}
+
+// endTelemetry ends the span of the command with the outcome of the command and exports the telemetry recorded
+func endTelemetry(err error) {
+	if commandSpan != nil {
+		code := constants.ExitCodeOK
+		if err != nil {
+			code = exitCode(err)
+			commandSpan.RecordError(err)
+			commandSpan.SetStatus(codes.Error, err.Error())
+		}
+		attrs := []attribute.KeyValue{commandAttributeKey.String(commandName), exitCodeAttributeKey.Int(code)}
+		commandSpan.SetAttributes(exitCodeAttributeKey.Int(code))
+		commandSpan.End()
+		histogram, histErr := otel.Meter(instrumentationName).Float64Histogram(commandDurationMetric,
+			metric.WithUnit("s"), metric.WithDescription("Duration of the CLI commands"))
+		if histErr == nil {
+			histogram.Record(context.Background(), time.Since(commandStart).Seconds(), metric.WithAttributes(attrs...))
+		}
+		commandSpan = nil
+	}
+
+	if shutdownTelemetry != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), telemetryExportTimeout)
+		defer cancel()
+		if shutdownErr := shutdownTelemetry(ctx); shutdownErr != nil {
+			log.WithError(shutdownErr).Warn("Failed to export telemetry")
+		}
+		shutdownTelemetry = nil
+	}
+}
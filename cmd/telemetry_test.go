@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/test"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// exportedSpan holds the fields of the spans written by the file exporter checked by the tests
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		SpanID string
+	}
+	Attributes []struct {
+		Key   string
+		Value struct {
+			Value interface{}
+		}
+	}
+	Status struct {
+		Code string
+	}
+}
+
+func (s *exportedSpan) attribute(key string) interface{} {
+	for _, attr := range s.Attributes {
+		if attr.Key == key {
+			return attr.Value.Value
+		}
+	}
+	return nil
+}
+
+// readTelemetryFile returns the spans and the names of the metrics written by the file exporter
+func readTelemetryFile(t *testing.T, path string) (map[string][]*exportedSpan, string) {
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	spans := map[string][]*exportedSpan{}
+	var metrics strings.Builder
+	decoder := json.NewDecoder(file)
+	for {
+		var record map[string]json.RawMessage
+		if err = decoder.Decode(&record); err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		if scopeMetrics, ok := record["ScopeMetrics"]; ok {
+			metrics.Write(scopeMetrics)
+			continue
+		}
+		recordBytes, _ := json.Marshal(record)
+		span := &exportedSpan{}
+		assert.NoError(t, json.Unmarshal(recordBytes, span))
+		spans[span.Name] = append(spans[span.Name], span)
+	}
+	return spans, metrics.String()
+}
+
+func TestTelemetry(t *testing.T) {
+	var calls int32
+	var mu sync.Mutex
+	var traceParents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceParents = append(traceParents, r.Header.Get(constants.HTTPHeaderKeyTraceParent))
+		mu.Unlock()
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set(constants.HTTPHeaderKeyRetryAfter, "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+
+	telemetryFile := filepath.Join(t.TempDir(), "telemetry.json")
+	setGlobalFlag(t, constants.TelemetryFile, telemetryFile)
+	t.Setenv(constants.TraceParentEnvVar, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	// the pre run hook of the root command is only set when the CLI is executed
+	tenantCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		configValues, err := config.LoadConfiguration()
+		if err != nil {
+			return err
+		}
+		return startTelemetry(cmd, configValues)
+	}
+	t.Cleanup(func() {
+		tenantCmd.PersistentPreRunE = nil
+	})
+	resetContexts(tenantCmd)
+	tenantCmd.AddCommand(listCmd)
+
+	_, err := execute(t, tenantCmd, []string{constants.ListCmd, constants.ServiceOfferCmd, "-q", "telemetry-test"})
+	assert.NoError(t, err)
+	endTelemetry(err)
+
+	spans, metrics := readTelemetryFile(t, telemetryFile)
+	commandSpans := spans[strings.Join([]string{constants.RootCmd, constants.ListCmd, constants.ServiceOfferCmd}, " ")]
+	requestSpans := spans["HTTP GET"]
+	attemptSpans := spans["HTTP GET attempt"]
+	if !assert.Len(t, commandSpans, 1) || !assert.Len(t, requestSpans, 1) || !assert.Len(t, attemptSpans, 2) {
+		return
+	}
+
+	assert.Equal(t, "00f067aa0ba902b7", commandSpans[0].Parent.SpanID, "Test command span is part of the inbound trace")
+	assert.Equal(t, commandSpans[0].SpanContext.SpanID, requestSpans[0].Parent.SpanID)
+	assert.Equal(t, "telemetry-test", requestSpans[0].attribute("trustauthority.request_id"))
+	assert.EqualValues(t, http.StatusOK, requestSpans[0].attribute("http.response.status_code"))
+	for i, attempt := range attemptSpans {
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", attempt.SpanContext.TraceID)
+		assert.Equal(t, requestSpans[0].SpanContext.SpanID, attempt.Parent.SpanID, "Test retries are child spans of the request")
+		assert.Contains(t, traceParents, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+attempt.SpanContext.SpanID+"-01",
+			"Test attempt %d is sent with its traceparent", i)
+	}
+	assert.EqualValues(t, http.StatusServiceUnavailable, attemptSpans[0].attribute("http.response.status_code"))
+	assert.Equal(t, "Error", attemptSpans[0].Status.Code)
+	assert.EqualValues(t, 1, attemptSpans[1].attribute("http.resend_count"))
+
+	assert.Contains(t, metrics, "trustauthority.cli.command.duration")
+	assert.Contains(t, metrics, "trustauthority.http.client.duration")
+	assert.Contains(t, metrics, "trustauthority.http.client.retries")
+}
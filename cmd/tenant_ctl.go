@@ -6,20 +6,31 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"intel/tac/v1/client"
 	"intel/tac/v1/config"
 	"intel/tac/v1/constants"
-	"intel/tac/v1/internal/models"
+	"intel/tac/v1/printer"
+	"intel/tac/v1/sdk"
 	"intel/tac/v1/utils"
 	"intel/tac/v1/validation"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 )
 
 var (
-	apiKey string
+	apiKey  string
+	profile string
 )
 
 // tenantCmd represents the base command when called without any subcommands
@@ -39,46 +50,222 @@ func Execute() {
 
 	cleanedLogPath := filepath.Clean(userHomeDir + constants.LogFilePath)
 
-	logFile, err := os.OpenFile(cleanedLogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, constants.DefaultFilePermission)
+	// the CLI can be used without running the installer, e.g. in CI, so the log directory may not exist yet
+	var logFile io.Writer
+	if err = os.MkdirAll(filepath.Dir(cleanedLogPath), 0700); err == nil {
+		logFile, err = os.OpenFile(cleanedLogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, constants.DefaultFilePermission)
+	}
 	if err != nil {
-		fmt.Println("Error opening/creating log file: " + err.Error())
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, "Error opening/creating log file, logs are disabled: "+err.Error())
+		logFile = io.Discard
 	}
 	tenantCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := printer.ValidateFormat(outputFormat); err != nil {
+			return validation.NewInputError(err)
+		}
+		//API key is not needed for generating policy JWT or setting up config, API key check is skipped for these commands.
+		//The shell completions load the API key of the profile selected by the flags of the command being completed
+		cmdListWithNoApiKey := map[string]bool{constants.PolicyJwtCmd: true, constants.SetupConfigCmd: true,
+			constants.UninstallCmd: true, constants.MockServerCmd: true, constants.CompletionCmd: true,
+			cobra.ShellCompRequestCmd: true, cobra.ShellCompNoDescRequestCmd: true, constants.ClearCmd: true}
+		config.SetActiveProfile(profile)
 		configValues, err := config.LoadConfiguration()
 		if err != nil {
 			if logErr := utils.SetUpLogs(logFile, constants.DefaultLogLevel); logErr != nil {
 				return logErr
 			}
 			logrus.WithError(err).Error("Error loading configuration")
+			// The config commands are used to fix the configuration, so they are not blocked by an invalid one or
+			// a secret store which cannot be reached
+			if isConfigCmd(cmd) || cmdListWithNoApiKey[cmd.Name()] {
+				return nil
+			}
 			return err
 		} else {
 			if err := utils.SetUpLogs(logFile, configValues.LogLevel); err != nil {
 				return err
 			}
+			if err := startTelemetry(cmd, configValues); err != nil {
+				return err
+			}
 		}
-		//API key is not needed for generating policy JWT or setting up config, API key check is skipped for these commands
-		cmdListWithNoApiKey := map[string]bool{constants.PolicyJwtCmd: true, constants.SetupConfigCmd: true,
-			constants.UninstallCmd: true}
 		//API key is not needed for generating policy JWT or setting up config, API key check is skipped for these 2 commands
-		if ok := cmdListWithNoApiKey[cmd.Name()]; !ok {
+		//and when the responses are replayed from a cassette
+		if ok := cmdListWithNoApiKey[cmd.Name()]; !ok && !isConfigCmd(cmd) && !isReplaying() {
 			apiKey = configValues.TrustAuthorityApiKey
 			if err := validation.ValidateTrustAuthorityAPIKey(apiKey); err != nil {
-				return err
+				return &authError{err: err}
 			}
 		}
 		return nil
 	}
-	err = tenantCmd.Execute()
+	err = tenantCmd.ExecuteContext(newSignalContext())
+	endTelemetry(err)
 	if err != nil {
 		//Need to set it here separately as well since previously we are setting it only for the executed command
 		logrus.SetOutput(logFile)
-		logrus.WithField(constants.HTTPHeaderKeyRequestId, models.RespHeaderFields.RequestId).
-			WithField(constants.HTTPHeaderKeyTraceId, models.RespHeaderFields.TraceId).Error(err)
-		os.Exit(1)
+		entry := logrus.NewEntry(logrus.StandardLogger())
+		if apiErr, ok := client.AsAPIError(err); ok {
+			entry = entry.WithField(constants.HTTPHeaderKeyRequestId, apiErr.RequestId).
+				WithField(constants.HTTPHeaderKeyTraceId, apiErr.TraceId)
+		}
+		entry.Error(err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// newSignalContext returns the root context of the commands, cancelled on SIGINT or SIGTERM so that in-flight
+// requests and their retries stop promptly. A second signal terminates the CLI right away.
+func newSignalContext() context.Context {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx
+}
+
+// isConfigCmd checks if the command is the config command or one of its subcommands
+func isConfigCmd(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == setupConfigCmd {
+			return true
+		}
 	}
+	return false
 }
 
 func init() {
 	cobra.OnInitialize()
+	tenantCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return validation.NewInputError(err)
+	})
+
+	tenantCmd.PersistentFlags().StringVarP(&outputFormat, constants.OutputParamName, "o", printer.Table, "Output format. One of: "+
+		strings.Join(printer.Formats(), "|"))
+	tenantCmd.PersistentFlags().StringVar(&profile, constants.ProfileParamName, "", "Name of the configuration profile to be used "+
+		"instead of the current one. Can also be set with the "+constants.ProfileEnvVar+" env variable")
+	tenantCmd.PersistentFlags().String(constants.UrlParamName, "", "Trust Authority base URL, overrides the "+constants.UrlEnvVar+
+		" env variable and the configuration")
+	tenantCmd.PersistentFlags().String(constants.ApiKeyFileParamName, "", "Path of the file holding the Trust Authority API key, "+
+		"overrides the "+constants.ApiKeyEnvVar+" env variable and the configuration")
+	tenantCmd.PersistentFlags().Int(constants.TimeoutParamName, 0, "HTTP client timeout in seconds, overrides the "+
+		constants.HttpClientTimeoutEnvVar+" env variable and the configuration")
+
+	tenantCmd.PersistentFlags().String(constants.CaBundle, "", "Path of a PEM file holding the CA certificates to be trusted "+
+		"in addition to the system ones")
+	tenantCmd.PersistentFlags().String(constants.ClientCert, "", "Path of the PEM encoded client certificate used for mTLS")
+	tenantCmd.PersistentFlags().String(constants.ClientKey, "", "Path of the PEM encoded client key used for mTLS")
+	tenantCmd.PersistentFlags().String(constants.Proxy, "", "URL of the proxy used to reach Trust Authority, overrides the "+
+		"HTTPS_PROXY env variable")
+	tenantCmd.PersistentFlags().String(constants.NoProxy, "", "Comma separated list of hosts, domains and CIDRs reached without "+
+		"proxy, overrides the NO_PROXY env variable")
+	tenantCmd.PersistentFlags().String(constants.MinTLSVersion, "", "Minimum TLS version, one of 1.0, 1.1, 1.2 or 1.3 (default 1.2)")
+
+	tenantCmd.PersistentFlags().Int(constants.RetryMax, constants.DefaultRetryCount, "Maximum number of retries of a request")
+	tenantCmd.PersistentFlags().Duration(constants.RetryWaitMin, constants.DefaultRetryWaitMin*time.Second, "Minimum wait between retries")
+	tenantCmd.PersistentFlags().Duration(constants.RetryWaitMax, constants.DefaultRetryWaitMax*time.Second, "Maximum wait between retries")
+	tenantCmd.PersistentFlags().Bool(constants.RetryJitter, true, "Randomize the wait between retries")
+	tenantCmd.PersistentFlags().String(constants.RetryStatusCodes, constants.DefaultRetryStatusCodes, "Comma separated list of "+
+		"the response status codes which are retried")
+	tenantCmd.PersistentFlags().Bool(constants.RetryNonIdempotent, false, "Retry POST and PATCH requests on server errors and "+
+		"timeouts, the request ID is kept so that duplicates can be identified. Rate limited requests are always retried")
+	tenantCmd.PersistentFlags().Duration(constants.Deadline, 0, "Overall time allowed for a request including its retries, "+
+		"e.g. 1m (default no deadline)")
+	tenantCmd.PersistentFlags().String(constants.RequestIdPrefix, "", "Prefix of the request IDs generated when the "+
+		"--request-id flag is not provided")
+	tenantCmd.PersistentFlags().String(constants.OtlpEndpoint, "", "Base URL of the OTLP/HTTP collector the traces and metrics "+
+		"are exported to, e.g. http://localhost:4318. Overrides the "+constants.OtelOtlpEndpointEnvVar+" env variable")
+	tenantCmd.PersistentFlags().Bool(constants.DebugHttp, false, "Log the requests and responses exchanged with Trust "+
+		"Authority including their headers and bodies, same as --v=3. API keys are redacted")
+	tenantCmd.PersistentFlags().Int(constants.DebugHttpLevel, 0, "HTTP debug level: 1 logs the method, URL, status and "+
+		"duration of each attempt, 2 adds the headers and 3 the bodies")
+	tenantCmd.PersistentFlags().String(constants.DebugHttpOutput, constants.DebugHttpOutputStderr, "Destination of the HTTP "+
+		"debug logs, one of "+constants.DebugHttpOutputStderr+" or "+constants.DebugHttpOutputLog+" (the CLI log file)")
+	tenantCmd.PersistentFlags().String(constants.Record, "", "Directory the requests and responses are recorded in as "+
+		"cassette files, with the API keys redacted")
+	tenantCmd.PersistentFlags().String(constants.Replay, "", "Directory of the cassette the responses are replayed from, "+
+		"no request is sent to Trust Authority")
+	tenantCmd.PersistentFlags().String(constants.TelemetryFile, "", "Path of the file the traces and metrics are appended "+
+		"to as JSON")
+	tenantCmd.PersistentFlags().Bool(constants.NoCacheParamName, false, "Fetch the services, service offers, products, "+
+		"plans and tags from Trust Authority without using the local cache")
+	tenantCmd.PersistentFlags().Bool(constants.RefreshParamName, false, "Fetch the services, service offers, products, "+
+		"plans and tags from Trust Authority and update the local cache")
+
+	// the flags take precedence over the env variables and the configuration file
+	_ = viper.BindPFlag(constants.TrustAuthBaseUrl, tenantCmd.PersistentFlags().Lookup(constants.UrlParamName))
+	_ = viper.BindPFlag(constants.ApiKeyFileParamName, tenantCmd.PersistentFlags().Lookup(constants.ApiKeyFileParamName))
+	_ = viper.BindPFlag(constants.HttpClientTimeout, tenantCmd.PersistentFlags().Lookup(constants.TimeoutParamName))
+	for _, setting := range []string{constants.CaBundle, constants.ClientCert, constants.ClientKey, constants.Proxy,
+		constants.NoProxy, constants.MinTLSVersion, constants.RetryMax, constants.RetryWaitMin, constants.RetryWaitMax,
+		constants.RetryJitter, constants.RetryStatusCodes, constants.RetryNonIdempotent, constants.Deadline,
+		constants.RequestIdPrefix, constants.OtlpEndpoint, constants.TelemetryFile} {
+		_ = viper.BindPFlag(setting, tenantCmd.PersistentFlags().Lookup(setting))
+	}
+}
+
+// newTrustAuthorityClient creates the Trust Authority client from the configuration
+func newTrustAuthorityClient() (*sdk.Client, error) {
+	configValues, err := config.LoadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	transportOptions, err := configValues.TransportOptions()
+	if err != nil {
+		return nil, err
+	}
+	if transportOptions.Debug, err = httpDebugOptions(); err != nil {
+		return nil, err
+	}
+	transportOptions.RecordDir, _ = tenantCmd.PersistentFlags().GetString(constants.Record)
+	transportOptions.ReplayDir, _ = tenantCmd.PersistentFlags().GetString(constants.Replay)
+	httpClient, err := client.NewHTTPClient(transportOptions)
+	if err != nil {
+		return nil, err
+	}
+	baseUrl := configValues.TrustAuthorityBaseUrl
+	if baseUrl == "" && isReplaying() {
+		baseUrl = constants.ReplayBaseUrl
+	}
+	opts := []sdk.Option{sdk.WithBaseUrl(baseUrl), sdk.WithApiKey(apiKey), sdk.WithHTTPClient(httpClient)}
+	cacheOptions, err := newCacheOptions(configValues)
+	if err != nil {
+		return nil, err
+	}
+	if cacheOptions != nil {
+		opts = append(opts, sdk.WithCache(*cacheOptions))
+	}
+	return sdk.New(opts...)
+}
+
+// isReplaying tells if the responses are replayed from a cassette with the --replay flag
+func isReplaying() bool {
+	replayDir, _ := tenantCmd.PersistentFlags().GetString(constants.Replay)
+	return replayDir != ""
+}
+
+// httpDebugOptions returns the HTTP debug logs selected with the --debug-http, --v and --debug-http-output flags
+func httpDebugOptions() (client.DebugOptions, error) {
+	flags := tenantCmd.PersistentFlags()
+	level, err := flags.GetInt(constants.DebugHttpLevel)
+	if err != nil {
+		return client.DebugOptions{}, err
+	}
+	if debug, _ := flags.GetBool(constants.DebugHttp); debug {
+		level = client.DebugHTTPBodies
+	}
+	if level < client.DebugHTTPOff {
+		return client.DebugOptions{}, validation.NewInputError(errors.Errorf("Invalid HTTP debug level %d, cannot be negative", level))
+	}
+
+	output, _ := flags.GetString(constants.DebugHttpOutput)
+	switch output {
+	case constants.DebugHttpOutputStderr:
+		return client.DebugOptions{Level: level, Output: tenantCmd.ErrOrStderr()}, nil
+	case constants.DebugHttpOutputLog:
+		return client.DebugOptions{Level: level, Output: logrus.StandardLogger().Out}, nil
+	}
+	return client.DebugOptions{}, validation.NewInputError(errors.Errorf("Invalid HTTP debug output %q, should be one of %s or %s",
+		output, constants.DebugHttpOutputStderr, constants.DebugHttpOutputLog))
 }
@@ -0,0 +1,610 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/client"
+	"intel/tac/v1/config"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/test"
+	"intel/tac/v1/validation"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMain runs the tests with a temporary home directory, so that the resources cached by the commands never leak
+// into the user's cache or between test runs
+func TestMain(m *testing.M) {
+	homeDir, err := os.MkdirTemp("", "trustauthorityctl-home")
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	_ = os.Setenv("HOME", homeDir)
+	code := m.Run()
+	_ = os.RemoveAll(homeDir)
+	os.Exit(code)
+}
+
+// setGlobalFlag sets a flag of the root command for the duration of the test
+func setGlobalFlag(t *testing.T, name, value string) {
+	flag := tenantCmd.PersistentFlags().Lookup(name)
+	assert.NoError(t, flag.Value.Set(value))
+	flag.Changed = true
+	t.Cleanup(func() {
+		assert.NoError(t, flag.Value.Set(flag.DefValue))
+		flag.Changed = false
+	})
+}
+
+func TestConfigurationOverrides(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	_, err := config.LoadConfiguration()
+	assert.NoError(t, err)
+	backupConfigFile(t)
+
+	assert.NoError(t, config.SetValue(constants.TrustAuthApiKeyEnvVar, testApiKey))
+	assert.NoError(t, config.SetValue(constants.Loglevel, "warning"))
+	assert.NoError(t, config.SetValue(constants.HttpClientTimeout, "20"))
+
+	configValues, err := config.LoadConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, configValues.TrustAuthorityApiKey)
+	assert.Equal(t, "warning", configValues.LogLevel)
+	assert.Equal(t, 20, configValues.HTTPClientTimeout)
+
+	// the env variable names of previous versions are still read
+	t.Setenv(constants.LegacyLogLevelEnvVar, "error")
+	t.Setenv(constants.LegacyTimeoutEnvVar, "25")
+
+	configValues, err = config.LoadConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, "error", configValues.LogLevel)
+	assert.Equal(t, 25, configValues.HTTPClientTimeout)
+
+	// env variables take precedence over the profile, the prefixed names over the former ones
+	envApiKey := "ZW52YXBpa2V5Zm9ydGhlY29uZmlndXJhdGlvbm92ZXJyaWRlcw"
+	t.Setenv(constants.ApiKeyEnvVar, envApiKey)
+	t.Setenv(constants.LogLevelEnvVar, "debug")
+	t.Setenv(constants.HttpClientTimeoutEnvVar, "30")
+
+	configValues, err = config.LoadConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, envApiKey, configValues.TrustAuthorityApiKey)
+	assert.Equal(t, "debug", configValues.LogLevel)
+	assert.Equal(t, 30, configValues.HTTPClientTimeout)
+
+	// flags take precedence over the env variables
+	flagApiKey := "ZmxhZ2FwaWtleWZvcnRoZWNvbmZpZ3VyYXRpb25vdmVycmlkZXM"
+	apiKeyFile := filepath.Join(t.TempDir(), "api-key")
+	assert.NoError(t, os.WriteFile(apiKeyFile, []byte(flagApiKey+"\n"), constants.DefaultFilePermission))
+	setGlobalFlag(t, constants.ApiKeyFileParamName, apiKeyFile)
+	setGlobalFlag(t, constants.TimeoutParamName, "40")
+
+	configValues, err = config.LoadConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, flagApiKey, configValues.TrustAuthorityApiKey)
+	assert.Equal(t, "debug", configValues.LogLevel)
+	assert.Equal(t, 40, configValues.HTTPClientTimeout)
+
+	// the configuration file is optional
+	assert.NoError(t, os.Remove(tempConfigFile.Name()))
+	configValues, err = config.LoadConfiguration()
+	assert.NoError(t, err)
+	assert.Equal(t, flagApiKey, configValues.TrustAuthorityApiKey)
+	assert.Equal(t, 40, configValues.HTTPClientTimeout)
+
+	setGlobalFlag(t, constants.ApiKeyFileParamName, filepath.Join(t.TempDir(), "missing"))
+	_, err = config.LoadConfiguration()
+	assert.Error(t, err)
+}
+
+// writeClientCertificate generates a self-signed client certificate and key, returning their paths
+func writeClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "trustauthorityctl"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDer)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return cert, certFile, keyFile
+}
+
+func TestTransportFlags(t *testing.T) {
+	mockServer := test.MockServer(t)
+	defer mockServer.Close()
+	test.SetupMockConfiguration(mockServer.URL, tempConfigFile)
+
+	clientCert, certFile, keyFile := writeClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	// serve the mock server API over TLS, requiring a client certificate
+	server := httptest.NewUnstartedServer(mockServer.Config.Handler)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	useMockServer(t, server.URL)
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		0600))
+
+	tenantCmd.AddCommand(listCmd)
+	args := []string{constants.ListCmd, constants.ServiceOfferCmd, "-q", "transport-test"}
+
+	_, err := execute(t, tenantCmd, args)
+	assert.Error(t, err, "Test TLS connection with an unknown CA")
+
+	setGlobalFlag(t, constants.CaBundle, caBundle)
+	_, err = execute(t, tenantCmd, args)
+	assert.Error(t, err, "Test TLS connection without client certificate")
+
+	setGlobalFlag(t, constants.ClientCert, certFile)
+	_, err = execute(t, tenantCmd, args)
+	assert.Error(t, err, "Test TLS connection with a client certificate but no key")
+
+	setGlobalFlag(t, constants.ClientKey, keyFile)
+	_, err = execute(t, tenantCmd, args)
+	assert.NoError(t, err, "Test mTLS connection")
+
+	setGlobalFlag(t, constants.MinTLSVersion, "1.3")
+	_, err = execute(t, tenantCmd, args)
+	assert.NoError(t, err, "Test mTLS connection with TLS 1.3")
+
+	setGlobalFlag(t, constants.MinTLSVersion, "2.0")
+	_, err = execute(t, tenantCmd, args)
+	assert.Error(t, err, "Test unsupported minimum TLS version")
+
+	setGlobalFlag(t, constants.MinTLSVersion, "")
+	setGlobalFlag(t, constants.Proxy, "http://%zz")
+	_, err = execute(t, tenantCmd, args)
+	assert.Error(t, err, "Test invalid proxy URL")
+
+	setGlobalFlag(t, constants.Proxy, "http://127.0.0.1:1")
+	setGlobalFlag(t, constants.NoProxy, "127.0.0.1")
+	_, err = execute(t, tenantCmd, args)
+	assert.NoError(t, err, "Test proxy bypassed for the Trust Authority host")
+}
+
+func TestRetryPolicy(t *testing.T) {
+	var calls int32
+	var failures int32
+	var status int32
+	var delay int64
+	var retryAfter atomic.Value
+	retryAfter.Store("0")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Duration(atomic.LoadInt64(&delay)))
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.Header().Set(constants.HTTPHeaderKeyRetryAfter, retryAfter.Load().(string))
+			w.WriteHeader(int(atomic.LoadInt32(&status)))
+			return
+		}
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+	// each request is sent to the server instead of listing the cached service offers
+	setGlobalFlag(t, constants.NoCacheParamName, "true")
+	setGlobalFlag(t, constants.RetryWaitMin, "1ms")
+	setGlobalFlag(t, constants.RetryWaitMax, "5ms")
+
+	createCmd.AddCommand(createTagCmd)
+	tenantCmd.AddCommand(createCmd)
+	tenantCmd.AddCommand(listCmd)
+	listArgs := []string{constants.ListCmd, constants.ServiceOfferCmd, "-q", "retry-test"}
+	createArgs := []string{constants.CreateCmd, constants.TagCmd, "-n", "retry-test", "-q", "retry-test"}
+
+	tt := []struct {
+		args          []string
+		status        int32
+		failures      int32
+		statusCodes   string
+		nonIdempotent string
+		wantCalls     int32
+		wantErr       bool
+		description   string
+	}{
+		{
+			args:        listArgs,
+			status:      http.StatusTooManyRequests,
+			failures:    2,
+			wantCalls:   3,
+			wantErr:     false,
+			description: "Test rate limited GET request is retried",
+		},
+		{
+			args:        listArgs,
+			status:      http.StatusServiceUnavailable,
+			failures:    3,
+			wantCalls:   3,
+			wantErr:     true,
+			description: "Test GET request fails once the retries are exhausted",
+		},
+		{
+			args:        listArgs,
+			status:      http.StatusBadRequest,
+			failures:    1,
+			wantCalls:   1,
+			wantErr:     true,
+			description: "Test GET request is not retried on client errors",
+		},
+		{
+			args:        createArgs,
+			status:      http.StatusServiceUnavailable,
+			failures:    1,
+			wantCalls:   1,
+			wantErr:     true,
+			description: "Test POST request is not retried on server errors by default",
+		},
+		{
+			args:        createArgs,
+			status:      http.StatusTooManyRequests,
+			failures:    1,
+			wantCalls:   2,
+			wantErr:     false,
+			description: "Test rate limited POST request is retried",
+		},
+		{
+			args:          createArgs,
+			status:        http.StatusServiceUnavailable,
+			failures:      1,
+			nonIdempotent: "true",
+			wantCalls:     2,
+			wantErr:       false,
+			description:   "Test POST request is retried on server errors when enabled",
+		},
+		{
+			args:        listArgs,
+			status:      http.StatusServiceUnavailable,
+			failures:    1,
+			statusCodes: "500",
+			wantCalls:   1,
+			wantErr:     true,
+			description: "Test GET request is not retried on a status code which is not listed",
+		},
+		{
+			args:          createArgs,
+			status:        http.StatusTooManyRequests,
+			failures:      1,
+			statusCodes:   "500",
+			nonIdempotent: "false",
+			wantCalls:     2,
+			wantErr:       false,
+			description:   "Test rate limited POST request is retried when 429 is not listed",
+		},
+	}
+
+	for _, tc := range tt {
+		atomic.StoreInt32(&calls, 0)
+		atomic.StoreInt32(&failures, tc.failures)
+		atomic.StoreInt32(&status, tc.status)
+		statusCodes := constants.DefaultRetryStatusCodes
+		if tc.statusCodes != "" {
+			statusCodes = tc.statusCodes
+		}
+		setGlobalFlag(t, constants.RetryStatusCodes, statusCodes)
+		if tc.nonIdempotent != "" {
+			setGlobalFlag(t, constants.RetryNonIdempotent, tc.nonIdempotent)
+		}
+
+		_, err := execute(t, tenantCmd, tc.args)
+		if tc.wantErr == true {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+		assert.Equal(t, tc.wantCalls, atomic.LoadInt32(&calls), tc.description)
+	}
+
+	// the wait requested by the server is bounded by the maximum retry wait
+	atomic.StoreInt32(&calls, 0)
+	atomic.StoreInt32(&failures, 1)
+	atomic.StoreInt32(&status, http.StatusTooManyRequests)
+	retryAfter.Store("86400")
+	start := time.Now()
+	_, err := execute(t, tenantCmd, listArgs)
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second, "Test a Retry-After longer than the maximum retry wait")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// the deadline bounds the time spent on the request and all its retries
+	atomic.StoreInt32(&calls, 0)
+	atomic.StoreInt32(&failures, 10)
+	atomic.StoreInt32(&status, http.StatusServiceUnavailable)
+	atomic.StoreInt64(&delay, int64(30*time.Millisecond))
+	setGlobalFlag(t, constants.Deadline, "50ms")
+	start = time.Now()
+	_, err = execute(t, tenantCmd, listArgs)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.LessOrEqual(t, atomic.LoadInt32(&calls), int32(2))
+}
+
+func TestCancellation(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// hang until the test completes
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.Header().Set(constants.HTTPHeaderKeyRetryAfter, "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	defer close(done)
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+
+	createCmd.AddCommand(createTagCmd)
+	tenantCmd.AddCommand(createCmd)
+	tenantCmd.AddCommand(listCmd)
+
+	tt := []struct {
+		args        []string
+		description string
+	}{
+		{
+			args:        []string{constants.ListCmd, constants.ServiceOfferCmd, "-q", "cancel-test"},
+			description: "Test cancelling a request waiting for a retry",
+		},
+		{
+			args:        []string{constants.CreateCmd, constants.TagCmd, "-n", "cancel-test", "-q", "cancel-test"},
+			description: "Test cancelling a hung request",
+		},
+	}
+
+	for _, tc := range tt {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		tenantCmd.SetOut(new(bytes.Buffer))
+		tenantCmd.SetErr(new(bytes.Buffer))
+		tenantCmd.SetArgs(tc.args)
+		resetContexts(tenantCmd)
+		start := time.Now()
+		err := tenantCmd.ExecuteContext(ctx)
+
+		assert.True(t, errors.Is(err, client.ErrCancelled), tc.description)
+		assert.Less(t, time.Since(start), 2*time.Second, tc.description)
+	}
+	resetContexts(tenantCmd)
+}
+
+// resetContexts clears the contexts kept by the commands from previous executions, cobra only propagates the
+// context of the root command to the subcommands which do not have one yet
+func resetContexts(c *cobra.Command) {
+	c.SetContext(nil)
+	for _, sub := range c.Commands() {
+		resetContexts(sub)
+	}
+}
+
+func TestRequestIdAndTraceParent(t *testing.T) {
+	var requestId, traceParent atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId.Store(r.Header.Get(constants.HTTPHeaderKeyRequestId))
+		traceParent.Store(r.Header.Get(constants.HTTPHeaderKeyTraceParent))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+	setGlobalFlag(t, constants.NoCacheParamName, "true")
+
+	tenantCmd.AddCommand(listCmd)
+	inbound := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tt := []struct {
+		requestId     string
+		prefix        string
+		traceParent   string
+		wantRequestId string
+		wantPrefix    string
+		wantTraceId   string
+		wantErr       bool
+		description   string
+	}{
+		{
+			description: "Test request ID is generated when it is not provided",
+		},
+		{
+			prefix:      "ci-",
+			wantPrefix:  "ci-",
+			description: "Test generated request ID is prefixed",
+		},
+		{
+			requestId:     "support-ticket-42",
+			prefix:        "ci-",
+			wantRequestId: "support-ticket-42",
+			description:   "Test provided request ID is sent",
+		},
+		{
+			traceParent: inbound,
+			wantTraceId: "4bf92f3577b34da6a3ce929d0e0e4736",
+			description: "Test TRACEPARENT env variable is propagated",
+		},
+		{
+			traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			description: "Test invalid TRACEPARENT env variable starts a new trace",
+		},
+		{
+			prefix:      "invalid#",
+			wantErr:     true,
+			description: "Test invalid request ID prefix",
+		},
+	}
+
+	for _, tc := range tt {
+		requestId.Store("")
+		traceParent.Store("")
+		setGlobalFlag(t, constants.RequestIdPrefix, tc.prefix)
+		t.Setenv(constants.TraceParentEnvVar, tc.traceParent)
+
+		output, err := execute(t, tenantCmd, []string{constants.ListCmd, constants.ServiceOfferCmd, "-q", tc.requestId})
+		if tc.wantErr {
+			assert.True(t, validation.IsInputError(err), tc.description)
+			assert.Empty(t, requestId.Load(), tc.description)
+			assert.NotContains(t, output, constants.HTTPHeaderKeyRequestId+":", tc.description)
+			continue
+		}
+		assert.NoError(t, err, tc.description)
+
+		sentRequestId := requestId.Load().(string)
+		if tc.wantRequestId != "" {
+			assert.Equal(t, tc.wantRequestId, sentRequestId, tc.description)
+		} else {
+			assert.True(t, strings.HasPrefix(sentRequestId, tc.wantPrefix), tc.description)
+			_, err = uuid.Parse(strings.TrimPrefix(sentRequestId, tc.wantPrefix))
+			assert.NoError(t, err, tc.description)
+		}
+
+		sentTraceParent, err := client.ParseTraceParent(traceParent.Load().(string))
+		assert.NoError(t, err, tc.description)
+		if tc.wantTraceId != "" {
+			assert.Equal(t, tc.wantTraceId, sentTraceParent.TraceIdString(), tc.description)
+			assert.NotEqual(t, inbound, sentTraceParent.String(), tc.description)
+		}
+
+		assert.Contains(t, output, constants.HTTPHeaderKeyRequestId+":  "+sentRequestId, tc.description)
+		assert.Contains(t, output, constants.HTTPHeaderKeyTraceId+":    "+sentTraceParent.TraceIdString(), tc.description)
+		// the IDs are printed once the response is
+		assert.True(t, strings.HasSuffix(output, constants.HTTPHeaderKeyTraceParent+": "+sentTraceParent.String()),
+			tc.description)
+	}
+}
+
+func TestDebugHttp(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1)%2 == 1 {
+			w.Header().Set(constants.HTTPHeaderKeyRetryAfter, "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
+		_, _ = w.Write([]byte(`{"id":"` + uuid.NewString() + `","name":"debug-client","keys":["secret-key-1","secret-key-2"],` +
+			`"policy_ids":[],"tags":[]}`))
+	}))
+	defer server.Close()
+	test.SetupMockConfiguration(server.URL, tempConfigFile)
+	useMockServer(t, server.URL)
+	// each request is sent to the server instead of listing the cached service offers
+	setGlobalFlag(t, constants.NoCacheParamName, "true")
+	setGlobalFlag(t, constants.RetryWaitMin, "1ms")
+	setGlobalFlag(t, constants.RetryWaitMax, "5ms")
+	t.Cleanup(func() {
+		assert.NoError(t, getApiClientsCmd.Flags().Set(constants.ApiClientIdParamName, ""))
+		tenantCmd.SetOut(nil)
+		tenantCmd.SetErr(nil)
+	})
+	tenantCmd.AddCommand(listCmd)
+	args := []string{constants.ListCmd, constants.ApiClientCmd, "-r", uuid.NewString(), "-c", uuid.NewString(), "-q", "debug-test"}
+
+	tt := []struct {
+		flag        string
+		value       string
+		want        []string
+		wantNot     []string
+		description string
+	}{
+		{
+			flag:        constants.DebugHttpLevel,
+			value:       "1",
+			want:        []string{"> GET " + server.URL, "< 503 Service Unavailable", "(retry 1)", "< 200 OK"},
+			wantNot:     []string{"> Request-Id:", "debug-client"},
+			description: "Test level 1 logs the attempts",
+		},
+		{
+			flag:        constants.DebugHttpLevel,
+			value:       "2",
+			want:        []string{"> X-Api-Key: REDACTED", "> Request-Id: debug-test", "< Content-Type: application/json"},
+			wantNot:     []string{"debug-client"},
+			description: "Test level 2 logs the headers with the API key redacted",
+		},
+		{
+			flag:        constants.DebugHttp,
+			value:       "true",
+			want:        []string{`"name":"debug-client"`, `"keys":["REDACTED","REDACTED"]`},
+			wantNot:     []string{"secret-key"},
+			description: "Test --debug-http logs the bodies with the api client keys redacted",
+		},
+	}
+
+	for _, tc := range tt {
+		setGlobalFlag(t, tc.flag, tc.value)
+		debugOutput := new(bytes.Buffer)
+		tenantCmd.SetOut(io.Discard)
+		tenantCmd.SetErr(debugOutput)
+		tenantCmd.SetArgs(args)
+
+		err := tenantCmd.Execute()
+		assert.NoError(t, err, tc.description)
+		for _, want := range tc.want {
+			assert.Contains(t, debugOutput.String(), want, tc.description)
+		}
+		for _, wantNot := range tc.wantNot {
+			assert.NotContains(t, debugOutput.String(), wantNot, tc.description)
+		}
+		assert.NoError(t, tenantCmd.PersistentFlags().Lookup(tc.flag).Value.Set(tenantCmd.PersistentFlags().Lookup(tc.flag).DefValue))
+	}
+
+	setGlobalFlag(t, constants.DebugHttpOutput, "invalid")
+	_, err := execute(t, tenantCmd, args)
+	assert.True(t, validation.IsInputError(err))
+}
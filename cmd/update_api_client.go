@@ -6,21 +6,12 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
 	"intel/tac/v1/models"
-	"intel/tac/v1/utils"
-	"intel/tac/v1/validation"
-	"net/http"
-	"net/url"
-	"strings"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -31,14 +22,16 @@ var updateApiClientCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("update apiClient called")
-		response, err := updateApiClient(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := updateApiClient(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("ApiClient: \n\n", response)
-		fmt.Println("\nNOTE: There may be a delay of up to two (2) minutes for the changes to the attestation API key to take effect.")
-		fmt.Print("\n")
+		if err = printResponse(cmd, response); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "\nNOTE: There may be a delay of up to two (2) minutes for the changes to the attestation API key to take effect.")
 		return nil
 	},
 }
@@ -46,10 +39,10 @@ var updateApiClientCmd = &cobra.Command{
 func init() {
 	updateCmd.AddCommand(updateApiClientCmd)
 
-	updateApiClientCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id of the Trust Authority service for which the api client needs to be updated")
-	updateApiClientCmd.Flags().StringP(constants.ProductIdParamName, "p", "", "Id of the Trust Authority Product for which the api client needs to be updated")
-	updateApiClientCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id of the api client that needs to be updated")
-	updateApiClientCmd.Flags().StringSliceP(constants.PolicyIdsParamName, "i", []string{}, "List of comma separated policy IDs to be linked to the api client")
+	updateApiClientCmd.Flags().StringP(constants.ServiceIdParamName, "r", "", "Id or name of the Trust Authority service for which the api client needs to be updated")
+	updateApiClientCmd.Flags().StringP(constants.ProductIdParamName, "p", "", "Id or name of the Trust Authority Product for which the api client needs to be updated")
+	updateApiClientCmd.Flags().StringP(constants.ApiClientIdParamName, "c", "", "Id or name of the api client that needs to be updated")
+	updateApiClientCmd.Flags().StringSliceP(constants.PolicyIdsParamName, "i", []string{}, "List of comma separated policy IDs or names to be linked to the api client")
 	updateApiClientCmd.Flags().StringSliceP(constants.TagKeyAndValuesParamName, "v", []string{}, "List of the comma separated tad Id and value pairs in the "+
 		"following format:\n Workload:WorkloadAI,Workload:WorkloadEXE etc.")
 	updateApiClientCmd.Flags().StringP(constants.ActivationStatus, "s", "", "Add activation status for api client, should be one of \"Active\", \"Inactive\" or \"Cancelled\"")
@@ -57,94 +50,72 @@ func init() {
 	updateApiClientCmd.MarkFlagRequired(constants.ServiceIdParamName)
 	updateApiClientCmd.MarkFlagRequired(constants.ProductIdParamName)
 	updateApiClientCmd.MarkFlagRequired(constants.ApiClientIdParamName)
+	updateApiClientCmd.RegisterFlagCompletionFunc(constants.ServiceIdParamName, completeServices)
+	updateApiClientCmd.RegisterFlagCompletionFunc(constants.ProductIdParamName, completeProducts)
+	updateApiClientCmd.RegisterFlagCompletionFunc(constants.ApiClientIdParamName, completeApiClients)
+	updateApiClientCmd.RegisterFlagCompletionFunc(constants.PolicyIdsParamName, completePolicyList)
+	promptMissingFlags(updateApiClientCmd, servicePrompt(), productPrompt(), apiClientPrompt())
 }
 
-func updateApiClient(cmd *cobra.Command) (string, error) {
-
-	configValues, err := config.LoadConfiguration()
-	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
+func updateApiClient(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	serviceIdString, err := cmd.Flags().GetString(constants.ServiceIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	serviceId, err := uuid.Parse(serviceIdString)
+	serviceId, err := resolver.ServiceId(cmd.Context(), serviceIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid service id provided")
+		return nil, err
 	}
 
 	productIdString, err := cmd.Flags().GetString(constants.ProductIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	productId, err := uuid.Parse(productIdString)
+	productId, err := resolver.ProductId(cmd.Context(), serviceId, productIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid product id provided")
+		return nil, err
 	}
 
 	apiClientIdString, err := cmd.Flags().GetString(constants.ApiClientIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	apiClientId, err := uuid.Parse(apiClientIdString)
+	apiClientId, err := resolver.ApiClientId(cmd.Context(), serviceId, apiClientIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid api client Id provided")
+		return nil, err
 	}
 
 	activationStatus, err := cmd.Flags().GetString(constants.ActivationStatus)
 	if err != nil {
-		return "", err
-	} else if activationStatus != "" && activationStatus != constants.ApiClientStatusActive &&
-		activationStatus != constants.ApiClientStatusInactive && activationStatus != constants.ApiClientStatusCancelled {
-		return "", errors.Errorf("Activation status should be one of %s, %s or %s", constants.ApiClientStatusActive,
-			constants.ApiClientStatusInactive, constants.ApiClientStatusCancelled)
+		return nil, err
 	}
 
 	policyIdsString, err := cmd.Flags().GetStringSlice(constants.PolicyIdsParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	var policyIds []uuid.UUID
-	for _, policyId := range policyIdsString {
-		policyUUID, err := uuid.Parse(policyId)
-		if err != nil {
-			return "", errors.Wrap(err, "Invalid policy ID found "+policyId+". Should be UUID.")
-		}
-		policyIds = append(policyIds, policyUUID)
+	policyIds, err := resolver.PolicyIds(cmd.Context(), policyIdsString)
+	if err != nil {
+		return nil, err
 	}
 
 	tagKeyValuesString, err := cmd.Flags().GetStringSlice(constants.TagKeyAndValuesParamName)
-
-	var tagIdValues []models.ApiClientTagIdValue
-	for _, tagIdValue := range tagKeyValuesString {
-		splitTag := strings.Split(tagIdValue, ":")
-		if len(splitTag) != 2 {
-			return "", errors.New("Tag Id value pairs are not provided in proper format, please check help section for more details")
-		}
-		if err = validation.ValidateTagName(splitTag[0]); err != nil {
-			return "", err
-		}
-		if err = validation.ValidateTagValue(splitTag[1]); err != nil {
-			return "", err
-		}
-		tagIdValues = append(tagIdValues, models.ApiClientTagIdValue{Key: splitTag[0], Value: splitTag[1]})
+	if err != nil {
+		return nil, err
+	}
+	tagIdValues, err := sdk.ParseTagValues(tagKeyValuesString)
+	if err != nil {
+		return nil, err
 	}
 
 	var apiClientInfo = models.UpdateApiClient{
@@ -153,22 +124,10 @@ func updateApiClient(cmd *cobra.Command) (string, error) {
 		TagIdsValues: tagIdValues,
 		ServiceId:    serviceId,
 	}
-
 	if activationStatus != "" {
 		var status = models.ApiClientStatus(activationStatus)
 		apiClientInfo.Status = &status
 	}
 
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-	response, err := tmsClient.UpdateApiClient(&apiClientInfo, apiClientId)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
-	}
-
-	return string(responseBytes), nil
+	return taClient.UpdateApiClient(cmd.Context(), apiClientId, &apiClientInfo, sdk.Metadata(md))
 }
@@ -6,21 +6,11 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/pms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
 	"intel/tac/v1/models"
-	"intel/tac/v1/utils"
-	"intel/tac/v1/validation"
-	"net/http"
-	"net/url"
-	"os"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -32,103 +22,65 @@ var updatePolicyCmd = &cobra.Command{
 	Long:  ``,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Info("update Policy called")
-		response, err := updatePolicy(cmd)
-		utils.PrintRequestAndTraceId()
+		md := &client.RequestMetadata{}
+		defer printFooter(cmd, md)
+		response, err := updatePolicy(cmd, md)
 		if err != nil {
 			return err
 		}
-		fmt.Println("Updated policy: \n\n", response)
-		return nil
+		return printResponse(cmd, response)
 	},
 }
 
 func init() {
 	updateCmd.AddCommand(updatePolicyCmd)
 
-	updatePolicyCmd.Flags().StringP(constants.PolicyIdParamName, "i", "", "Id of the policy to be updated")
+	updatePolicyCmd.Flags().StringP(constants.PolicyIdParamName, "i", "", "Id or name of the policy to be updated")
 	updatePolicyCmd.Flags().StringP(constants.PolicyNameParamName, "n", "", "Name of the policy to be updated")
 	updatePolicyCmd.Flags().StringP(constants.PolicyFileParamName, "f", "", "Path of the file containing the rego policy to be uploaded. The file size should be <= 10 KB")
 	updatePolicyCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	updatePolicyCmd.MarkFlagRequired(constants.PolicyIdParamName)
+	updatePolicyCmd.RegisterFlagCompletionFunc(constants.PolicyIdParamName, completePolicies)
+	promptMissingFlags(updatePolicyCmd, policyPrompt(constants.PolicyIdParamName))
 }
 
-func updatePolicy(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func updatePolicy(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	pmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.PmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	policyIdString, err := cmd.Flags().GetString(constants.PolicyIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	policyId, err := uuid.Parse(policyIdString)
+	policyId, err := resolver.PolicyId(cmd.Context(), policyIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid policy Id provided, should be in UUID format")
+		return nil, err
 	}
 
-	var policyUpdateReq = models.PolicyUpdateRequest{PolicyId: policyId}
-
 	policyName, err := cmd.Flags().GetString(constants.PolicyNameParamName)
 	if err != nil {
-		return "", err
-	}
-
-	if policyName != "" {
-		if err = validation.ValidatePolicyName(policyName); err != nil {
-			return "", err
-		}
-		policyUpdateReq.PolicyName = policyName
+		return nil, err
 	}
 
 	policyFilePath, err := cmd.Flags().GetString(constants.PolicyFileParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+
+	var policyUpdateReq = models.PolicyUpdateRequest{PolicyId: policyId, PolicyName: policyName}
 	// policy file is not mandatory, skipping policy read if file path is empty
 	if policyFilePath != "" {
-		path, err := validation.ValidatePath(policyFilePath)
-		if err != nil {
-			return "", err
-		}
-		policyBytes, err := os.ReadFile(path)
-		if err != nil {
-			return "", errors.Wrap(err, "Error reading policy file")
-		}
-
-		err = validation.ValidateSize(policyFilePath)
-		if err != nil {
-			return "", err
-		}
-
-		if string(policyBytes) != "" {
-			policyUpdateReq.Policy = string(policyBytes)
+		if policyUpdateReq.Policy, err = sdk.ReadPolicyFile(policyFilePath); err != nil {
+			return nil, err
 		}
 	}
 
-	pmsClient := pms.NewPmsClient(client, pmsUrl, apiKey)
-	response, err := pmsClient.UpdatePolicy(&policyUpdateReq)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
-	}
-
-	return string(responseBytes), nil
+	return taClient.UpdatePolicy(cmd.Context(), &policyUpdateReq, sdk.Metadata(md))
 }
@@ -6,19 +6,11 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"intel/tac/v1/client/tms"
-	"intel/tac/v1/config"
+	"intel/tac/v1/client"
 	"intel/tac/v1/constants"
 	"intel/tac/v1/models"
-	"intel/tac/v1/utils"
-	"net/http"
-	"net/url"
-	"time"
+	"intel/tac/v1/sdk"
 
 	"github.com/spf13/cobra"
 )
@@ -36,13 +28,13 @@ var (
 		Long:  ``,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log.Info("update user role called")
-			userId, err := updateUserRole(cmd)
-			utils.PrintRequestAndTraceId()
+			md := &client.RequestMetadata{}
+			defer printFooter(cmd, md)
+			response, err := updateUserRole(cmd, md)
 			if err != nil {
 				return err
 			}
-			fmt.Printf("\nUpdated User: %s \n\n", userId)
-			return nil
+			return printResponse(cmd, response)
 		},
 	}
 )
@@ -51,66 +43,43 @@ func init() {
 	updateCmd.AddCommand(updateUserCmd)
 	updateUserCmd.AddCommand(updateUserRoleCmd)
 
-	updateUserRoleCmd.Flags().StringP(constants.UserIdParamName, "u", "", "Id of the specific user")
+	updateUserRoleCmd.Flags().StringP(constants.UserIdParamName, "u", "", "Id or email of the specific user")
 	updateUserRoleCmd.Flags().StringP(constants.UserRoleParamName, "r", "", "Role of the specific user that needs to be updated. Should be either Tenant Admin or User")
 	updateUserRoleCmd.Flags().StringP(constants.RequestIdParamName, "q", "", "Request ID to be associated with the specific request. This is optional.")
 	updateUserRoleCmd.MarkFlagRequired(constants.UserIdParamName)
 	updateUserRoleCmd.MarkFlagRequired(constants.UserRoleParamName)
+	updateUserRoleCmd.RegisterFlagCompletionFunc(constants.UserIdParamName, completeUsers)
+	promptMissingFlags(updateUserRoleCmd, userPrompt(),
+		choicePrompt(constants.UserRoleParamName, "Role", constants.TenantAdminRole, constants.UserRole))
 }
 
-func updateUserRole(cmd *cobra.Command) (string, error) {
-	configValues, err := config.LoadConfiguration()
+func updateUserRole(cmd *cobra.Command, md *client.RequestMetadata) (interface{}, error) {
+	taClient, err := newTrustAuthorityClient()
 	if err != nil {
-		return "", err
-	}
-	client := &http.Client{
-		Timeout: time.Duration(configValues.HTTPClientTimeout) * time.Second,
-	}
-
-	tmsUrl, err := url.Parse(configValues.TrustAuthorityBaseUrl + constants.TmsBaseUrl)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if err = setRequestId(cmd); err != nil {
-		return "", err
+	if err = setRequestId(cmd, md); err != nil {
+		return nil, err
 	}
+	resolver := sdk.NewResolver(taClient, sdk.Metadata(md))
 
 	userIdString, err := cmd.Flags().GetString(constants.UserIdParamName)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	userId, err := uuid.Parse(userIdString)
+	userId, err := resolver.UserId(cmd.Context(), userIdString)
 	if err != nil {
-		return "", errors.Wrap(err, "Invalid user id provided")
+		return nil, err
 	}
 
 	userRole, err := cmd.Flags().GetString(constants.UserRoleParamName)
 	if err != nil {
-		return "", err
-	}
-	if userRole != constants.TenantAdminRole && userRole != constants.UserRole {
-		return "", errors.Errorf("%s is not a valid user role. Roles should be either %s or %s", userRole,
-			constants.TenantAdminRole, constants.UserRole)
+		return nil, err
 	}
 
-	updateUserRoleReq := &models.UpdateTenantUserRoles{
+	return taClient.UpdateUserRole(cmd.Context(), &models.UpdateTenantUserRoles{
 		UserId: userId,
 		Role:   userRole,
-	}
-
-	tmsClient := tms.NewTmsClient(client, tmsUrl, apiKey)
-
-	response, err := tmsClient.UpdateTenantUserRole(updateUserRoleReq)
-	if err != nil {
-		return "", err
-	}
-
-	responseBytes, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		return "", err
-	}
-
-	return string(responseBytes), nil
+	}, sdk.Metadata(md))
 }
@@ -9,48 +9,176 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 	"intel/tac/v1/constants"
+	"intel/tac/v1/secrets"
 	"intel/tac/v1/utils"
 	"intel/tac/v1/validation"
 	"net/url"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Configuration struct {
-	TrustAuthorityBaseUrl string `yaml:"trustauthority-url" mapstructure:"trustauthority-url"`
-	TrustAuthorityApiKey  string `yaml:"trustauthority-api-key" mapstructure:"trustauthority-api-key"`
-	LogLevel              string `yaml:"log-level" mapstructure:"log-level"`
-	HTTPClientTimeout     int    `yaml:"http-client-timeout" mapstructure:"http-client-timeout"`
+	TrustAuthorityBaseUrl string `json:"trustauthority-url" yaml:"trustauthority-url" mapstructure:"trustauthority-url"`
+	TrustAuthorityApiKey  string `json:"trustauthority-api-key" yaml:"trustauthority-api-key" mapstructure:"trustauthority-api-key"`
+	LogLevel              string `json:"log-level" yaml:"log-level" mapstructure:"log-level"`
+	HTTPClientTimeout     int    `json:"http-client-timeout" yaml:"http-client-timeout" mapstructure:"http-client-timeout"`
+	SecretStore           string `json:"secret-store,omitempty" yaml:"secret-store,omitempty" mapstructure:"secret-store"`
+	CaBundle              string `json:"ca-bundle,omitempty" yaml:"ca-bundle,omitempty" mapstructure:"ca-bundle"`
+	ClientCert            string `json:"client-cert,omitempty" yaml:"client-cert,omitempty" mapstructure:"client-cert"`
+	ClientKey             string `json:"client-key,omitempty" yaml:"client-key,omitempty" mapstructure:"client-key"`
+	Proxy                 string `json:"proxy,omitempty" yaml:"proxy,omitempty" mapstructure:"proxy"`
+	NoProxy               string `json:"no-proxy,omitempty" yaml:"no-proxy,omitempty" mapstructure:"no-proxy"`
+	MinTLSVersion         string `json:"min-tls-version,omitempty" yaml:"min-tls-version,omitempty" mapstructure:"min-tls-version"`
+	// RetryMax and RetryJitter are pointers so that disabling them in a profile is not mistaken for an unset value
+	RetryMax           *int          `json:"retry-max,omitempty" yaml:"retry-max,omitempty" mapstructure:"retry-max"`
+	RetryWaitMin       time.Duration `json:"retry-wait-min,omitempty" yaml:"retry-wait-min,omitempty" mapstructure:"retry-wait-min"`
+	RetryWaitMax       time.Duration `json:"retry-wait-max,omitempty" yaml:"retry-wait-max,omitempty" mapstructure:"retry-wait-max"`
+	RetryJitter        *bool         `json:"retry-jitter,omitempty" yaml:"retry-jitter,omitempty" mapstructure:"retry-jitter"`
+	RetryStatusCodes   string        `json:"retry-status-codes,omitempty" yaml:"retry-status-codes,omitempty" mapstructure:"retry-status-codes"`
+	RetryNonIdempotent bool          `json:"retry-non-idempotent,omitempty" yaml:"retry-non-idempotent,omitempty" mapstructure:"retry-non-idempotent"`
+	Deadline           time.Duration `json:"deadline,omitempty" yaml:"deadline,omitempty" mapstructure:"deadline"`
+	RequestIdPrefix    string        `json:"request-id-prefix,omitempty" yaml:"request-id-prefix,omitempty" mapstructure:"request-id-prefix"`
+	OtlpEndpoint       string        `json:"otlp-endpoint,omitempty" yaml:"otlp-endpoint,omitempty" mapstructure:"otlp-endpoint"`
+	TelemetryFile      string        `json:"telemetry-file,omitempty" yaml:"telemetry-file,omitempty" mapstructure:"telemetry-file"`
+	// the cache TTLs are the times the resources which seldom change are reused for before being fetched again
+	CacheTTLServices      time.Duration `json:"cache-ttl-services,omitempty" yaml:"cache-ttl-services,omitempty" mapstructure:"cache-ttl-services"`
+	CacheTTLServiceOffers time.Duration `json:"cache-ttl-service-offers,omitempty" yaml:"cache-ttl-service-offers,omitempty" mapstructure:"cache-ttl-service-offers"`
+	CacheTTLProducts      time.Duration `json:"cache-ttl-products,omitempty" yaml:"cache-ttl-products,omitempty" mapstructure:"cache-ttl-products"`
+	CacheTTLPlans         time.Duration `json:"cache-ttl-plans,omitempty" yaml:"cache-ttl-plans,omitempty" mapstructure:"cache-ttl-plans"`
+	CacheTTLTags          time.Duration `json:"cache-ttl-tags,omitempty" yaml:"cache-ttl-tags,omitempty" mapstructure:"cache-ttl-tags"`
 }
 
-// this function sets the configuration file name and type
+// this function sets the configuration file name and type, and the env variables overriding the configuration
 func init() {
 	userHomeDir, _ := os.UserHomeDir()
 	viper.SetConfigName(constants.ConfigFileName)
 	viper.SetConfigType(constants.ConfigFileExtension)
 	viper.AddConfigPath(userHomeDir + constants.ConfigDir)
+
+	_ = viper.BindEnv(constants.TrustAuthBaseUrl, constants.UrlEnvVar)
+	_ = viper.BindEnv(constants.TrustAuthApiKeyEnvVar, constants.ApiKeyEnvVar)
+	_ = viper.BindEnv(constants.Loglevel, constants.LogLevelEnvVar, constants.LegacyLogLevelEnvVar)
+	_ = viper.BindEnv(constants.HttpClientTimeout, constants.HttpClientTimeoutEnvVar, constants.LegacyTimeoutEnvVar)
+	_ = viper.BindEnv(constants.CaBundle, constants.CaBundleEnvVar)
+	_ = viper.BindEnv(constants.ClientCert, constants.ClientCertEnvVar)
+	_ = viper.BindEnv(constants.ClientKey, constants.ClientKeyEnvVar)
+	_ = viper.BindEnv(constants.Proxy, constants.ProxyEnvVar)
+	_ = viper.BindEnv(constants.NoProxy, constants.NoProxyEnvVar)
+	_ = viper.BindEnv(constants.MinTLSVersion, constants.MinTLSVersionEnvVar)
+	for _, key := range []string{constants.RetryMax, constants.RetryWaitMin, constants.RetryWaitMax, constants.RetryJitter,
+		constants.RetryStatusCodes, constants.RetryNonIdempotent, constants.Deadline, constants.RequestIdPrefix,
+		constants.TelemetryFile, constants.CacheTTLServices, constants.CacheTTLServiceOffers, constants.CacheTTLProducts,
+		constants.CacheTTLPlans, constants.CacheTTLTags} {
+		_ = viper.BindEnv(key, envVarName(key))
+	}
+	// the standard OpenTelemetry env variable is used when the CLI specific one is not set
+	_ = viper.BindEnv(constants.OtlpEndpoint, envVarName(constants.OtlpEndpoint), constants.OtelOtlpEndpointEnvVar)
+
+	//set default
+	viper.SetDefault(constants.Loglevel, constants.DefaultLogLevel)
+	viper.SetDefault(constants.HttpClientTimeout, constants.DefaultHttpClientTimeout)
+	viper.SetDefault(constants.RetryMax, constants.DefaultRetryCount)
+	viper.SetDefault(constants.RetryWaitMin, constants.DefaultRetryWaitMin*time.Second)
+	viper.SetDefault(constants.RetryWaitMax, constants.DefaultRetryWaitMax*time.Second)
+	viper.SetDefault(constants.RetryJitter, true)
+	viper.SetDefault(constants.RetryStatusCodes, constants.DefaultRetryStatusCodes)
+	viper.SetDefault(constants.CacheTTLServices, constants.DefaultServicesCacheTTL*time.Minute)
+	viper.SetDefault(constants.CacheTTLServiceOffers, constants.DefaultCatalogCacheTTL*time.Hour)
+	viper.SetDefault(constants.CacheTTLProducts, constants.DefaultCatalogCacheTTL*time.Hour)
+	viper.SetDefault(constants.CacheTTLPlans, constants.DefaultCatalogCacheTTL*time.Hour)
+	viper.SetDefault(constants.CacheTTLTags, constants.DefaultTagsCacheTTL*time.Minute)
 }
 
+// envVarName returns the name of the env variable overriding a configuration key, e.g. TRUSTAUTHORITY_RETRY_MAX
+func envVarName(key string) string {
+	return constants.EnvVarPrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// LoadConfiguration loads the configuration of the active profile. The values are resolved in the following order,
+// the first one found is used:
+//   - the --url, --api-key-file and --timeout flags
+//   - the TRUSTAUTHORITY_URL, TRUSTAUTHORITY_API_KEY, TRUSTAUTHORITY_LOG_LEVEL and TRUSTAUTHORITY_HTTP_CLIENT_TIMEOUT
+//     env variables, LOG_LEVEL and HTTP_CLIENT_TIMEOUT being read when the prefixed ones are not set
+//   - the active profile, with the API key read from its secret store
+//   - the top level values of the configuration file and the defaults
+//
+// The configuration file is optional, the CLI can be used with flags and env variables only.
 func LoadConfiguration() (*Configuration, error) {
 	ret := Configuration{}
 	// Find and read the config file
 	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			// Config file not found
-			return &ret, errors.Wrap(err, "Config file not found")
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok && !os.IsNotExist(err) {
+			return &ret, errors.Wrap(err, "Failed to load config")
+		}
+		log.Debug("Config file not found, using flags and env variables only")
+	}
+
+	configFile, err := ReadConfigFile()
+	if err != nil {
+		return &ret, err
+	}
+
+	var profile *Configuration
+	profileName := configFile.ActiveProfileName()
+	if len(configFile.Profiles) != 0 {
+		var ok bool
+		profile, ok = configFile.Profiles[profileName]
+		if !ok {
+			return &ret, errors.Errorf("Profile %q not found in configuration", profileName)
+		}
+		profileSettings, err := profile.settings()
+		if err != nil {
+			return &ret, err
+		}
+		if err = viper.MergeConfigMap(profileSettings); err != nil {
+			return &ret, errors.Wrapf(err, "Failed to load profile %q", profileName)
 		}
-		return &ret, errors.Wrap(err, "Failed to load config")
 	}
+
 	if err := viper.Unmarshal(&ret); err != nil {
 		return &ret, errors.Wrap(err, "Failed to unmarshal config")
 	}
+
+	if profile != nil {
+		ret.SecretStore = profile.SecretStore
+	}
+
+	if apiKeyFile := viper.GetString(constants.ApiKeyFileParamName); apiKeyFile != "" {
+		apiKey, err := readApiKeyFile(apiKeyFile)
+		if err != nil {
+			return &ret, err
+		}
+		ret.TrustAuthorityApiKey = apiKey
+	} else if ret.TrustAuthorityApiKey == "" && profile != nil && secrets.IsExternal(profile.SecretStore) {
+		// the API key of the profile is only read from its secret store when it is not overridden by the env
+		apiKey, err := profile.resolveApiKey(profileName)
+		if err != nil {
+			return &ret, err
+		}
+		ret.TrustAuthorityApiKey = apiKey
+	}
 	return &ret, nil
 }
 
-func SetupConfig(envFilePath string) error {
+// readApiKeyFile reads the API key from the file provided with the --api-key-file flag
+func readApiKeyFile(apiKeyFile string) (string, error) {
+	path, err := validation.ValidatePath(apiKeyFile)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid API key file path provided")
+	}
+	apiKey, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to read API key file")
+	}
+	return strings.TrimSpace(string(apiKey)), nil
+}
+
+// SetupConfig writes the configuration provided in the env file to the active profile, keeping the other settings of
+// the profile. The API key is kept in the requested secret store, an empty store name keeps the store already used by
+// the profile.
+func SetupConfig(envFilePath, secretStore string) error {
 	if envFilePath == "" {
 		return errors.New("EnvFilePath needs to be provided in configuration")
 	}
@@ -60,36 +188,25 @@ func SetupConfig(envFilePath string) error {
 		return errors.Wrap(err, "Invalid Env file path provided")
 	}
 
-	userHomeDir, err := os.UserHomeDir()
-	if err != nil {
-		return errors.Wrap(err, "Error fetching user home directory path")
-	}
-
-	cleanedConfigPath := filepath.Clean(userHomeDir + constants.DefaultConfigFilePath)
-
-	configFile, err := os.OpenFile(cleanedConfigPath, os.O_CREATE|os.O_TRUNC|os.O_RDWR, constants.DefaultFilePermission)
+	configFile, err := ReadConfigFile()
 	if err != nil {
-		return errors.Wrap(err, "Failed to open/create config file")
+		return err
 	}
-	defer func() {
-		derr := configFile.Close()
-		if derr != nil {
-			log.WithError(derr).Error("Error closing config file")
-		}
-	}()
 
 	if err = utils.ReadAnswerFileToEnv(envFilePath); err != nil {
 		return err
 	}
 
-	//set default
-	viper.SetDefault(constants.Loglevel, constants.DefaultLogLevel)
-	viper.SetDefault(constants.HttpClientTimeout, constants.DefaultHttpClientTimeout)
-
-	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
-	viper.AutomaticEnv()
+	profileName := configFile.ActiveProfileName()
+	if err = validateProfileName(profileName); err != nil {
+		return err
+	}
 
-	configValues := &Configuration{}
+	// the settings of an existing profile, e.g. set with config set, are kept
+	configValues, ok := configFile.Profiles[profileName]
+	if !ok {
+		configValues = &Configuration{}
+	}
 
 	configValues.TrustAuthorityBaseUrl = viper.GetString(constants.TrustAuthBaseUrl)
 	if configValues.TrustAuthorityBaseUrl == "" {
@@ -101,23 +218,36 @@ func SetupConfig(envFilePath string) error {
 		return errors.Wrap(err, "Invalid Trust Authority Base URL")
 	}
 
-	configValues.TrustAuthorityApiKey = viper.GetString(constants.TrustAuthApiKeyEnvVar)
-	if err := validation.ValidateTrustAuthorityAPIKey(configValues.TrustAuthorityApiKey); err != nil {
+	apiKey := viper.GetString(constants.TrustAuthApiKeyEnvVar)
+	if err := validation.ValidateTrustAuthorityAPIKey(apiKey); err != nil {
 		return errors.Wrap(err, "Invalid API Key provided")
 	}
 
 	logLevel, err := log.ParseLevel(viper.GetString(constants.Loglevel))
 	if err != nil {
 		log.Warn("Invalid/No log level provided. Setting log level to info")
+		configValues.LogLevel = ""
 	} else {
 		configValues.LogLevel = logLevel.String()
 	}
 
 	configValues.HTTPClientTimeout = viper.GetInt(constants.HttpClientTimeout)
 
-	err = yaml.NewEncoder(configFile).Encode(configValues)
-	if err != nil {
-		return errors.Wrap(err, "Failed to encode config structure")
+	if secretStore != "" {
+		if err = secrets.Validate(secretStore); err != nil {
+			return err
+		}
+		configValues.SecretStore = ""
+		if secrets.IsExternal(secretStore) {
+			configValues.SecretStore = secretStore
+		}
+	}
+	if err = configValues.storeApiKey(profileName, apiKey); err != nil {
+		return err
+	}
+	configFile.Profiles[profileName] = configValues
+	if configFile.CurrentProfile == "" {
+		configFile.CurrentProfile = profileName
 	}
-	return nil
+	return configFile.Write()
 }
@@ -0,0 +1,299 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package config
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/secrets"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ConfigFile is the layout of the configuration file. Each profile holds the configuration for one
+// Trust Authority tenant/environment and the current profile is used unless another one is requested.
+type ConfigFile struct {
+	CurrentProfile string                    `json:"current-profile" yaml:"current-profile"`
+	Profiles       map[string]*Configuration `json:"profiles" yaml:"profiles"`
+}
+
+// ProfileSummary is the profile information printed by the get-contexts command
+type ProfileSummary struct {
+	Current               bool   `json:"current"`
+	Name                  string `json:"name"`
+	TrustAuthorityBaseUrl string `json:"trustauthority_url"`
+	SecretStore           string `json:"secret_store"`
+}
+
+// Columns returns the table headers of the profiles
+func (p ProfileSummary) Columns() []string {
+	return []string{"CURRENT", "NAME", "URL", "SECRET STORE"}
+}
+
+// Row returns the table row of the profile, the current profile is marked with *
+func (p ProfileSummary) Row() []string {
+	current := ""
+	if p.Current {
+		current = "*"
+	}
+	return []string{current, p.Name, p.TrustAuthorityBaseUrl, p.SecretStore}
+}
+
+// legacyConfigFile is the single profile layout written by previous versions of the CLI
+type legacyConfigFile struct {
+	Configuration `yaml:",inline"`
+	ConfigFile    `yaml:",inline"`
+}
+
+var activeProfile string
+
+// explicitZeroKeys are the keys for which a zero value set in a profile overrides the default
+var explicitZeroKeys = map[string]bool{
+	constants.RetryMax:    true,
+	constants.RetryJitter: true,
+}
+
+// SetActiveProfile selects the profile to be used by LoadConfiguration, overriding the current profile
+// stored in the configuration file
+func SetActiveProfile(name string) {
+	activeProfile = name
+}
+
+// ActiveProfileName returns the name of the profile to be used, the one requested with the --profile flag or the
+// TRUSTAUTHORITY_PROFILE env variable takes precedence over the current profile of the configuration file
+func (cf *ConfigFile) ActiveProfileName() string {
+	if activeProfile != "" {
+		return activeProfile
+	}
+	if envProfile := os.Getenv(constants.ProfileEnvVar); envProfile != "" {
+		return envProfile
+	}
+	if cf.CurrentProfile != "" {
+		return cf.CurrentProfile
+	}
+	return constants.DefaultProfileName
+}
+
+// ReadConfigFile reads the configuration file, migrating the single profile layout of previous versions to
+// the default profile in memory, the file is only rewritten with the profiles when the configuration is written.
+// An empty configuration file is returned if the file does not exist yet.
+func ReadConfigFile() (*ConfigFile, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	configBytes, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ConfigFile{Profiles: map[string]*Configuration{}}, nil
+		}
+		return nil, errors.Wrap(err, "Failed to read config file")
+	}
+
+	var legacy legacyConfigFile
+	if err = yaml.Unmarshal(configBytes, &legacy); err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal config")
+	}
+
+	cf := legacy.ConfigFile
+	if cf.Profiles == nil {
+		cf.Profiles = map[string]*Configuration{}
+	}
+	if legacy.Configuration != (Configuration{}) {
+		if _, ok := cf.Profiles[constants.DefaultProfileName]; !ok {
+			log.Debugf("Reading the configuration of a previous version as profile %q", constants.DefaultProfileName)
+			legacyConfig := legacy.Configuration
+			cf.Profiles[constants.DefaultProfileName] = &legacyConfig
+			if cf.CurrentProfile == "" {
+				cf.CurrentProfile = constants.DefaultProfileName
+			}
+		}
+	}
+	return &cf, nil
+}
+
+// Write saves the configuration file
+func (cf *ConfigFile) Write() error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err = enc.Encode(cf); err != nil {
+		return errors.Wrap(err, "Failed to encode config structure")
+	}
+
+	if err = os.WriteFile(path, buf.Bytes(), constants.DefaultFilePermission); err != nil {
+		return errors.Wrap(err, "Failed to write config file")
+	}
+	return nil
+}
+
+// UseProfile sets the current profile of the configuration file
+func UseProfile(name string) error {
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	if _, ok := cf.Profiles[name]; !ok {
+		return errors.Errorf("Profile %q not found in configuration", name)
+	}
+	cf.CurrentProfile = name
+	return cf.Write()
+}
+
+// SetProfile creates or updates the profile with the provided values, empty values leave the existing ones intact
+func SetProfile(name string, values *Configuration) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	profile, ok := cf.Profiles[name]
+	if !ok {
+		profile = &Configuration{
+			LogLevel:          constants.DefaultLogLevel,
+			HTTPClientTimeout: constants.DefaultHttpClientTimeout,
+		}
+		cf.Profiles[name] = profile
+	}
+	if values.TrustAuthorityBaseUrl != "" {
+		profile.TrustAuthorityBaseUrl = values.TrustAuthorityBaseUrl
+	}
+	if values.TrustAuthorityApiKey != "" {
+		profile.TrustAuthorityApiKey = values.TrustAuthorityApiKey
+	}
+	if values.LogLevel != "" {
+		profile.LogLevel = values.LogLevel
+	}
+	if values.HTTPClientTimeout != 0 {
+		profile.HTTPClientTimeout = values.HTTPClientTimeout
+	}
+	if values.SecretStore != "" {
+		if err = profile.migrateSecretStore(name, values.SecretStore); err != nil {
+			return err
+		}
+	}
+	if cf.CurrentProfile == "" {
+		cf.CurrentProfile = name
+	}
+	return cf.Write()
+}
+
+// DeleteProfile removes the profile from the configuration file along with the API key kept in its secret store
+func DeleteProfile(name string) error {
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	profile, ok := cf.Profiles[name]
+	if !ok {
+		return errors.Errorf("Profile %q not found in configuration", name)
+	}
+	if err = profile.deleteApiKey(name); err != nil {
+		log.WithError(err).Warnf("Failed to delete API key of profile %q from the %s secret store", name, profile.SecretStore)
+	}
+	delete(cf.Profiles, name)
+	if cf.CurrentProfile == name {
+		cf.CurrentProfile = ""
+	}
+	return cf.Write()
+}
+
+// GetProfiles lists the profiles of the configuration file sorted by name
+func GetProfiles() ([]ProfileSummary, error) {
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range cf.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	active := cf.ActiveProfileName()
+	profiles := make([]ProfileSummary, 0, len(names))
+	for _, name := range names {
+		profiles = append(profiles, ProfileSummary{
+			Current:               name == active,
+			Name:                  name,
+			TrustAuthorityBaseUrl: cf.Profiles[name].TrustAuthorityBaseUrl,
+			SecretStore:           secretStoreName(cf.Profiles[name].SecretStore),
+		})
+	}
+	return profiles, nil
+}
+
+// settings returns the non empty values of the profile keyed by their configuration key names
+func (c *Configuration) settings() (map[string]interface{}, error) {
+	configBytes, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to encode profile")
+	}
+	values := map[string]interface{}{}
+	if err = yaml.Unmarshal(configBytes, &values); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode profile")
+	}
+	for key, value := range values {
+		if explicitZeroKeys[key] {
+			continue
+		}
+		if value == nil || value == "" || value == 0 || value == false {
+			delete(values, key)
+		}
+	}
+	return values, nil
+}
+
+func secretStoreName(name string) string {
+	if name == "" {
+		return secrets.Plaintext
+	}
+	return name
+}
+
+func validateProfileName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("Profile name cannot be empty")
+	}
+	if strings.ContainsAny(name, " \t\n.") {
+		return errors.New("Profile name cannot contain spaces or dots")
+	}
+	return nil
+}
+
+// configFilePath returns the path of the configuration file found by viper, falling back to the default path
+// under the user home directory when it does not exist yet
+func configFilePath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	if err := viper.ReadInConfig(); err == nil {
+		return viper.ConfigFileUsed(), nil
+	}
+
+	userHomeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "Error fetching user home directory path")
+	}
+	return filepath.Clean(userHomeDir + constants.DefaultConfigFilePath), nil
+}
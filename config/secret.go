@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package config
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"intel/tac/v1/secrets"
+)
+
+// apiKeyCache holds the API keys read from the secret stores so that the keyring or the passphrase are only
+// requested once per invocation, keyed by secret store and profile name
+var apiKeyCache = map[string]string{}
+
+func apiKeyCacheKey(storeName, profileName string) string {
+	return storeName + "/" + profileName
+}
+
+// resolveApiKey reads the API key of the profile from its secret store. The API key of profiles using the
+// plaintext store is kept in the configuration file and returned as is.
+func (c *Configuration) resolveApiKey(profileName string) (string, error) {
+	if !secrets.IsExternal(c.SecretStore) {
+		return c.TrustAuthorityApiKey, nil
+	}
+	cacheKey := apiKeyCacheKey(c.SecretStore, profileName)
+	if apiKey, ok := apiKeyCache[cacheKey]; ok {
+		return apiKey, nil
+	}
+
+	store, err := secrets.New(c.SecretStore)
+	if err != nil {
+		return "", err
+	}
+	apiKey, err := store.Get(profileName)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to read API key of profile %q from the %s secret store", profileName, store.Name())
+	}
+	apiKeyCache[cacheKey] = apiKey
+	return apiKey, nil
+}
+
+// storeApiKey moves the API key of the profile to its secret store, the key is removed from the configuration
+// unless the plaintext store is used
+func (c *Configuration) storeApiKey(profileName, apiKey string) error {
+	if !secrets.IsExternal(c.SecretStore) {
+		c.TrustAuthorityApiKey = apiKey
+		return nil
+	}
+
+	store, err := secrets.New(c.SecretStore)
+	if err != nil {
+		return err
+	}
+	if err = store.Set(profileName, apiKey); err != nil {
+		return errors.Wrapf(err, "Failed to store API key of profile %q in the %s secret store", profileName, store.Name())
+	}
+	// auto is resolved to the store actually used so that the key is read back from the same place
+	c.SecretStore = store.Name()
+	c.TrustAuthorityApiKey = ""
+	apiKeyCache[apiKeyCacheKey(c.SecretStore, profileName)] = apiKey
+	return nil
+}
+
+// deleteApiKey removes the API key of the profile from its secret store
+func (c *Configuration) deleteApiKey(profileName string) error {
+	delete(apiKeyCache, apiKeyCacheKey(c.SecretStore, profileName))
+	if !secrets.IsExternal(c.SecretStore) {
+		return nil
+	}
+	store, err := secrets.New(c.SecretStore)
+	if err != nil {
+		return err
+	}
+	return store.Delete(profileName)
+}
+
+// migrateSecretStore moves the API key of the profile from its current secret store to the requested one
+func (c *Configuration) migrateSecretStore(profileName, storeName string) error {
+	if err := secrets.Validate(storeName); err != nil {
+		return err
+	}
+	if storeName == c.SecretStore || (!secrets.IsExternal(storeName) && !secrets.IsExternal(c.SecretStore)) {
+		return nil
+	}
+
+	apiKey, err := c.resolveApiKey(profileName)
+	if err != nil {
+		return err
+	}
+
+	previous := *c
+	c.SecretStore = storeName
+	if err = c.storeApiKey(profileName, apiKey); err != nil {
+		*c = previous
+		return err
+	}
+	if err = previous.deleteApiKey(profileName); err != nil {
+		log.WithError(err).Warnf("Failed to delete API key of profile %q from the %s secret store", profileName,
+			previous.SecretStore)
+	}
+	if !secrets.IsExternal(storeName) {
+		c.SecretStore = ""
+	}
+	return nil
+}
+
+// SetSecretStore moves the API key of the active profile to the requested secret store, e.g. to migrate the
+// plaintext API key of an existing configuration to the OS keyring
+func SetSecretStore(storeName string) error {
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	profileName := cf.ActiveProfileName()
+	profile, ok := cf.Profiles[profileName]
+	if !ok {
+		return errors.Errorf("Profile %q not found in configuration", profileName)
+	}
+	if err = profile.migrateSecretStore(profileName, storeName); err != nil {
+		return err
+	}
+	return cf.Write()
+}
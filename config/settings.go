@@ -0,0 +1,492 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/secrets"
+	"intel/tac/v1/validation"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maskedApiKeyVisibleChars = 4
+	transportCheckName       = "transport"
+)
+
+// configKeys are the keys of a profile which can be read and updated with the config get/set/unset commands
+var configKeys = []string{constants.TrustAuthBaseUrl, constants.TrustAuthApiKeyEnvVar, constants.Loglevel,
+	constants.HttpClientTimeout, constants.SecretStore, constants.CaBundle, constants.ClientCert, constants.ClientKey,
+	constants.Proxy, constants.NoProxy, constants.MinTLSVersion, constants.RetryMax, constants.RetryWaitMin,
+	constants.RetryWaitMax, constants.RetryJitter, constants.RetryStatusCodes, constants.RetryNonIdempotent,
+	constants.Deadline, constants.RequestIdPrefix, constants.OtlpEndpoint, constants.TelemetryFile,
+	constants.CacheTTLServices, constants.CacheTTLServiceOffers, constants.CacheTTLProducts, constants.CacheTTLPlans,
+	constants.CacheTTLTags}
+
+// retryKeys are the keys of the retry policy settings
+var retryKeys = map[string]bool{constants.RetryMax: true, constants.RetryWaitMin: true, constants.RetryWaitMax: true,
+	constants.RetryJitter: true, constants.RetryStatusCodes: true, constants.RetryNonIdempotent: true,
+	constants.Deadline: true}
+
+// cacheTTLKeys are the keys of the times the cached resources are reused for
+var cacheTTLKeys = map[string]bool{constants.CacheTTLServices: true, constants.CacheTTLServiceOffers: true,
+	constants.CacheTTLProducts: true, constants.CacheTTLPlans: true, constants.CacheTTLTags: true}
+
+// ConfigCheck is the result of one of the checks run by the config validate command
+type ConfigCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Columns returns the table headers of the config checks
+func (c ConfigCheck) Columns() []string {
+	return []string{"NAME", "STATUS", "MESSAGE"}
+}
+
+// Row returns the table row of the config check
+func (c ConfigCheck) Row() []string {
+	return []string{c.Name, c.Status, c.Message}
+}
+
+// Config check statuses
+const (
+	CheckPassed = "OK"
+	CheckFailed = "FAILED"
+)
+
+// Keys returns the configuration keys supported by the config get/set/unset commands
+func Keys() []string {
+	return configKeys
+}
+
+// View returns the configuration file with the plaintext API keys masked
+func View() (*ConfigFile, error) {
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	for name, profile := range cf.Profiles {
+		masked := *profile
+		masked.TrustAuthorityApiKey = MaskApiKey(profile.TrustAuthorityApiKey)
+		cf.Profiles[name] = &masked
+	}
+	return cf, nil
+}
+
+// MarshalJSON prints the durations the way they are set in the configuration file, e.g. 10s, so that the configuration
+// printed by the config view command reads the same in every output format
+func (c Configuration) MarshalJSON() ([]byte, error) {
+	type configuration Configuration
+	return json.Marshal(struct {
+		configuration
+		RetryWaitMin          string `json:"retry-wait-min,omitempty"`
+		RetryWaitMax          string `json:"retry-wait-max,omitempty"`
+		Deadline              string `json:"deadline,omitempty"`
+		CacheTTLServices      string `json:"cache-ttl-services,omitempty"`
+		CacheTTLServiceOffers string `json:"cache-ttl-service-offers,omitempty"`
+		CacheTTLProducts      string `json:"cache-ttl-products,omitempty"`
+		CacheTTLPlans         string `json:"cache-ttl-plans,omitempty"`
+		CacheTTLTags          string `json:"cache-ttl-tags,omitempty"`
+	}{
+		configuration:         configuration(c),
+		RetryWaitMin:          durationSetting(c.RetryWaitMin),
+		RetryWaitMax:          durationSetting(c.RetryWaitMax),
+		Deadline:              durationSetting(c.Deadline),
+		CacheTTLServices:      durationSetting(c.CacheTTLServices),
+		CacheTTLServiceOffers: durationSetting(c.CacheTTLServiceOffers),
+		CacheTTLProducts:      durationSetting(c.CacheTTLProducts),
+		CacheTTLPlans:         durationSetting(c.CacheTTLPlans),
+		CacheTTLTags:          durationSetting(c.CacheTTLTags),
+	})
+}
+
+// GetValue returns the value of a key in the active profile, the API key is masked
+func GetValue(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return "", err
+	}
+	profileName := cf.ActiveProfileName()
+	profile, ok := cf.Profiles[profileName]
+	if !ok {
+		return "", errors.Errorf("Profile %q not found in configuration", profileName)
+	}
+
+	switch key {
+	case constants.TrustAuthBaseUrl:
+		return profile.TrustAuthorityBaseUrl, nil
+	case constants.TrustAuthApiKeyEnvVar:
+		apiKey, err := profile.resolveApiKey(profileName)
+		if err != nil {
+			return "", err
+		}
+		return MaskApiKey(apiKey), nil
+	case constants.Loglevel:
+		return profile.LogLevel, nil
+	case constants.HttpClientTimeout:
+		return strconv.Itoa(profile.HTTPClientTimeout), nil
+	case constants.SecretStore:
+		return secretStoreName(profile.SecretStore), nil
+	case constants.RequestIdPrefix:
+		return profile.RequestIdPrefix, nil
+	case constants.OtlpEndpoint:
+		return profile.OtlpEndpoint, nil
+	case constants.TelemetryFile:
+		return profile.TelemetryFile, nil
+	}
+	if cacheTTLKeys[key] {
+		return durationSetting(*profile.durationSetting(key)), nil
+	}
+	if retryKeys[key] {
+		return profile.retrySetting(key), nil
+	}
+	return *profile.transportSetting(key), nil
+}
+
+// SetValue validates and sets the value of a key in the active profile, the profile is created if it does not
+// exist yet. The API key is written to the secret store of the profile.
+func SetValue(key, value string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	profileName := cf.ActiveProfileName()
+	if err = validateProfileName(profileName); err != nil {
+		return err
+	}
+	profile, ok := cf.Profiles[profileName]
+	if !ok {
+		profile = &Configuration{
+			LogLevel:          constants.DefaultLogLevel,
+			HTTPClientTimeout: constants.DefaultHttpClientTimeout,
+		}
+		cf.Profiles[profileName] = profile
+	}
+
+	switch key {
+	case constants.TrustAuthBaseUrl:
+		if err = validation.ValidateTrustAuthorityUrl(value); err != nil {
+			return err
+		}
+		profile.TrustAuthorityBaseUrl = value
+	case constants.TrustAuthApiKeyEnvVar:
+		if err = validation.ValidateTrustAuthorityAPIKey(value); err != nil {
+			return errors.Wrap(err, "Invalid API Key provided")
+		}
+		if err = profile.storeApiKey(profileName, value); err != nil {
+			return err
+		}
+	case constants.Loglevel:
+		if err = validation.ValidateLogLevel(value); err != nil {
+			return err
+		}
+		profile.LogLevel = strings.ToLower(value)
+	case constants.HttpClientTimeout:
+		timeout, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.Wrap(err, "Invalid HTTP client timeout provided")
+		}
+		if err = validation.ValidateHttpClientTimeout(timeout); err != nil {
+			return err
+		}
+		profile.HTTPClientTimeout = timeout
+	case constants.SecretStore:
+		if err = profile.migrateSecretStore(profileName, value); err != nil {
+			return err
+		}
+	case constants.RequestIdPrefix:
+		if err = validation.ValidateRequestIdPrefix(value); err != nil {
+			return err
+		}
+		profile.RequestIdPrefix = value
+	case constants.OtlpEndpoint:
+		if err = validation.ValidateOtlpEndpoint(value); err != nil {
+			return err
+		}
+		profile.OtlpEndpoint = value
+	case constants.TelemetryFile:
+		profile.TelemetryFile = value
+	default:
+		if cacheTTLKeys[key] {
+			if err = profile.setDurationSetting(key, value); err != nil {
+				return err
+			}
+			break
+		}
+		if retryKeys[key] {
+			err = profile.setRetrySetting(key, value)
+		} else {
+			*profile.transportSetting(key) = value
+		}
+		if err != nil {
+			return err
+		}
+		// building the HTTP client loads the certificates and checks the proxy URL, TLS version and retry policy
+		if err = profile.validateTransport(); err != nil {
+			return err
+		}
+	}
+
+	if cf.CurrentProfile == "" {
+		cf.CurrentProfile = profileName
+	}
+	return cf.Write()
+}
+
+// UnsetValue removes the value of a key from the active profile. The defaults are used for the log level and HTTP
+// client timeout, the API key is deleted from its secret store and unsetting the secret store moves the API key
+// back to the configuration file.
+func UnsetValue(key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	cf, err := ReadConfigFile()
+	if err != nil {
+		return err
+	}
+	profileName := cf.ActiveProfileName()
+	profile, ok := cf.Profiles[profileName]
+	if !ok {
+		return errors.Errorf("Profile %q not found in configuration", profileName)
+	}
+
+	switch key {
+	case constants.TrustAuthBaseUrl:
+		profile.TrustAuthorityBaseUrl = ""
+	case constants.TrustAuthApiKeyEnvVar:
+		if err = profile.deleteApiKey(profileName); err != nil {
+			return err
+		}
+		profile.TrustAuthorityApiKey = ""
+	case constants.Loglevel:
+		profile.LogLevel = ""
+	case constants.HttpClientTimeout:
+		profile.HTTPClientTimeout = 0
+	case constants.SecretStore:
+		if err = profile.migrateSecretStore(profileName, secrets.Plaintext); err != nil {
+			return err
+		}
+	case constants.RequestIdPrefix:
+		profile.RequestIdPrefix = ""
+	case constants.OtlpEndpoint:
+		profile.OtlpEndpoint = ""
+	case constants.TelemetryFile:
+		profile.TelemetryFile = ""
+	default:
+		if cacheTTLKeys[key] {
+			*profile.durationSetting(key) = 0
+		} else if retryKeys[key] {
+			profile.unsetRetrySetting(key)
+		} else {
+			*profile.transportSetting(key) = ""
+		}
+	}
+	return cf.Write()
+}
+
+// Validate checks the values of the configuration and returns the result of each check
+func (c *Configuration) Validate() []ConfigCheck {
+	return []ConfigCheck{
+		NewConfigCheck(constants.TrustAuthBaseUrl, validation.ValidateTrustAuthorityUrl(c.TrustAuthorityBaseUrl)),
+		NewConfigCheck(constants.TrustAuthApiKeyEnvVar, validation.ValidateTrustAuthorityAPIKey(c.TrustAuthorityApiKey)),
+		NewConfigCheck(constants.Loglevel, validation.ValidateLogLevel(c.LogLevel)),
+		NewConfigCheck(constants.HttpClientTimeout, validation.ValidateHttpClientTimeout(c.HTTPClientTimeout)),
+		NewConfigCheck(transportCheckName, c.validateTransport()),
+		NewConfigCheck(constants.RequestIdPrefix, validation.ValidateRequestIdPrefix(c.RequestIdPrefix)),
+		NewConfigCheck(constants.OtlpEndpoint, validation.ValidateOtlpEndpoint(c.OtlpEndpoint)),
+	}
+}
+
+func (c *Configuration) validateTransport() error {
+	_, err := c.NewHTTPClient()
+	return err
+}
+
+// retrySetting returns the retry policy setting of the configuration for the key
+func (c *Configuration) retrySetting(key string) string {
+	switch key {
+	case constants.RetryMax:
+		if c.RetryMax == nil {
+			return ""
+		}
+		return strconv.Itoa(*c.RetryMax)
+	case constants.RetryWaitMin:
+		return durationSetting(c.RetryWaitMin)
+	case constants.RetryWaitMax:
+		return durationSetting(c.RetryWaitMax)
+	case constants.RetryJitter:
+		if c.RetryJitter == nil {
+			return ""
+		}
+		return strconv.FormatBool(*c.RetryJitter)
+	case constants.RetryStatusCodes:
+		return c.RetryStatusCodes
+	case constants.RetryNonIdempotent:
+		return strconv.FormatBool(c.RetryNonIdempotent)
+	default:
+		return durationSetting(c.Deadline)
+	}
+}
+
+// setRetrySetting parses and sets the retry policy setting of the configuration for the key
+func (c *Configuration) setRetrySetting(key, value string) error {
+	switch key {
+	case constants.RetryMax:
+		retryMax, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.Wrap(err, "Invalid retry count provided")
+		}
+		c.RetryMax = &retryMax
+	case constants.RetryJitter:
+		jitter, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.Wrap(err, "Invalid retry jitter provided")
+		}
+		c.RetryJitter = &jitter
+	case constants.RetryNonIdempotent:
+		nonIdempotent, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.Wrap(err, "Invalid non idempotent retry setting provided")
+		}
+		c.RetryNonIdempotent = nonIdempotent
+	case constants.RetryStatusCodes:
+		if _, err := client.ParseStatusCodes(value); err != nil {
+			return err
+		}
+		c.RetryStatusCodes = value
+	default:
+		return c.setDurationSetting(key, value)
+	}
+	return nil
+}
+
+// setDurationSetting parses and sets the duration setting of the configuration for the key
+func (c *Configuration) setDurationSetting(key, value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return errors.Wrapf(err, "Invalid %s provided", key)
+	}
+	if duration < 0 {
+		return errors.Errorf("Invalid %s provided, cannot be negative", key)
+	}
+	*c.durationSetting(key) = duration
+	return nil
+}
+
+func (c *Configuration) unsetRetrySetting(key string) {
+	switch key {
+	case constants.RetryMax:
+		c.RetryMax = nil
+	case constants.RetryJitter:
+		c.RetryJitter = nil
+	case constants.RetryNonIdempotent:
+		c.RetryNonIdempotent = false
+	case constants.RetryStatusCodes:
+		c.RetryStatusCodes = ""
+	default:
+		*c.durationSetting(key) = 0
+	}
+}
+
+func (c *Configuration) durationSetting(key string) *time.Duration {
+	switch key {
+	case constants.RetryWaitMin:
+		return &c.RetryWaitMin
+	case constants.RetryWaitMax:
+		return &c.RetryWaitMax
+	case constants.CacheTTLServices:
+		return &c.CacheTTLServices
+	case constants.CacheTTLServiceOffers:
+		return &c.CacheTTLServiceOffers
+	case constants.CacheTTLProducts:
+		return &c.CacheTTLProducts
+	case constants.CacheTTLPlans:
+		return &c.CacheTTLPlans
+	case constants.CacheTTLTags:
+		return &c.CacheTTLTags
+	default:
+		return &c.Deadline
+	}
+}
+
+func durationSetting(duration time.Duration) string {
+	if duration == 0 {
+		return ""
+	}
+	return duration.String()
+}
+
+// transportSetting returns the TLS or proxy setting of the configuration for the key
+func (c *Configuration) transportSetting(key string) *string {
+	switch key {
+	case constants.CaBundle:
+		return &c.CaBundle
+	case constants.ClientCert:
+		return &c.ClientCert
+	case constants.ClientKey:
+		return &c.ClientKey
+	case constants.Proxy:
+		return &c.Proxy
+	case constants.NoProxy:
+		return &c.NoProxy
+	default:
+		return &c.MinTLSVersion
+	}
+}
+
+// NewConfigCheck returns the result of a check based on the error it returned
+func NewConfigCheck(name string, err error) ConfigCheck {
+	if err != nil {
+		return ConfigCheck{Name: name, Status: CheckFailed, Message: err.Error()}
+	}
+	return ConfigCheck{Name: name, Status: CheckPassed}
+}
+
+// ChecksError returns an error listing the failed checks, if any
+func ChecksError(checks []ConfigCheck) error {
+	var failed []string
+	for _, check := range checks {
+		if check.Status == CheckFailed {
+			failed = append(failed, check.Name)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("Invalid configuration: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// MaskApiKey hides all but the last characters of the API key
+func MaskApiKey(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+	if len(apiKey) <= maskedApiKeyVisibleChars {
+		return strings.Repeat("*", len(apiKey))
+	}
+	return strings.Repeat("*", 8) + apiKey[len(apiKey)-maskedApiKeyVisibleChars:]
+}
+
+func validateKey(key string) error {
+	for _, configKey := range configKeys {
+		if key == configKey {
+			return nil
+		}
+	}
+	return errors.Errorf("Unsupported configuration key %q, should be one of %s", key, strings.Join(configKeys, ", "))
+}
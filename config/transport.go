@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package config
+
+import (
+	"github.com/pkg/errors"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"net/http"
+	"time"
+)
+
+// TransportOptions returns the TLS, proxy and retry settings of the configuration
+func (c *Configuration) TransportOptions() (*client.TransportOptions, error) {
+	retryMax := constants.DefaultRetryCount
+	if c.RetryMax != nil {
+		retryMax = *c.RetryMax
+	}
+	retryJitter := true
+	if c.RetryJitter != nil {
+		retryJitter = *c.RetryJitter
+	}
+	retryWaitMin, retryWaitMax := c.RetryWaitMin, c.RetryWaitMax
+	if retryWaitMin == 0 && retryWaitMax == 0 {
+		retryWaitMin = constants.DefaultRetryWaitMin * time.Second
+		retryWaitMax = constants.DefaultRetryWaitMax * time.Second
+	}
+	statusCodes := c.RetryStatusCodes
+	if statusCodes == "" {
+		statusCodes = constants.DefaultRetryStatusCodes
+	}
+	retryStatusCodes, err := client.ParseStatusCodes(statusCodes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid retry status codes")
+	}
+
+	return &client.TransportOptions{
+		Timeout:  time.Duration(c.HTTPClientTimeout) * time.Second,
+		Deadline: c.Deadline,
+		Retry: client.RetryOptions{
+			Max:           retryMax,
+			WaitMin:       retryWaitMin,
+			WaitMax:       retryWaitMax,
+			Jitter:        retryJitter,
+			StatusCodes:   retryStatusCodes,
+			NonIdempotent: c.RetryNonIdempotent,
+		},
+		CaBundle:      c.CaBundle,
+		ClientCert:    c.ClientCert,
+		ClientKey:     c.ClientKey,
+		Proxy:         c.Proxy,
+		NoProxy:       c.NoProxy,
+		MinTLSVersion: c.MinTLSVersion,
+	}, nil
+}
+
+// NewHTTPClient returns the HTTP client used to reach Trust Authority with the TLS, proxy and retry settings of the
+// configuration
+func (c *Configuration) NewHTTPClient() (*http.Client, error) {
+	opts, err := c.TransportOptions()
+	if err != nil {
+		return nil, err
+	}
+	return client.NewHTTPClient(opts)
+}
@@ -17,9 +17,14 @@ const (
 	ConfigFileName        = "config"
 	ConfigFileExtension   = "yaml"
 	LogFilePath           = LogDir + "trustauthorityctl.log"
+	SecretsDir            = ConfigDir + "secrets/"
+	CacheDir              = ConfigDir + "cache/"
+	SecretFileExtension   = ".age"
 	DefaultFilePermission = 0640
 	MaxPolicyFileSize     = 10240
-	ExplicitCLIName       = "Intel Trust Authority CLI"
+	// MaxRequestIdPrefixLength leaves room for the UUID appended to the prefix in a request ID of 128 characters
+	MaxRequestIdPrefixLength = 92
+	ExplicitCLIName          = "Intel Trust Authority CLI"
 )
 
 // Command and parameter names
@@ -50,15 +55,50 @@ const (
 	EnvFileParamName             = "env-file"
 	AlgorithmParamName           = "algorithm"
 	DisableNotificationParamName = "disable-notification"
+	OutputParamName              = "output"
+	ProfileParamName             = "profile"
+	UrlParamName                 = "url"
+	SecretStoreParamName         = "secret-store"
+	CheckConnectivityParamName   = "check-connectivity"
+	ApiKeyFileParamName          = "api-key-file"
+	TimeoutParamName             = "timeout"
+	PortParamName                = "port"
+	HostParamName                = "host"
+	SeedFileParamName            = "seed-file"
+	FilenameParamName            = "filename"
+	SplitPoliciesParamName       = "split-policies"
+	SkipExistingParamName        = "skip-existing"
+	OverwriteParamName           = "overwrite"
+	NoCacheParamName             = "no-cache"
+	RefreshParamName             = "refresh"
+	OfflineParamName             = "offline"
+	AllParamName                 = "all"
 
-	RootCmd        = "trustauthorityctl"
-	CreateCmd      = "create"
-	ListCmd        = "list"
-	DeleteCmd      = "delete"
-	UpdateCmd      = "update"
-	UninstallCmd   = "uninstall"
-	VersionCmd     = "version"
-	SetupConfigCmd = "config"
+	RootCmd           = "trustauthorityctl"
+	CreateCmd         = "create"
+	ListCmd           = "list"
+	DeleteCmd         = "delete"
+	UpdateCmd         = "update"
+	UninstallCmd      = "uninstall"
+	VersionCmd        = "version"
+	SetupConfigCmd    = "config"
+	UseContextCmd     = "use-context"
+	GetContextsCmd    = "get-contexts"
+	SetContextCmd     = "set-context"
+	DeleteContextCmd  = "delete-context"
+	SetSecretStoreCmd = "set-secret-store"
+	ViewCmd           = "view"
+	GetCmd            = "get"
+	SetCmd            = "set"
+	UnsetCmd          = "unset"
+	ValidateCmd       = "validate"
+	ApplyCmd          = "apply"
+	DiffCmd           = "diff"
+	ExportCmd         = "export"
+	ImportCmd         = "import"
+	CompletionCmd     = "completion"
+	CacheCmd          = "cache"
+	ClearCmd          = "clear"
 )
 
 // Resource names
@@ -74,24 +114,81 @@ const (
 	TagCmd            = "tag"
 	RoleCmd           = "role"
 	TenantSettingsCmd = "tenant-settings"
+	MockServerCmd     = "mock-server"
 )
 
 const (
-	TrustAuthBaseUrl      = "trustauthority-url"
-	TrustAuthApiKeyEnvVar = "trustauthority-api-key"
-	HttpClientTimeout     = "http-client-timeout"
-	Loglevel              = "log-level"
+	TrustAuthBaseUrl        = "trustauthority-url"
+	TrustAuthApiKeyEnvVar   = "trustauthority-api-key"
+	HttpClientTimeout       = "http-client-timeout"
+	Loglevel                = "log-level"
+	EnvVarPrefix            = "TRUSTAUTHORITY_"
+	ProfileEnvVar           = "TRUSTAUTHORITY_PROFILE"
+	UrlEnvVar               = "TRUSTAUTHORITY_URL"
+	ApiKeyEnvVar            = "TRUSTAUTHORITY_API_KEY"
+	LogLevelEnvVar          = "TRUSTAUTHORITY_LOG_LEVEL"
+	HttpClientTimeoutEnvVar = "TRUSTAUTHORITY_HTTP_CLIENT_TIMEOUT"
+	LegacyLogLevelEnvVar    = "LOG_LEVEL"           // read when TRUSTAUTHORITY_LOG_LEVEL is not set
+	LegacyTimeoutEnvVar     = "HTTP_CLIENT_TIMEOUT" // read when TRUSTAUTHORITY_HTTP_CLIENT_TIMEOUT is not set
+	SecretStore             = "secret-store"
+	CaBundle                = "ca-bundle"
+	ClientCert              = "client-cert"
+	ClientKey               = "client-key"
+	Proxy                   = "proxy"
+	NoProxy                 = "no-proxy"
+	MinTLSVersion           = "min-tls-version"
+	RetryMax                = "retry-max"
+	RetryWaitMin            = "retry-wait-min"
+	RetryWaitMax            = "retry-wait-max"
+	RetryJitter             = "retry-jitter"
+	RetryStatusCodes        = "retry-status-codes"
+	RetryNonIdempotent      = "retry-non-idempotent"
+	Deadline                = "deadline"
+	RequestIdPrefix         = "request-id-prefix"
+	OtlpEndpoint            = "otlp-endpoint"
+	TelemetryFile           = "telemetry-file"
+	CacheTTLServices        = "cache-ttl-services"
+	CacheTTLServiceOffers   = "cache-ttl-service-offers"
+	CacheTTLProducts        = "cache-ttl-products"
+	CacheTTLPlans           = "cache-ttl-plans"
+	CacheTTLTags            = "cache-ttl-tags"
+	DebugHttp               = "debug-http"
+	DebugHttpLevel          = "v"
+	DebugHttpOutput         = "debug-http-output"
+	Record                  = "record"
+	Replay                  = "replay"
+	CaBundleEnvVar          = "TRUSTAUTHORITY_CA_BUNDLE"
+	ClientCertEnvVar        = "TRUSTAUTHORITY_CLIENT_CERT"
+	ClientKeyEnvVar         = "TRUSTAUTHORITY_CLIENT_KEY"
+	ProxyEnvVar             = "TRUSTAUTHORITY_PROXY"
+	NoProxyEnvVar           = "TRUSTAUTHORITY_NO_PROXY"
+	MinTLSVersionEnvVar     = "TRUSTAUTHORITY_MIN_TLS_VERSION"
+	SecretPassphraseEnvVar  = "TRUSTAUTHORITY_SECRET_PASSPHRASE"
+	RequestIdPrefixEnvVar   = "TRUSTAUTHORITY_REQUEST_ID_PREFIX"
+	TraceParentEnvVar       = "TRACEPARENT"
+	OtelOtlpEndpointEnvVar  = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	SecretServiceName       = "trustauthorityctl"
+	SecretServiceProbeUser  = "trustauthorityctl-probe"
+	DefaultProfileName      = "default"
 
 	DefaultLogLevel          = "info"
 	DefaultHttpClientTimeout = 10
 	DefaultRetryWaitMin      = 2  //minimum time to wait before retry
 	DefaultRetryWaitMax      = 10 //maximum time to wait before retry
 	DefaultRetryCount        = 2  // number of retries
+	DefaultRetryStatusCodes  = "429,500,503,504"
 	ApiClientStatusActive    = "Active"
 	ApiClientStatusInactive  = "Inactive"
 	ApiClientStatusCancelled = "Cancelled"
 	TenantAdminRole          = "Tenant Admin"
 	UserRole                 = "User"
+	DefaultMockServerHost    = "localhost"
+	DefaultMockServerPort    = 8080
+	CompletionTimeout        = 3  // time allowed to list the completions of a flag, in seconds
+	CompletionCacheTTL       = 60 // time the completions of a flag are reused for, in seconds
+	DefaultCatalogCacheTTL   = 24 // time the service offers, products and plans are cached for, in hours
+	DefaultServicesCacheTTL  = 60 // time the services of the tenant are cached for, in minutes
+	DefaultTagsCacheTTL      = 10 // time the tags of the tenant are cached for, in minutes
 
 	PS384       = "PS384"
 	RS256       = "RS256"
@@ -114,6 +211,33 @@ const (
 	HTTPHeaderKeyApiKey      = "x-api-key"
 	HTTPHeaderKeyRequestId   = "request-id"
 	HTTPHeaderKeyTraceId     = "trace-id"
+	HTTPHeaderKeyRetryAfter  = "Retry-After"
+	HTTPHeaderKeyTraceParent = "traceparent"
+	OtlpTracesPath           = "/v1/traces"
+	OtlpMetricsPath          = "/v1/metrics"
+	// ReplayBaseUrl is the base URL used when replaying a cassette without a Trust Authority URL configured, the
+	// replayed requests do not reach the network
+	ReplayBaseUrl = "https://replay.trustauthority.invalid"
+)
+
+// Destinations of the HTTP debug logs
+const (
+	DebugHttpOutputStderr = "stderr"
+	DebugHttpOutputLog    = "log"
+)
+
+// Exit codes of the CLI, documented in the README
+const (
+	ExitCodeOK        = 0
+	ExitCodeError     = 1
+	ExitCodeUsage     = 2
+	ExitCodeAuth      = 3
+	ExitCodeNotFound  = 4
+	ExitCodeConflict  = 5
+	ExitCodeNetwork   = 6
+	ExitCodeServer    = 7
+	ExitCodeDrift     = 8
+	ExitCodeCancelled = 130
 )
 
 // API endpoint
@@ -0,0 +1,256 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"intel/tac/v1/models"
+	"intel/tac/v1/sdk"
+)
+
+// Results of applying a change
+const (
+	ResultCreated   = "created"
+	ResultUpdated   = "updated"
+	ResultUnchanged = "unchanged"
+	ResultFailed    = "failed"
+)
+
+// Result is the outcome of applying the change of a resource
+type Result struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Result string `json:"result"`
+	Id     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Columns returns the table headers of the results
+func (r Result) Columns() []string {
+	return []string{"KIND", "NAME", "RESULT", "ID", "ERROR"}
+}
+
+// Row returns the table row of the result
+func (r Result) Row() []string {
+	return []string{r.Kind, r.Name, r.Result, r.Id, r.Error}
+}
+
+// Apply applies the changes returned by Plan and reports a result per resource. A failed change does not stop the
+// others, the resources created are added to the state so that the following changes can reference them. The
+// deletions are skipped, the resources missing from the manifest are left untouched.
+func Apply(ctx context.Context, c *sdk.Client, s *State, changes []Change, opts ...sdk.CallOption) []Result {
+	results := make([]Result, 0, len(changes))
+	for _, change := range changes {
+		if change.Action == ActionDelete {
+			continue
+		}
+		result := Result{Kind: change.Kind, Name: change.Name, Result: ResultUnchanged}
+		var id uuid.UUID
+		var err error
+		if change.Action != ActionUnchanged {
+			switch change.Kind {
+			case KindTag:
+				id, err = applyTag(ctx, c, s, change, opts)
+			case KindPolicy:
+				id, err = applyPolicy(ctx, c, s, change, opts)
+			case KindUser:
+				id, err = applyUser(ctx, c, s, change, opts)
+			case KindTenantSettings:
+				err = applyTenantSettings(ctx, c, s, change, opts)
+			case KindApiClient:
+				id, err = applyApiClient(ctx, c, s, change, opts)
+			default:
+				err = errors.Errorf("Unknown kind %q", change.Kind)
+			}
+			result.Result = ResultCreated
+			if change.Action == ActionUpdate {
+				result.Result = ResultUpdated
+			}
+		} else {
+			id = s.id(change)
+		}
+		if err != nil {
+			result.Result = ResultFailed
+			result.Error = err.Error()
+		}
+		if id != uuid.Nil {
+			result.Id = id.String()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// id returns the ID of the resource of an unchanged change
+func (s *State) id(change Change) uuid.UUID {
+	switch change.Kind {
+	case KindTag:
+		if tag := s.tag(change.Name); tag != nil && tag.ID != nil {
+			return *tag.ID
+		}
+	case KindPolicy:
+		if policy := s.policy(change.Name); policy != nil {
+			return policy.PolicyId
+		}
+	case KindUser:
+		if user := s.user(change.Name); user != nil {
+			return user.ID
+		}
+	case KindApiClient:
+		desired := change.Desired.(*ApiClient)
+		if service, err := s.service(desired.Service); err == nil {
+			if apiClient := s.apiClient(service.ID, desired.Name); apiClient != nil {
+				return apiClient.ID
+			}
+		}
+	}
+	return uuid.Nil
+}
+
+func applyTag(ctx context.Context, c *sdk.Client, s *State, change Change, opts []sdk.CallOption) (uuid.UUID, error) {
+	tag, err := c.CreateTag(ctx, &models.TagCreate{Name: change.Name}, opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	s.Tags = append(s.Tags, *tag)
+	if tag.ID == nil {
+		return uuid.Nil, nil
+	}
+	return *tag.ID, nil
+}
+
+func applyPolicy(ctx context.Context, c *sdk.Client, s *State, change Change, opts []sdk.CallOption) (uuid.UUID, error) {
+	desired := change.Desired.(*Policy)
+	serviceOffer, err := s.serviceOffer(desired.ServiceOffer)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if change.Action == ActionUpdate {
+		current := change.Current.(*Policy)
+		policy := s.policy(desired.Name)
+		if current.ServiceOffer != desired.ServiceOffer || current.PolicyType != desired.PolicyType ||
+			current.AttestationType != desired.AttestationType {
+			return policy.PolicyId, errors.Errorf("The service offer, policy type and attestation type of policy %q "+
+				"cannot be updated, delete the policy first", desired.Name)
+		}
+		updated, err := c.UpdatePolicy(ctx, &models.PolicyUpdateRequest{
+			PolicyId:   policy.PolicyId,
+			Policy:     desired.Policy,
+			PolicyName: desired.Name,
+		}, opts...)
+		if err != nil {
+			return policy.PolicyId, err
+		}
+		*policy = *updated
+		return policy.PolicyId, nil
+	}
+
+	policy, err := c.CreatePolicy(ctx, &models.PolicyRequest{CommonPolicy: models.CommonPolicy{
+		Policy:          desired.Policy,
+		PolicyName:      desired.Name,
+		PolicyType:      desired.PolicyType,
+		ServiceOfferId:  serviceOffer.ID,
+		AttestationType: desired.AttestationType,
+	}}, opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	s.Policies = append(s.Policies, *policy)
+	return policy.PolicyId, nil
+}
+
+func applyUser(ctx context.Context, c *sdk.Client, s *State, change Change, opts []sdk.CallOption) (uuid.UUID, error) {
+	desired := change.Desired.(*User)
+	if change.Action == ActionUpdate {
+		user := s.user(desired.Email)
+		updated, err := c.UpdateUserRole(ctx, &models.UpdateTenantUserRoles{UserId: user.ID, Role: desired.Role}, opts...)
+		if err != nil {
+			return user.ID, err
+		}
+		*user = *updated
+		return user.ID, nil
+	}
+
+	user, err := c.CreateUser(ctx, &models.CreateTenantUser{Email: desired.Email, Role: desired.Role}, opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	s.Users = append(s.Users, *user)
+	return user.ID, nil
+}
+
+func applyTenantSettings(ctx context.Context, c *sdk.Client, s *State, change Change, opts []sdk.CallOption) error {
+	desired := change.Desired.(*TenantSettings)
+	settings, err := c.UpdateTenantSettings(ctx, &models.AttestationFailureEmail{
+		AttestationFailureEmail: desired.AttestationFailureEmail,
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	s.TenantSettings = settings
+	return nil
+}
+
+func applyApiClient(ctx context.Context, c *sdk.Client, s *State, change Change, opts []sdk.CallOption) (uuid.UUID, error) {
+	desired := change.Desired.(*ApiClient)
+	service, err := s.service(desired.Service)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	product, err := s.product(service.ServiceOfferId, desired.Product)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	policyIds := []uuid.UUID{}
+	for _, name := range desired.Policies {
+		policy := s.policy(name)
+		if policy == nil {
+			return uuid.Nil, errors.Errorf("Policy %q not found", name)
+		}
+		policyIds = append(policyIds, policy.PolicyId)
+	}
+	tags := []models.ApiClientTagIdValue{}
+	for _, tag := range desired.Tags {
+		if s.tag(tag.Key) == nil {
+			return uuid.Nil, errors.Errorf("Tag %q not found", tag.Key)
+		}
+		tags = append(tags, models.ApiClientTagIdValue{Key: tag.Key, Value: tag.Value})
+	}
+	status := models.ApiClientStatus(desired.Status)
+
+	if change.Action == ActionUpdate {
+		apiClient := s.apiClient(service.ID, desired.Name)
+		_, err = c.UpdateApiClient(ctx, apiClient.ID, &models.UpdateApiClient{
+			ServiceId:    service.ID,
+			ProductId:    product.ID,
+			Name:         &desired.Name,
+			PolicyIds:    policyIds,
+			TagIdsValues: tags,
+			Status:       &status,
+		}, opts...)
+		if err != nil {
+			return apiClient.ID, err
+		}
+		return apiClient.ID, nil
+	}
+
+	apiClient, err := c.CreateApiClient(ctx, &models.CreateApiClient{
+		ServiceId:    service.ID,
+		ProductId:    product.ID,
+		Name:         desired.Name,
+		PolicyIds:    policyIds,
+		TagIdsValues: tags,
+		Status:       status,
+	}, opts...)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	s.ApiClients = append(s.ApiClients, *apiClient)
+	return apiClient.ID, nil
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines printed around the changed ones
+const diffContext = 3
+
+// Diff writes the changes as a unified diff between the current resources of the tenant and the ones of the manifest,
+// and tells whether there is any change. The unchanged resources are left out.
+func Diff(w io.Writer, changes []Change) (bool, error) {
+	drift := false
+	for _, change := range changes {
+		if change.Action == ActionUnchanged {
+			continue
+		}
+		drift = true
+
+		var current, desired []string
+		var err error
+		fromName, toName := "/dev/null", "/dev/null"
+		if change.Current != nil {
+			if current, err = encodeYAML(newResource(change.Kind, change.Name, "", change.Current)); err != nil {
+				return drift, err
+			}
+			fromName = "tenant/" + change.Kind + "/" + change.Name
+		}
+		if change.Desired != nil {
+			if desired, err = encodeYAML(newResource(change.Kind, change.Name, "", change.Desired)); err != nil {
+				return drift, err
+			}
+			toName = "manifest/" + change.Kind + "/" + change.Name
+		}
+		if err = writeUnifiedDiff(w, fromName, toName, trimLines(current), trimLines(desired)); err != nil {
+			return drift, err
+		}
+	}
+	return drift, nil
+}
+
+// trimLines drops the empty line following the last line break
+func trimLines(lines []string) []string {
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// edit is a line of a diff, op is ' ' for a line common to both sides, '-' for a removed line and '+' for an added one
+type edit struct {
+	op   byte
+	line string
+}
+
+// diffLines computes the edits turning a into b from their longest common subsequence
+func diffLines(a, b []string) []edit {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{op: ' ', line: a[i]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{op: '-', line: a[i]})
+			i++
+		default:
+			edits = append(edits, edit{op: '+', line: b[j]})
+			j++
+		}
+	}
+	return edits
+}
+
+// writeUnifiedDiff writes the differences between a and b in the unified format, with diffContext lines of context
+// around each hunk
+func writeUnifiedDiff(w io.Writer, fromName, toName string, a, b []string) error {
+	edits := diffLines(a, b)
+	if _, err := fmt.Fprintf(w, "--- %s\n+++ %s\n", fromName, toName); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(edits); {
+		for i < len(edits) && edits[i].op == ' ' {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		// the hunk extends over the following changes separated by less than twice the context
+		end := i
+		for {
+			for end < len(edits) && edits[end].op != ' ' {
+				end++
+			}
+			next := end
+			for next < len(edits) && edits[next].op == ' ' {
+				next++
+			}
+			if next < len(edits) && next-end <= 2*diffContext {
+				end = next
+				continue
+			}
+			end += diffContext
+			if end > len(edits) {
+				end = len(edits)
+			}
+			break
+		}
+		if err := writeHunk(w, edits, start, end); err != nil {
+			return err
+		}
+		i = end
+	}
+	return nil
+}
+
+func writeHunk(w io.Writer, edits []edit, start, end int) error {
+	fromLine, toLine := 0, 0
+	for _, e := range edits[:start] {
+		if e.op != '+' {
+			fromLine++
+		}
+		if e.op != '-' {
+			toLine++
+		}
+	}
+	fromCount, toCount := 0, 0
+	var buf strings.Builder
+	for _, e := range edits[start:end] {
+		if e.op != '+' {
+			fromCount++
+		}
+		if e.op != '-' {
+			toCount++
+		}
+		buf.WriteByte(e.op)
+		buf.WriteString(e.line)
+		if !strings.HasSuffix(e.line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+	// the line numbers start at 1, a side without lines starts at 0
+	if fromCount > 0 {
+		fromLine++
+	}
+	if toCount > 0 {
+		toLine++
+	}
+	_, err := fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n%s", fromLine, fromCount, toLine, toCount, buf.String())
+	return err
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWriteUnifiedDiff(t *testing.T) {
+	lines := func(s string) []string {
+		return trimLines(strings.SplitAfter(s, "\n"))
+	}
+
+	tt := []struct {
+		from        string
+		to          string
+		want        string
+		description string
+	}{
+		{
+			from:        "",
+			to:          "a\nb\n",
+			want:        "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+			description: "Test a created file",
+		},
+		{
+			from:        "a\nb\n",
+			to:          "",
+			want:        "--- from\n+++ to\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+			description: "Test a deleted file",
+		},
+		{
+			from:        "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:          "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\neleven\n12\n",
+			want:        "--- from\n+++ to\n@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+three\n 4\n 5\n 6\n@@ -9,4 +9,5 @@\n 9\n 10\n 11\n+eleven\n 12\n",
+			description: "Test changes far apart printed as separate hunks",
+		},
+		{
+			from:        "1\n2\n3\n4\n5\n",
+			to:          "1\ntwo\n3\nfour\n5\n",
+			want:        "--- from\n+++ to\n@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n-4\n+four\n 5\n",
+			description: "Test close changes merged in a single hunk",
+		},
+	}
+
+	for _, tc := range tt {
+		var buf bytes.Buffer
+		assert.NoError(t, writeUnifiedDiff(&buf, "from", "to", lines(tc.from), lines(tc.to)), tc.description)
+		assert.Equal(t, tc.want, buf.String(), tc.description)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	taClient := newTestClient(t)
+	apply(t, taClient, writeManifest(t, tenantManifest))
+
+	m, err := Load(writeManifest(t, tenantManifest))
+	assert.NoError(t, err)
+	s, err := FetchState(context.Background(), taClient)
+	assert.NoError(t, err)
+	changes, err := Plan(m, s)
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	drift, err := Diff(&buf, changes)
+	assert.NoError(t, err)
+	assert.False(t, drift, "Test a manifest matching the tenant")
+	assert.Empty(t, buf.String())
+
+	m.Policies[0].Policy = "default matches_sgx_policy = false\n\nmatches_sgx_policy {\n  input.sgx_is_debuggable == false\n}\n"
+	m.Users = nil
+	m.Tags = []Tag{{Name: "Region"}}
+	m.ApiClients[0].Tags = m.ApiClients[0].Tags[:1]
+	changes, err = Plan(m, s)
+	assert.NoError(t, err)
+	buf.Reset()
+	drift, err = Diff(&buf, changes)
+	assert.NoError(t, err)
+	assert.True(t, drift, "Test a manifest differing from the tenant")
+	output := buf.String()
+	assert.Contains(t, output, "--- /dev/null\n+++ manifest/Tag/Region\n")
+	assert.Contains(t, output, "--- tenant/Tag/Power\n+++ /dev/null\n", "Test a tag missing from the manifest")
+	assert.Contains(t, output, "+    matches_sgx_policy {\n", "Test the rego policy diffed line by line")
+	assert.Contains(t, output, "-    - key: Workload\n-      value: WorkloadAI\n")
+	assert.NotContains(t, output, "admin@example.com", "Test the users left out of the manifest")
+}
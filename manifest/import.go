@@ -0,0 +1,135 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"intel/tac/v1/sdk"
+)
+
+// ResultSkipped is the result of a resource of an import which already exists in the tenant
+const ResultSkipped = "skipped"
+
+// ImportResult is the outcome of importing a resource, along with the ID the resource had in the tenant it was
+// exported from
+type ImportResult struct {
+	Result
+	SourceId string `json:"source_id,omitempty"`
+}
+
+// Columns returns the table headers of the import results
+func (r ImportResult) Columns() []string {
+	return []string{"KIND", "NAME", "RESULT", "SOURCE ID", "ID", "ERROR"}
+}
+
+// Row returns the table row of the import result
+func (r ImportResult) Row() []string {
+	return []string{r.Kind, r.Name, r.Result.Result, r.SourceId, r.Id, r.Error}
+}
+
+// ImportOptions tells how the resources of an import which already exist in the tenant are handled, by default they
+// fail to import. The tenant settings exist in every tenant, they are updated unless SkipExisting is set.
+type ImportOptions struct {
+	// SkipExisting leaves the existing resources untouched
+	SkipExisting bool
+	// Overwrite updates the existing resources, like apply does
+	Overwrite bool
+}
+
+// Import recreates the resources of an exported manifest in the tenant and reports the ID each resource had in the
+// source tenant along with its ID in the tenant. The api clients of the manifest may reference the policies and tags
+// of the manifest by their source IDs, which are remapped to the resources created.
+func Import(ctx context.Context, c *sdk.Client, s *State, m *Manifest, options ImportOptions, opts ...sdk.CallOption) ([]ImportResult, error) {
+	changes, err := Plan(m.remap(), s)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ImportResult, 0, len(changes))
+	for _, change := range changes {
+		if change.Action == ActionDelete {
+			continue
+		}
+		result := ImportResult{SourceId: m.sourceId(change)}
+		exists := change.Action != ActionCreate
+		switch {
+		case !exists, options.Overwrite, change.Kind == KindTenantSettings && !options.SkipExisting:
+			result.Result = Apply(ctx, c, s, []Change{change}, opts...)[0]
+		case options.SkipExisting:
+			result.Result = Result{Kind: change.Kind, Name: change.Name, Result: ResultSkipped}
+		default:
+			result.Result = Result{Kind: change.Kind, Name: change.Name, Result: ResultFailed,
+				Error: change.Kind + " already exists, use --skip-existing or --overwrite"}
+		}
+		if id := s.id(change); result.Id == "" && id != uuid.Nil {
+			result.Id = id.String()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// remap replaces the source IDs of the policies and tags referenced by the api clients with their names
+func (m *Manifest) remap() *Manifest {
+	remapped := *m
+	remapped.ApiClients = nil
+	for _, apiClient := range m.ApiClients {
+		policies := make([]string, 0, len(apiClient.Policies))
+		for _, ref := range apiClient.Policies {
+			for _, policy := range m.Policies {
+				if policy.Id != "" && policy.Id == ref {
+					ref = policy.Name
+				}
+			}
+			policies = append(policies, ref)
+		}
+		tags := make([]TagValue, 0, len(apiClient.Tags))
+		for _, tag := range apiClient.Tags {
+			for _, other := range m.Tags {
+				if other.Id != "" && other.Id == tag.Key {
+					tag.Key = other.Name
+				}
+			}
+			tags = append(tags, tag)
+		}
+		apiClient.Policies, apiClient.Tags = policies, tags
+		remapped.ApiClients = append(remapped.ApiClients, apiClient)
+	}
+	return &remapped
+}
+
+// sourceId returns the ID the resource of a change had in the tenant the manifest was exported from
+func (m *Manifest) sourceId(change Change) string {
+	switch change.Kind {
+	case KindTag:
+		for _, tag := range m.Tags {
+			if tag.Name == change.Name {
+				return tag.Id
+			}
+		}
+	case KindPolicy:
+		for _, policy := range m.Policies {
+			if policy.Name == change.Name {
+				return policy.Id
+			}
+		}
+	case KindUser:
+		for _, user := range m.Users {
+			if user.Email == change.Name {
+				return user.Id
+			}
+		}
+	case KindApiClient:
+		desired := change.Desired.(*ApiClient)
+		for _, apiClient := range m.ApiClients {
+			if apiClient.Name == desired.Name && apiClient.Id != "" {
+				return apiClient.Id
+			}
+		}
+	}
+	return ""
+}
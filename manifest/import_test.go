@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	source := newTestClient(t)
+	apply(t, source, writeManifest(t, tenantManifest))
+	s, err := FetchState(context.Background(), source)
+	assert.NoError(t, err)
+	bundle := FromState(s)
+	// reference the policy and the tag by their IDs in the source tenant
+	bundle.ApiClients[0].Policies = []string{bundle.Policies[0].Id}
+	bundle.ApiClients[0].Tags[0].Key = bundle.Tags[0].Id
+
+	target := newTestClient(t)
+	s, err = FetchState(context.Background(), target)
+	assert.NoError(t, err)
+	results, err := Import(context.Background(), target, s, bundle, ImportOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 5)
+	for _, result := range results {
+		assert.Empty(t, result.Error, result.Name)
+		if result.Kind != KindTenantSettings {
+			assert.Equal(t, ResultCreated, result.Result.Result, result.Name)
+			assert.NotEmpty(t, result.SourceId, result.Name)
+			assert.NotEmpty(t, result.Id, result.Name)
+		}
+	}
+
+	s, err = FetchState(context.Background(), target)
+	assert.NoError(t, err)
+	imported := FromState(s)
+	assert.Len(t, s.ApiClients, 1)
+	assert.Equal(t, imported.Policies[0].Id, s.ApiClients[0].PolicyIds[0].String(), "Test remapping the policy ID")
+	assert.Equal(t, []TagValue{{Key: "Power", Value: "high"}, {Key: "Workload", Value: "WorkloadAI"}},
+		imported.ApiClients[0].Tags, "Test remapping the tag ID")
+
+	tests := []struct {
+		name     string
+		options  ImportOptions
+		expected string
+	}{
+		{"Test importing existing resources", ImportOptions{}, ResultFailed},
+		{"Test skipping existing resources", ImportOptions{SkipExisting: true}, ResultSkipped},
+		{"Test overwriting existing resources", ImportOptions{Overwrite: true}, ResultUnchanged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := FetchState(context.Background(), target)
+			assert.NoError(t, err)
+			results, err := Import(context.Background(), target, s, bundle, tt.options)
+			assert.NoError(t, err)
+			for _, result := range results {
+				expected := tt.expected
+				if result.Kind == KindTenantSettings && !tt.options.SkipExisting {
+					expected = ResultUnchanged
+				}
+				assert.Equal(t, expected, result.Result.Result, result.Name)
+				if result.Kind != KindTenantSettings {
+					assert.NotEmpty(t, result.Id, result.Name)
+				}
+			}
+		})
+	}
+}
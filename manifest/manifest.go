@@ -0,0 +1,250 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// Package manifest reads the declarative configuration of a tenant from kubectl style YAML manifests and applies it
+// to Trust Authority. The resources of a manifest reference each other by name, e.g. an api client lists the names of
+// its policies, so that the same manifest can be applied to any tenant.
+package manifest
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"intel/tac/v1/validation"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Kinds of the resources of a manifest
+const (
+	KindTag            = "Tag"
+	KindPolicy         = "Policy"
+	KindUser           = "User"
+	KindTenantSettings = "TenantSettings"
+	KindApiClient      = "ApiClient"
+	// KindList is a document holding a list of resources in its items, e.g. a manifest written as a single JSON document
+	KindList = "List"
+)
+
+// Manifest is the configuration of a tenant
+type Manifest struct {
+	Tags           []Tag
+	Policies       []Policy
+	Users          []User
+	TenantSettings *TenantSettings
+	ApiClients     []ApiClient
+}
+
+// Tag is a tag of the tenant, it has no spec
+type Tag struct {
+	Name string `yaml:"-" json:"-"`
+	Id   string `yaml:"-" json:"-"`
+}
+
+// Policy is a rego policy, its rego text is either inlined or read from a file relative to the manifest
+type Policy struct {
+	Name            string `yaml:"-" json:"-"`
+	Id              string `yaml:"-" json:"-"`
+	ServiceOffer    string `yaml:"service_offer" json:"service_offer"`
+	PolicyType      string `yaml:"policy_type" json:"policy_type"`
+	AttestationType string `yaml:"attestation_type" json:"attestation_type"`
+	Policy          string `yaml:"policy,omitempty" json:"policy,omitempty"`
+	PolicyFile      string `yaml:"policy_file,omitempty" json:"policy_file,omitempty"`
+}
+
+// User is a user of the tenant, named by its email
+type User struct {
+	Email string `yaml:"-" json:"-"`
+	Id    string `yaml:"-" json:"-"`
+	Role  string `yaml:"role" json:"role"`
+}
+
+// TenantSettings holds the settings of the tenant, a manifest has at most one
+type TenantSettings struct {
+	AttestationFailureEmail string `yaml:"attest_failure_email" json:"attest_failure_email"`
+}
+
+// ApiClient is an api client of a service, it references its service, product and policies by name or ID and its
+// tags by name
+type ApiClient struct {
+	Name     string     `yaml:"-" json:"-"`
+	Id       string     `yaml:"-" json:"-"`
+	Service  string     `yaml:"service" json:"service"`
+	Product  string     `yaml:"product" json:"product"`
+	Status   string     `yaml:"status,omitempty" json:"status,omitempty"`
+	Policies []string   `yaml:"policies,omitempty" json:"policies,omitempty"`
+	Tags     []TagValue `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// TagValue is the value of a tag of an api client
+type TagValue struct {
+	Key   string `yaml:"key" json:"key"`
+	Value string `yaml:"value" json:"value"`
+}
+
+// document is a YAML document of a manifest
+type document struct {
+	Kind     string      `yaml:"kind"`
+	Metadata metadata    `yaml:"metadata"`
+	Spec     yaml.Node   `yaml:"spec"`
+	Items    []yaml.Node `yaml:"items"`
+}
+
+// metadata names a resource, the ID is the one of the tenant the resource was exported from
+type metadata struct {
+	Name string `yaml:"name" json:"name"`
+	Id   string `yaml:"id,omitempty" json:"id,omitempty"`
+}
+
+// Load reads a manifest file holding one resource per YAML document, JSON documents are accepted as well. The policy
+// files are read relative to the directory of the manifest.
+func Load(path string) (*Manifest, error) {
+	cleanPath, err := validation.ValidatePath(path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return nil, validation.NewInputError(errors.Wrap(err, "Failed to read manifest"))
+	}
+	return Parse(content, filepath.Dir(cleanPath))
+}
+
+// Parse parses the content of a manifest, the policy files are read relative to baseDir
+func Parse(content []byte, baseDir string) (*Manifest, error) {
+	m := &Manifest{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for i := 1; ; i++ {
+		var doc document
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, validation.NewInputError(errors.Wrapf(err, "Invalid manifest document %d", i))
+		}
+		if err = m.add(&doc, baseDir); err != nil {
+			return nil, validation.NewInputError(errors.Wrapf(err, "Invalid manifest document %d", i))
+		}
+	}
+	return m, nil
+}
+
+func (m *Manifest) add(doc *document, baseDir string) error {
+	name, id := doc.Metadata.Name, doc.Metadata.Id
+	if doc.Kind == KindList {
+		for _, item := range doc.Items {
+			var itemDoc document
+			if err := item.Decode(&itemDoc); err != nil {
+				return err
+			}
+			if err := m.add(&itemDoc, baseDir); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if doc.Kind == "" {
+		// empty documents, e.g. after a trailing ---, are ignored
+		if name == "" && doc.Spec.Kind == 0 {
+			return nil
+		}
+		return errors.New("kind is missing")
+	}
+	if name == "" && doc.Kind != KindTenantSettings {
+		return errors.Errorf("metadata.name of %s is missing", doc.Kind)
+	}
+
+	switch doc.Kind {
+	case KindTag:
+		for _, tag := range m.Tags {
+			if tag.Name == name {
+				return errors.Errorf("Tag %q is defined more than once", name)
+			}
+		}
+		m.Tags = append(m.Tags, Tag{Name: name, Id: id})
+	case KindPolicy:
+		policy := Policy{}
+		if err := decodeSpec(&doc.Spec, &policy); err != nil {
+			return errors.Wrapf(err, "Invalid spec of policy %q", name)
+		}
+		policy.Name, policy.Id = name, id
+		if err := policy.readPolicyFile(baseDir); err != nil {
+			return err
+		}
+		for _, other := range m.Policies {
+			if other.Name == name {
+				return errors.Errorf("Policy %q is defined more than once", name)
+			}
+		}
+		m.Policies = append(m.Policies, policy)
+	case KindUser:
+		user := User{}
+		if err := decodeSpec(&doc.Spec, &user); err != nil {
+			return errors.Wrapf(err, "Invalid spec of user %q", name)
+		}
+		user.Email, user.Id = name, id
+		for _, other := range m.Users {
+			if other.Email == name {
+				return errors.Errorf("User %q is defined more than once", name)
+			}
+		}
+		m.Users = append(m.Users, user)
+	case KindTenantSettings:
+		if m.TenantSettings != nil {
+			return errors.New("TenantSettings is defined more than once")
+		}
+		m.TenantSettings = &TenantSettings{}
+		if err := decodeSpec(&doc.Spec, m.TenantSettings); err != nil {
+			return errors.Wrap(err, "Invalid spec of tenant settings")
+		}
+	case KindApiClient:
+		apiClient := ApiClient{}
+		if err := decodeSpec(&doc.Spec, &apiClient); err != nil {
+			return errors.Wrapf(err, "Invalid spec of api client %q", name)
+		}
+		apiClient.Name, apiClient.Id = name, id
+		m.ApiClients = append(m.ApiClients, apiClient)
+	default:
+		return errors.Errorf("Unknown kind %q, should be one of %s, %s, %s, %s, %s or %s", doc.Kind, KindTag, KindPolicy,
+			KindUser, KindTenantSettings, KindApiClient, KindList)
+	}
+	return nil
+}
+
+// decodeSpec decodes the spec of a resource, rejecting the unknown fields so that typos do not go unnoticed
+func decodeSpec(node *yaml.Node, spec interface{}) error {
+	if node.Kind == 0 {
+		return nil
+	}
+	content, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	return decoder.Decode(spec)
+}
+
+// readPolicyFile reads the rego text of the policy from its policy file, if any
+func (p *Policy) readPolicyFile(baseDir string) error {
+	if p.PolicyFile == "" {
+		return nil
+	}
+	if p.Policy != "" {
+		return errors.Errorf("Policy %q has both policy and policy_file", p.Name)
+	}
+	path := p.PolicyFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read policy file of policy %q", p.Name)
+	}
+	p.Policy = string(content)
+	return nil
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const tenantManifest = `
+kind: Tag
+metadata:
+  name: Power
+---
+kind: Policy
+metadata:
+  name: sgx-policy
+spec:
+  service_offer: Attestation
+  policy_type: Appraisal policy
+  attestation_type: SGX Attestation
+  policy_file: sgx-policy.rego
+---
+kind: User
+metadata:
+  name: admin@example.com
+spec:
+  role: Tenant Admin
+---
+kind: TenantSettings
+spec:
+  attest_failure_email: admin@example.com
+---
+kind: ApiClient
+metadata:
+  name: ci-client
+spec:
+  service: Attestation
+  product: Developer
+  policies: [sgx-policy]
+  tags:
+    - key: Power
+      value: high
+    - key: Workload
+      value: WorkloadAI
+`
+
+func newTestClient(t *testing.T) *sdk.Client {
+	server, err := mockserver.New(nil)
+	assert.NoError(t, err)
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	taClient, err := sdk.New(sdk.WithBaseUrl(httpServer.URL), sdk.WithApiKey("key"),
+		sdk.WithRetry(client.RetryOptions{Max: 0}))
+	assert.NoError(t, err)
+	return taClient
+}
+
+func writeManifest(t *testing.T, content string) string {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "sgx-policy.rego"), []byte("default matches_sgx_policy = false"), 0600))
+	path := filepath.Join(dir, "tenant.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func apply(t *testing.T, taClient *sdk.Client, path string) []Result {
+	m, err := Load(path)
+	assert.NoError(t, err)
+	s, err := FetchState(context.Background(), taClient)
+	assert.NoError(t, err)
+	changes, err := Plan(m, s)
+	assert.NoError(t, err)
+	return Apply(context.Background(), taClient, s, changes)
+}
+
+func TestApply(t *testing.T) {
+	taClient := newTestClient(t)
+	path := writeManifest(t, tenantManifest)
+
+	results := apply(t, taClient, path)
+	assert.Len(t, results, 5)
+	for _, result := range results {
+		// the tenant settings of a tenant always exist
+		expected := ResultCreated
+		if result.Kind == KindTenantSettings {
+			expected = ResultUpdated
+		}
+		assert.Equal(t, expected, result.Result, result.Name)
+		assert.Empty(t, result.Error, result.Name)
+	}
+
+	results = apply(t, taClient, path)
+	for _, result := range results {
+		assert.Equal(t, ResultUnchanged, result.Result, "Test applying the manifest again for "+result.Name)
+		if result.Kind != KindTenantSettings {
+			assert.NotEmpty(t, result.Id, result.Name)
+		}
+	}
+
+	updated := writeManifest(t, tenantManifest+`---
+kind: Policy
+metadata:
+  name: tdx-policy
+spec:
+  service_offer: Attestation
+  policy_type: Appraisal policy
+  attestation_type: SGX Attestation
+  policy: default matches_tdx_policy = false
+`)
+	updatedContent, err := os.ReadFile(updated)
+	assert.NoError(t, err)
+	updatedContent = []byte(string(updatedContent) + `---
+kind: ApiClient
+metadata:
+  name: ci-client
+spec:
+  service: Attestation
+  product: Developer
+  status: Inactive
+  policies: [sgx-policy, tdx-policy]
+`)
+	assert.NoError(t, os.WriteFile(updated, updatedContent, 0600))
+	m, err := Load(updated)
+	assert.NoError(t, err)
+	s, err := FetchState(context.Background(), taClient)
+	assert.NoError(t, err)
+	_, err = Plan(m, s)
+	assert.True(t, validation.IsInputError(err), "Test an api client defined twice")
+
+	m.ApiClients = m.ApiClients[1:]
+	m.Users[0].Role = constants.UserRole
+	changes, err := Plan(m, s)
+	assert.NoError(t, err)
+	actions := map[string]string{}
+	for _, change := range changes {
+		actions[change.Name] = change.Action
+	}
+	assert.Equal(t, map[string]string{"Power": ActionUnchanged, "sgx-policy": ActionUnchanged, "tdx-policy": ActionCreate,
+		"admin@example.com": ActionUpdate, KindTenantSettings: ActionUnchanged, "ci-client": ActionUpdate}, actions)
+
+	for _, result := range Apply(context.Background(), taClient, s, changes) {
+		assert.NotEqual(t, ResultFailed, result.Result, result.Error)
+	}
+	s, err = FetchState(context.Background(), taClient)
+	assert.NoError(t, err)
+	current := FromState(s)
+	assert.Equal(t, constants.UserRole, current.Users[0].Role)
+	assert.Equal(t, []string{"sgx-policy", "tdx-policy"}, current.ApiClients[0].Policies)
+	assert.Empty(t, current.ApiClients[0].Tags)
+	assert.Equal(t, constants.ApiClientStatusInactive, current.ApiClients[0].Status)
+}
+
+func TestApplyPolicyTypeChange(t *testing.T) {
+	taClient := newTestClient(t)
+	apply(t, taClient, writeManifest(t, tenantManifest))
+
+	m, err := Load(writeManifest(t, tenantManifest))
+	assert.NoError(t, err)
+	m.Policies[0].AttestationType = "TDX Attestation"
+	s, err := FetchState(context.Background(), taClient)
+	assert.NoError(t, err)
+	changes, err := Plan(m, s)
+	assert.NoError(t, err)
+	results := Apply(context.Background(), taClient, s, changes)
+	assert.Equal(t, ResultFailed, results[1].Result, "Test changing the attestation type of a policy")
+	assert.NotEmpty(t, results[1].Id)
+}
+
+func TestParseInvalidManifest(t *testing.T) {
+	tt := []struct {
+		manifest    string
+		description string
+	}{
+		{
+			manifest:    "kind: [",
+			description: "Test a manifest which is not YAML",
+		},
+		{
+			manifest:    "kind: Secret\nmetadata:\n  name: secret",
+			description: "Test an unknown kind",
+		},
+		{
+			manifest:    "metadata:\n  name: sgx-policy",
+			description: "Test a document without a kind",
+		},
+		{
+			manifest:    "kind: Tag",
+			description: "Test a tag without a name",
+		},
+		{
+			manifest:    "kind: User\nmetadata:\n  name: admin@example.com\nspec:\n  rol: User",
+			description: "Test a spec with an unknown field",
+		},
+		{
+			manifest:    "kind: Tag\nmetadata:\n  name: Power\n---\nkind: Tag\nmetadata:\n  name: Power",
+			description: "Test a tag defined twice",
+		},
+		{
+			manifest:    "kind: Policy\nmetadata:\n  name: sgx-policy\nspec:\n  policy_file: missing.rego",
+			description: "Test a missing policy file",
+		},
+	}
+
+	for _, tc := range tt {
+		_, err := Parse([]byte(tc.manifest), t.TempDir())
+		assert.True(t, validation.IsInputError(err), tc.description)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	m, err := Parse([]byte(`{"kind": "List", "items": [
+		{"kind": "Tag", "metadata": {"name": "Power"}},
+		{"kind": "User", "metadata": {"name": "user@example.com"}, "spec": {"role": "User"}}
+	]}`), t.TempDir())
+	assert.NoError(t, err)
+	assert.Equal(t, []Tag{{Name: "Power"}}, m.Tags)
+	assert.Equal(t, []User{{Email: "user@example.com", Role: constants.UserRole}}, m.Users)
+}
+
+func TestPlanUnknownReference(t *testing.T) {
+	taClient := newTestClient(t)
+	s, err := FetchState(context.Background(), taClient)
+	assert.NoError(t, err)
+
+	tt := []struct {
+		manifest    string
+		description string
+	}{
+		{
+			manifest:    "kind: ApiClient\nmetadata:\n  name: ci-client\nspec:\n  service: Missing\n  product: Developer",
+			description: "Test an unknown service",
+		},
+		{
+			manifest:    "kind: ApiClient\nmetadata:\n  name: ci-client\nspec:\n  service: Attestation\n  product: Missing",
+			description: "Test an unknown product",
+		},
+		{
+			manifest:    "kind: ApiClient\nmetadata:\n  name: ci-client\nspec:\n  service: Attestation\n  product: Developer\n  policies: [missing]",
+			description: "Test an unknown policy",
+		},
+		{
+			manifest:    "kind: ApiClient\nmetadata:\n  name: ci-client\nspec:\n  service: Attestation\n  product: Developer\n  tags: [{key: Missing, value: x}]",
+			description: "Test an unknown tag",
+		},
+		{
+			manifest:    "kind: Tag\nmetadata:\n  name: Workload",
+			description: "Test declaring a predefined tag",
+		},
+	}
+
+	for _, tc := range tt {
+		m, err := Parse([]byte(tc.manifest), t.TempDir())
+		assert.NoError(t, err)
+		_, err = Plan(m, s)
+		assert.True(t, validation.IsInputError(err), tc.description)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"github.com/pkg/errors"
+	"intel/tac/v1/validation"
+	"reflect"
+	"strings"
+)
+
+// Actions of a change
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+	ActionDelete    = "delete"
+)
+
+// Change is the change of a resource needed to reach the state described by a manifest. Current and Desired hold
+// the spec of the resource, e.g. a *Policy, Current is nil when the resource is created and Desired is nil when it
+// is deleted.
+type Change struct {
+	Kind    string
+	Name    string
+	Action  string
+	Current interface{}
+	Desired interface{}
+}
+
+// Plan compares a manifest with the current state of the tenant and returns the changes needed to apply it, in the
+// order they can be applied: tags and policies come before the api clients referencing them. The resources of the
+// tenant which are missing from the manifest are returned as deletions, for the kinds the manifest declares only.
+func Plan(m *Manifest, s *State) ([]Change, error) {
+	desired, err := m.normalize(s)
+	if err != nil {
+		return nil, err
+	}
+	current := FromState(s)
+	current.clearIds()
+
+	var changes []Change
+	for i := range desired.Tags {
+		change := Change{Kind: KindTag, Name: desired.Tags[i].Name, Desired: &desired.Tags[i]}
+		for j := range current.Tags {
+			if current.Tags[j].Name == change.Name {
+				change.Current = &current.Tags[j]
+			}
+		}
+		if change.Current == nil && s.tag(change.Name) != nil {
+			return nil, validation.NewInputError(errors.Errorf("Tag %q is predefined and cannot be declared", change.Name))
+		}
+		changes = append(changes, change.compare())
+	}
+	for i := range desired.Policies {
+		change := Change{Kind: KindPolicy, Name: desired.Policies[i].Name, Desired: &desired.Policies[i]}
+		for j := range current.Policies {
+			if current.Policies[j].Name == change.Name {
+				change.Current = &current.Policies[j]
+			}
+		}
+		changes = append(changes, change.compare())
+	}
+	for i := range desired.Users {
+		change := Change{Kind: KindUser, Name: desired.Users[i].Email, Desired: &desired.Users[i]}
+		for j := range current.Users {
+			if strings.EqualFold(current.Users[j].Email, change.Name) {
+				// the email of the user is the one of the manifest, only the role is compared
+				user := current.Users[j]
+				user.Email = change.Name
+				change.Current = &user
+			}
+		}
+		changes = append(changes, change.compare())
+	}
+	if desired.TenantSettings != nil {
+		change := Change{Kind: KindTenantSettings, Name: KindTenantSettings, Desired: desired.TenantSettings}
+		if current.TenantSettings != nil {
+			change.Current = current.TenantSettings
+		}
+		changes = append(changes, change.compare())
+	}
+	for i := range desired.ApiClients {
+		change := Change{Kind: KindApiClient, Name: desired.ApiClients[i].Name, Desired: &desired.ApiClients[i]}
+		for j := range current.ApiClients {
+			if current.ApiClients[j].Service == desired.ApiClients[i].Service &&
+				current.ApiClients[j].Name == change.Name {
+				change.Current = &current.ApiClients[j]
+			}
+		}
+		changes = append(changes, change.compare())
+	}
+	return append(changes, deletions(desired, current)...), nil
+}
+
+// deletions returns the resources of the current manifest which are missing from the desired one, api clients first
+// since they reference the other resources
+func deletions(desired, current *Manifest) []Change {
+	var changes []Change
+	if len(desired.ApiClients) > 0 {
+		for i, apiClient := range current.ApiClients {
+			found := false
+			for _, other := range desired.ApiClients {
+				found = found || (other.Service == apiClient.Service && other.Name == apiClient.Name)
+			}
+			if !found {
+				changes = append(changes, Change{Kind: KindApiClient, Name: apiClient.Name, Action: ActionDelete,
+					Current: &current.ApiClients[i]})
+			}
+		}
+	}
+	if len(desired.Users) > 0 {
+		for i, user := range current.Users {
+			found := false
+			for _, other := range desired.Users {
+				found = found || strings.EqualFold(other.Email, user.Email)
+			}
+			if !found {
+				changes = append(changes, Change{Kind: KindUser, Name: user.Email, Action: ActionDelete,
+					Current: &current.Users[i]})
+			}
+		}
+	}
+	if len(desired.Policies) > 0 {
+		for i, policy := range current.Policies {
+			found := false
+			for _, other := range desired.Policies {
+				found = found || other.Name == policy.Name
+			}
+			if !found {
+				changes = append(changes, Change{Kind: KindPolicy, Name: policy.Name, Action: ActionDelete,
+					Current: &current.Policies[i]})
+			}
+		}
+	}
+	if len(desired.Tags) > 0 {
+		for i, tag := range current.Tags {
+			found := false
+			for _, other := range desired.Tags {
+				found = found || other.Name == tag.Name
+			}
+			if !found {
+				changes = append(changes, Change{Kind: KindTag, Name: tag.Name, Action: ActionDelete,
+					Current: &current.Tags[i]})
+			}
+		}
+	}
+	return changes
+}
+
+func (c Change) compare() Change {
+	switch {
+	case c.Current == nil:
+		c.Action = ActionCreate
+	case reflect.DeepEqual(c.Current, c.Desired):
+		c.Action = ActionUnchanged
+	default:
+		c.Action = ActionUpdate
+	}
+	return c
+}
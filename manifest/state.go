@@ -0,0 +1,396 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"intel/tac/v1/sdk"
+	"intel/tac/v1/validation"
+	"sort"
+	"strings"
+)
+
+// State is the current state of a tenant, as retrieved from Trust Authority
+type State struct {
+	ServiceOffers  []models.ServiceOffer
+	Products       []models.Product
+	Services       []models.Service
+	Policies       []models.PolicyResponse
+	Tags           []models.Tag
+	Users          []models.TenantUser
+	ApiClients     []models.ApiClientDetail
+	TenantSettings *models.AttestationFailureEmail
+}
+
+// FetchState retrieves the resources of the tenant which a manifest can describe, along with the service offers,
+// products and services they reference
+func FetchState(ctx context.Context, c *sdk.Client, opts ...sdk.CallOption) (*State, error) {
+	var err error
+	s := &State{}
+	if s.ServiceOffers, err = c.ListServiceOffers(ctx, opts...); err != nil {
+		return nil, err
+	}
+	for _, serviceOffer := range s.ServiceOffers {
+		products, err := c.ListProducts(ctx, serviceOffer.ID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		s.Products = append(s.Products, products...)
+	}
+	if s.Services, err = c.ListServices(ctx, opts...); err != nil {
+		return nil, err
+	}
+	if s.Policies, err = c.ListPolicies(ctx, opts...); err != nil {
+		return nil, err
+	}
+	tags, err := c.ListTags(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.Tags = tags.Tags
+	if s.Users, err = c.ListUsers(ctx, opts...); err != nil {
+		return nil, err
+	}
+	for _, service := range s.Services {
+		apiClients, err := c.ListApiClients(ctx, service.ID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, apiClient := range apiClients {
+			detail, err := c.GetApiClient(ctx, service.ID, apiClient.ID, opts...)
+			if err != nil {
+				return nil, err
+			}
+			s.ApiClients = append(s.ApiClients, *detail)
+		}
+	}
+	if s.TenantSettings, err = c.GetTenantSettings(ctx, opts...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// FromState describes the current state of a tenant as a manifest holding the IDs of the resources, the predefined
+// tags are left out since they exist in every tenant
+func FromState(s *State) *Manifest {
+	m := &Manifest{}
+	for _, tag := range s.Tags {
+		if !tag.Predefined {
+			m.Tags = append(m.Tags, Tag{Name: tag.Name, Id: idString(tag.ID)})
+		}
+	}
+	for _, policy := range s.Policies {
+		m.Policies = append(m.Policies, s.policyManifest(&policy))
+	}
+	for _, user := range s.Users {
+		m.Users = append(m.Users, User{Email: user.Email, Id: user.ID.String(), Role: user.Role.Name})
+	}
+	if s.TenantSettings != nil {
+		m.TenantSettings = &TenantSettings{AttestationFailureEmail: s.TenantSettings.AttestationFailureEmail}
+	}
+	for _, apiClient := range s.ApiClients {
+		m.ApiClients = append(m.ApiClients, s.apiClientManifest(&apiClient))
+	}
+	m.sort()
+	return m
+}
+
+func (s *State) policyManifest(policy *models.PolicyResponse) Policy {
+	serviceOffer := policy.ServiceOfferId.String()
+	if offer := s.serviceOfferById(policy.ServiceOfferId); offer != nil {
+		serviceOffer = offer.Name
+	}
+	return Policy{
+		Name:            policy.PolicyName,
+		Id:              policy.PolicyId.String(),
+		ServiceOffer:    serviceOffer,
+		PolicyType:      policy.PolicyType,
+		AttestationType: policy.AttestationType,
+		Policy:          policy.Policy,
+	}
+}
+
+func (s *State) apiClientManifest(apiClient *models.ApiClientDetail) ApiClient {
+	m := ApiClient{
+		Name:    apiClient.Name,
+		Id:      apiClient.ID.String(),
+		Service: apiClient.ServiceId.String(),
+		Product: apiClient.ProductName,
+		Status:  string(apiClient.Status),
+	}
+	for _, service := range s.Services {
+		if service.ID == apiClient.ServiceId {
+			m.Service = s.serviceRef(&service)
+		}
+	}
+	for _, policyId := range apiClient.PolicyIds {
+		name := policyId.String()
+		if policy := s.policyById(policyId); policy != nil {
+			name = policy.PolicyName
+		}
+		m.Policies = append(m.Policies, name)
+	}
+	for _, tag := range apiClient.TagsValues {
+		m.Tags = append(m.Tags, TagValue{Key: tag.Name, Value: tag.Value})
+	}
+	sortApiClient(&m)
+	return m
+}
+
+func idString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// serviceRef references a service by its name, or by its ID when several services have that name
+func (s *State) serviceRef(service *models.Service) string {
+	for _, other := range s.Services {
+		if other.ID != service.ID && other.Name == service.Name {
+			return service.ID.String()
+		}
+	}
+	return service.Name
+}
+
+func (s *State) serviceOfferById(id uuid.UUID) *models.ServiceOffer {
+	for i := range s.ServiceOffers {
+		if s.ServiceOffers[i].ID == id {
+			return &s.ServiceOffers[i]
+		}
+	}
+	return nil
+}
+
+func (s *State) policyById(id uuid.UUID) *models.PolicyResponse {
+	for i := range s.Policies {
+		if s.Policies[i].PolicyId == id {
+			return &s.Policies[i]
+		}
+	}
+	return nil
+}
+
+// serviceOffer looks up a service offer by name or ID
+func (s *State) serviceOffer(ref string) (*models.ServiceOffer, error) {
+	var found *models.ServiceOffer
+	for i, serviceOffer := range s.ServiceOffers {
+		if serviceOffer.ID.String() == ref {
+			return &s.ServiceOffers[i], nil
+		}
+		if serviceOffer.Name == ref {
+			if found != nil {
+				return nil, validation.NewInputError(errors.Errorf("Service offer name %q is ambiguous, use its ID", ref))
+			}
+			found = &s.ServiceOffers[i]
+		}
+	}
+	if found == nil {
+		return nil, validation.NewInputError(errors.Errorf("Service offer %q not found", ref))
+	}
+	return found, nil
+}
+
+// service looks up a service by name or ID
+func (s *State) service(ref string) (*models.Service, error) {
+	var found *models.Service
+	for i, service := range s.Services {
+		if service.ID.String() == ref {
+			return &s.Services[i], nil
+		}
+		if service.Name == ref {
+			if found != nil {
+				return nil, validation.NewInputError(errors.Errorf("Service name %q is ambiguous, use its ID", ref))
+			}
+			found = &s.Services[i]
+		}
+	}
+	if found == nil {
+		return nil, validation.NewInputError(errors.Errorf("Service %q not found", ref))
+	}
+	return found, nil
+}
+
+// product looks up a product of a service offer by name or ID
+func (s *State) product(serviceOfferId uuid.UUID, ref string) (*models.Product, error) {
+	for i, product := range s.Products {
+		if product.ServiceOfferId == serviceOfferId && (product.ID.String() == ref || product.Name == ref) {
+			return &s.Products[i], nil
+		}
+	}
+	return nil, validation.NewInputError(errors.Errorf("Product %q not found", ref))
+}
+
+// policy looks up a policy by name or ID
+func (s *State) policy(ref string) *models.PolicyResponse {
+	for i, policy := range s.Policies {
+		if policy.PolicyName == ref || policy.PolicyId.String() == ref {
+			return &s.Policies[i]
+		}
+	}
+	return nil
+}
+
+// tag looks up a tag by name
+func (s *State) tag(name string) *models.Tag {
+	for i, tag := range s.Tags {
+		if tag.Name == name {
+			return &s.Tags[i]
+		}
+	}
+	return nil
+}
+
+// user looks up a user by email, ignoring its case
+func (s *State) user(email string) *models.TenantUser {
+	for i, user := range s.Users {
+		if strings.EqualFold(user.Email, email) {
+			return &s.Users[i]
+		}
+	}
+	return nil
+}
+
+// apiClient looks up an api client of a service by name
+func (s *State) apiClient(serviceId uuid.UUID, name string) *models.ApiClientDetail {
+	for i, apiClient := range s.ApiClients {
+		if apiClient.ServiceId == serviceId && apiClient.Name == name {
+			return &s.ApiClients[i]
+		}
+	}
+	return nil
+}
+
+// normalize resolves the references of the manifest against the state and the resources of the manifest itself, so
+// that it compares with FromState once the IDs are cleared
+func (m *Manifest) normalize(s *State) (*Manifest, error) {
+	normalized := &Manifest{TenantSettings: m.TenantSettings}
+	for _, tag := range m.Tags {
+		normalized.Tags = append(normalized.Tags, Tag{Name: tag.Name})
+	}
+	for _, user := range m.Users {
+		normalized.Users = append(normalized.Users, User{Email: user.Email, Role: user.Role})
+	}
+	for _, policy := range m.Policies {
+		serviceOffer, err := s.serviceOffer(policy.ServiceOffer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid policy %q", policy.Name)
+		}
+		policy.ServiceOffer = serviceOffer.Name
+		policy.Id, policy.PolicyFile = "", ""
+		normalized.Policies = append(normalized.Policies, policy)
+	}
+
+	for _, apiClient := range m.ApiClients {
+		service, err := s.service(apiClient.Service)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid api client %q", apiClient.Name)
+		}
+		product, err := s.product(service.ServiceOfferId, apiClient.Product)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid api client %q", apiClient.Name)
+		}
+		normalizedClient := ApiClient{
+			Name:    apiClient.Name,
+			Service: s.serviceRef(service),
+			Product: product.Name,
+			Status:  apiClient.Status,
+			Tags:    append([]TagValue(nil), apiClient.Tags...),
+		}
+		if normalizedClient.Status == "" {
+			normalizedClient.Status = constants.ApiClientStatusActive
+		}
+		for _, ref := range apiClient.Policies {
+			name, err := m.policyRef(s, ref)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Invalid api client %q", apiClient.Name)
+			}
+			normalizedClient.Policies = append(normalizedClient.Policies, name)
+		}
+		for _, tag := range apiClient.Tags {
+			if !m.hasTag(tag.Key) && s.tag(tag.Key) == nil {
+				return nil, validation.NewInputError(errors.Errorf("Invalid api client %q: Tag %q not found",
+					apiClient.Name, tag.Key))
+			}
+		}
+		for _, other := range normalized.ApiClients {
+			if other.Service == normalizedClient.Service && other.Name == normalizedClient.Name {
+				return nil, validation.NewInputError(errors.Errorf("Api client %q of service %q is defined more than once",
+					apiClient.Name, apiClient.Service))
+			}
+		}
+		sortApiClient(&normalizedClient)
+		normalized.ApiClients = append(normalized.ApiClients, normalizedClient)
+	}
+	normalized.sort()
+	return normalized, nil
+}
+
+// policyRef resolves the name or ID of a policy referenced by an api client to the name of the policy
+func (m *Manifest) policyRef(s *State, ref string) (string, error) {
+	for _, policy := range m.Policies {
+		if policy.Name == ref {
+			return ref, nil
+		}
+	}
+	if policy := s.policy(ref); policy != nil {
+		return policy.PolicyName, nil
+	}
+	return "", validation.NewInputError(errors.Errorf("Policy %q not found", ref))
+}
+
+func (m *Manifest) hasTag(name string) bool {
+	for _, tag := range m.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// clearIds clears the IDs of the resources, which are not compared
+func (m *Manifest) clearIds() {
+	for i := range m.Tags {
+		m.Tags[i].Id = ""
+	}
+	for i := range m.Policies {
+		m.Policies[i].Id = ""
+	}
+	for i := range m.Users {
+		m.Users[i].Id = ""
+	}
+	for i := range m.ApiClients {
+		m.ApiClients[i].Id = ""
+	}
+}
+
+// sort orders the resources of the manifest by name so that manifests compare and print consistently
+func (m *Manifest) sort() {
+	sort.SliceStable(m.Tags, func(i, j int) bool { return m.Tags[i].Name < m.Tags[j].Name })
+	sort.SliceStable(m.Policies, func(i, j int) bool { return m.Policies[i].Name < m.Policies[j].Name })
+	sort.SliceStable(m.Users, func(i, j int) bool { return m.Users[i].Email < m.Users[j].Email })
+	sort.SliceStable(m.ApiClients, func(i, j int) bool {
+		if m.ApiClients[i].Service != m.ApiClients[j].Service {
+			return m.ApiClients[i].Service < m.ApiClients[j].Service
+		}
+		return m.ApiClients[i].Name < m.ApiClients[j].Name
+	})
+}
+
+func sortApiClient(apiClient *ApiClient) {
+	sort.Strings(apiClient.Policies)
+	sort.SliceStable(apiClient.Tags, func(i, j int) bool {
+		if apiClient.Tags[i].Key != apiClient.Tags[j].Key {
+			return apiClient.Tags[i].Key < apiClient.Tags[j].Key
+		}
+		return apiClient.Tags[i].Value < apiClient.Tags[j].Value
+	})
+}
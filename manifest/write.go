@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Formats of a written manifest
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// policyFileChars are the characters kept in the name of a policy file
+var policyFileChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// resource is a resource of a manifest as it is written
+type resource struct {
+	Kind     string      `yaml:"kind" json:"kind"`
+	Metadata *metadata   `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	Spec     interface{} `yaml:"spec,omitempty" json:"spec,omitempty"`
+}
+
+// list is a manifest written as a single document
+type list struct {
+	Kind  string     `json:"kind"`
+	Items []resource `json:"items"`
+}
+
+// newResource wraps the spec of a resource with its kind, name and ID, the tags have no spec and the tenant settings
+// no name
+func newResource(kind, name, id string, spec interface{}) resource {
+	r := resource{Kind: kind, Spec: spec}
+	if kind != KindTenantSettings {
+		r.Metadata = &metadata{Name: name, Id: id}
+	}
+	if kind == KindTag {
+		r.Spec = nil
+	}
+	return r
+}
+
+// encodeYAML writes a resource as a YAML document, the multi-line rego policies are written as literal blocks so
+// that they diff line by line
+func encodeYAML(r resource) ([]string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(r); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return strings.SplitAfter(buf.String(), "\n"), nil
+}
+
+// resources lists the resources of the manifest in the order they can be applied
+func (m *Manifest) resources() []resource {
+	var resources []resource
+	for i := range m.Tags {
+		resources = append(resources, newResource(KindTag, m.Tags[i].Name, m.Tags[i].Id, nil))
+	}
+	for i := range m.Policies {
+		resources = append(resources, newResource(KindPolicy, m.Policies[i].Name, m.Policies[i].Id, &m.Policies[i]))
+	}
+	for i := range m.Users {
+		resources = append(resources, newResource(KindUser, m.Users[i].Email, m.Users[i].Id, &m.Users[i]))
+	}
+	if m.TenantSettings != nil {
+		resources = append(resources, newResource(KindTenantSettings, "", "", m.TenantSettings))
+	}
+	for i := range m.ApiClients {
+		resources = append(resources, newResource(KindApiClient, m.ApiClients[i].Name, m.ApiClients[i].Id, &m.ApiClients[i]))
+	}
+	return resources
+}
+
+// Write writes the manifest as YAML documents, one per resource, or as a JSON List document. The manifest written
+// can be read back with Load.
+func (m *Manifest) Write(w io.Writer, format string) error {
+	resources := m.resources()
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(list{Kind: KindList, Items: resources})
+	case FormatYAML:
+		for i, r := range resources {
+			lines, err := encodeYAML(r)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				if _, err = io.WriteString(w, "---\n"); err != nil {
+					return err
+				}
+			}
+			if _, err = io.WriteString(w, strings.Join(lines, "")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("Unknown manifest format %q, should be %s or %s", format, FormatYAML, FormatJSON)
+}
+
+// SplitPolicies writes the rego policies to files of policyDir named after the policies, and references them from the
+// manifest by their path relative to baseDir, the directory of the manifest
+func (m *Manifest) SplitPolicies(baseDir, policyDir string) error {
+	if err := os.MkdirAll(policyDir, 0750); err != nil {
+		return errors.Wrap(err, "Failed to create policy directory")
+	}
+	used := map[string]bool{}
+	for i := range m.Policies {
+		policy := &m.Policies[i]
+		// the names of two policies may only differ by the characters replaced
+		fileName := policyFileChars.ReplaceAllString(policy.Name, "_")
+		for n := 2; used[fileName]; n++ {
+			fileName = fmt.Sprintf("%s_%d", policyFileChars.ReplaceAllString(policy.Name, "_"), n)
+		}
+		used[fileName] = true
+		path := filepath.Join(policyDir, fileName+".rego")
+		if err := os.WriteFile(path, []byte(policy.Policy), 0600); err != nil {
+			return errors.Wrapf(err, "Failed to write policy file of policy %q", policy.Name)
+		}
+		relPath, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		policy.Policy, policy.PolicyFile = "", filepath.ToSlash(relPath)
+	}
+	return nil
+}
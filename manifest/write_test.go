@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	taClient := newTestClient(t)
+	apply(t, taClient, writeManifest(t, tenantManifest))
+	s, err := FetchState(context.Background(), taClient)
+	assert.NoError(t, err)
+
+	for _, format := range []string{FormatYAML, FormatJSON} {
+		exported := FromState(s)
+		var buf bytes.Buffer
+		assert.NoError(t, exported.Write(&buf, format), format)
+
+		m, err := Parse(buf.Bytes(), t.TempDir())
+		assert.NoError(t, err, format)
+		assert.Equal(t, exported, m, "Test reading back the manifest written as "+format)
+		changes, err := Plan(m, s)
+		assert.NoError(t, err, format)
+		for _, change := range changes {
+			assert.Equal(t, ActionUnchanged, change.Action, "Test applying the manifest written as "+format+" for "+change.Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.Error(t, FromState(s).Write(&buf, "xml"), "Test an unknown format")
+}
+
+func TestSplitPolicies(t *testing.T) {
+	m := &Manifest{Policies: []Policy{
+		{Name: "sgx policy", Policy: "default matches_sgx_policy = false"},
+		{Name: "sgx_policy", Policy: "default matches_sgx_policy = true"},
+	}}
+	dir := t.TempDir()
+	assert.NoError(t, m.SplitPolicies(dir, filepath.Join(dir, "policies")))
+	assert.Equal(t, "policies/sgx_policy.rego", m.Policies[0].PolicyFile)
+	assert.Equal(t, "policies/sgx_policy_2.rego", m.Policies[1].PolicyFile, "Test policy names conflicting once replaced")
+	assert.Empty(t, m.Policies[0].Policy)
+
+	path := filepath.Join(dir, "tenant.yaml")
+	var buf bytes.Buffer
+	assert.NoError(t, m.Write(&buf, FormatYAML))
+	assert.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+	loaded, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "default matches_sgx_policy = true", loaded.Policies[1].Policy, "Test reading back the policy files")
+}
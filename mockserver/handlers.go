@@ -0,0 +1,402 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mockserver
+
+import (
+	"github.com/google/uuid"
+	"intel/tac/v1/models"
+	"net/http"
+	"time"
+)
+
+func (s *Server) getServiceOffers(*http.Request) (int, interface{}, error) {
+	return http.StatusOK, s.serviceOffers, nil
+}
+
+func (s *Server) getProducts(r *http.Request) (int, interface{}, error) {
+	serviceOffer, err := s.pathServiceOffer(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	products := []models.Product{}
+	for _, product := range s.products {
+		if product.ServiceOfferId == serviceOffer.ID {
+			products = append(products, product)
+		}
+	}
+	return http.StatusOK, products, nil
+}
+
+func (s *Server) getPlans(r *http.Request) (int, interface{}, error) {
+	serviceOffer, err := s.pathServiceOffer(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	plans := []models.Plan{}
+	for _, plan := range s.plans {
+		if plan.ServiceOfferId == serviceOffer.ID {
+			plans = append(plans, plan)
+		}
+	}
+	return http.StatusOK, plans, nil
+}
+
+func (s *Server) getPlan(r *http.Request) (int, interface{}, error) {
+	serviceOffer, err := s.pathServiceOffer(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	planId, err := pathId(r, "planId")
+	if err != nil {
+		return 0, nil, err
+	}
+	plan := s.plan(planId)
+	if plan == nil || plan.ServiceOfferId != serviceOffer.ID {
+		return 0, nil, newError(http.StatusNotFound, "Plan %s not found", planId)
+	}
+
+	planProducts := models.PlanProducts{
+		ID:             plan.ID,
+		ServiceOfferId: plan.ServiceOfferId,
+		Name:           plan.Name,
+		MaxKey:         plan.MaxKey,
+		MaxTenantAdmin: plan.MaxTenantAdmin,
+		MaxTenantUser:  plan.MaxTenantUser,
+		MaxPolicy:      plan.MaxPolicy,
+		Ledger:         plan.Ledger,
+		Products:       []models.Product{},
+	}
+	for _, product := range s.products {
+		if product.PlanId == plan.ID {
+			planProducts.Products = append(planProducts.Products, product)
+		}
+	}
+	return http.StatusOK, planProducts, nil
+}
+
+func (s *Server) getServices(*http.Request) (int, interface{}, error) {
+	return http.StatusOK, s.services, nil
+}
+
+func (s *Server) getService(r *http.Request) (int, interface{}, error) {
+	service, err := s.pathService(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, models.ServiceDetail{
+		ID:               service.ID,
+		ServiceOfferId:   service.ServiceOfferId,
+		ServiceOfferName: s.serviceOffer(service.ServiceOfferId).Name,
+		Name:             service.Name,
+		CreatedAt:        service.CreatedAt,
+		Active:           service.Active,
+		PlanId:           service.PlanId,
+		PlanName:         service.PlanName,
+	}, nil
+}
+
+func (s *Server) createApiClient(r *http.Request) (int, interface{}, error) {
+	service, err := s.pathService(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	var request models.CreateApiClient
+	if err = decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+
+	plan := s.plan(service.PlanId)
+	if plan.MaxKey > 0 && len(s.serviceApiClients(service.ID)) >= plan.MaxKey {
+		return 0, nil, newError(http.StatusConflict, "Plan %q allows at most %d api clients per service", plan.Name, plan.MaxKey)
+	}
+	apiClient := &models.ApiClientDetail{
+		ID:        uuid.New(),
+		ServiceId: service.ID,
+		Name:      request.Name,
+		Status:    request.Status,
+		Keys:      []string{newApiKey()},
+		CreatedAt: time.Now().UTC(),
+	}
+	if err = s.setApiClient(service, apiClient, request.ProductId, request.PolicyIds, request.TagIdsValues); err != nil {
+		return 0, nil, err
+	}
+	s.apiClients = append(s.apiClients, apiClient)
+	return http.StatusCreated, apiClient, nil
+}
+
+func (s *Server) getApiClients(r *http.Request) (int, interface{}, error) {
+	service, err := s.pathService(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	apiClients := []models.ApiClient{}
+	for _, apiClient := range s.serviceApiClients(service.ID) {
+		apiClients = append(apiClients, apiClientSummary(apiClient))
+	}
+	return http.StatusOK, apiClients, nil
+}
+
+func (s *Server) getApiClient(r *http.Request) (int, interface{}, error) {
+	apiClient, err := s.pathApiClient(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, apiClient, nil
+}
+
+func (s *Server) updateApiClient(r *http.Request) (int, interface{}, error) {
+	apiClient, err := s.pathApiClient(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	var request models.UpdateApiClient
+	if err = decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+
+	// the api client is updated on a copy so that it is left unchanged when the request is invalid
+	updated := *apiClient
+	if request.Name != nil {
+		updated.Name = *request.Name
+	}
+	if request.Status != nil {
+		updated.Status = *request.Status
+	}
+	productId := request.ProductId
+	if productId == uuid.Nil {
+		productId = apiClient.ProductId
+	}
+	if err = s.setApiClient(s.service(apiClient.ServiceId), &updated, productId, request.PolicyIds, request.TagIdsValues); err != nil {
+		return 0, nil, err
+	}
+	*apiClient = updated
+	return http.StatusOK, apiClientSummary(apiClient), nil
+}
+
+func (s *Server) deleteApiClient(r *http.Request) (int, interface{}, error) {
+	apiClient, err := s.pathApiClient(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	s.apiClients = remove(s.apiClients, apiClient)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) getApiClientPolicies(r *http.Request) (int, interface{}, error) {
+	apiClient, err := s.pathApiClient(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, models.ApiClientPolicies{PolicyIds: apiClient.PolicyIds}, nil
+}
+
+func (s *Server) getApiClientTags(r *http.Request) (int, interface{}, error) {
+	apiClient, err := s.pathApiClient(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, models.ApiClientTags{TagsValues: apiClient.TagsValues}, nil
+}
+
+func (s *Server) createUser(r *http.Request) (int, interface{}, error) {
+	var request models.CreateTenantUser
+	if err := decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+	if request.Email == "" {
+		return 0, nil, newError(http.StatusBadRequest, "User email is required")
+	}
+	roleId, ok := roleIds[request.Role]
+	if !ok {
+		return 0, nil, newError(http.StatusBadRequest, "Role %q not found", request.Role)
+	}
+	for _, user := range s.users {
+		if user.Email == request.Email {
+			return 0, nil, newError(http.StatusConflict, "User %s already exists", request.Email)
+		}
+	}
+
+	user := &models.TenantUser{
+		ID:        uuid.New(),
+		Email:     request.Email,
+		Role:      models.Role{ID: roleId, Name: request.Role},
+		Active:    true,
+		CreatedAt: time.Now().UTC(),
+	}
+	s.users = append(s.users, user)
+	return http.StatusCreated, user, nil
+}
+
+func (s *Server) getUsers(*http.Request) (int, interface{}, error) {
+	return http.StatusOK, s.users, nil
+}
+
+func (s *Server) updateUserRole(r *http.Request) (int, interface{}, error) {
+	user, err := s.pathUser(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	var request models.UpdateTenantUserRoles
+	if err = decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+	roleId, ok := roleIds[request.Role]
+	if !ok {
+		return 0, nil, newError(http.StatusBadRequest, "Role %q not found", request.Role)
+	}
+	user.Role = models.Role{ID: roleId, Name: request.Role}
+	return http.StatusOK, user, nil
+}
+
+func (s *Server) deleteUser(r *http.Request) (int, interface{}, error) {
+	user, err := s.pathUser(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	s.users = remove(s.users, user)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) createTag(r *http.Request) (int, interface{}, error) {
+	var request models.TagCreate
+	if err := decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+	if request.Name == "" {
+		return 0, nil, newError(http.StatusBadRequest, "Tag name is required")
+	}
+	if s.tagByName(request.Name) != nil {
+		return 0, nil, newError(http.StatusConflict, "Tag %q already exists", request.Name)
+	}
+
+	id := uuid.New()
+	tag := &models.Tag{ID: &id, Name: request.Name}
+	s.tags = append(s.tags, tag)
+	return http.StatusCreated, tag, nil
+}
+
+func (s *Server) getTags(*http.Request) (int, interface{}, error) {
+	tags := models.Tags{Tags: []models.Tag{}}
+	for _, tag := range s.tags {
+		tags.Tags = append(tags.Tags, *tag)
+	}
+	return http.StatusOK, tags, nil
+}
+
+func (s *Server) deleteTag(r *http.Request) (int, interface{}, error) {
+	tagId, err := pathId(r, "tagId")
+	if err != nil {
+		return 0, nil, err
+	}
+	tag := s.tag(tagId)
+	if tag == nil {
+		return 0, nil, newError(http.StatusNotFound, "Tag %s not found", tagId)
+	}
+	if tag.Predefined {
+		return 0, nil, newError(http.StatusBadRequest, "Predefined tag %q cannot be deleted", tag.Name)
+	}
+	for _, apiClient := range s.apiClients {
+		for _, tagValue := range apiClient.TagsValues {
+			if tagValue.Name == tag.Name {
+				return 0, nil, newError(http.StatusConflict, "Tag %q is used by api client %s", tag.Name, apiClient.ID)
+			}
+		}
+	}
+	s.tags = remove(s.tags, tag)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) createPolicy(r *http.Request) (int, interface{}, error) {
+	var request models.PolicyRequest
+	if err := decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+	if err := s.validatePolicy(&request.CommonPolicy); err != nil {
+		return 0, nil, err
+	}
+	if plan := s.serviceOfferPlan(request.ServiceOfferId); plan != nil && plan.MaxPolicy > 0 &&
+		len(s.serviceOfferPolicies(request.ServiceOfferId)) >= plan.MaxPolicy {
+		return 0, nil, newError(http.StatusConflict, "Plan %q allows at most %d policies", plan.Name, plan.MaxPolicy)
+	}
+
+	now := time.Now().UTC()
+	policy := &models.PolicyResponse{
+		CommonPolicy: request.CommonPolicy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		PolicyHash:   policyHash(request.Policy),
+	}
+	policy.PolicyId = uuid.New()
+	s.policies = append(s.policies, policy)
+	return http.StatusCreated, policy, nil
+}
+
+func (s *Server) getPolicies(*http.Request) (int, interface{}, error) {
+	return http.StatusOK, s.policies, nil
+}
+
+func (s *Server) getPolicy(r *http.Request) (int, interface{}, error) {
+	policy, err := s.pathPolicy(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, policy, nil
+}
+
+func (s *Server) updatePolicy(r *http.Request) (int, interface{}, error) {
+	policy, err := s.pathPolicy(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	var request models.PolicyUpdateRequest
+	if err = decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+
+	updated := policy.CommonPolicy
+	if request.PolicyName != "" {
+		updated.PolicyName = request.PolicyName
+	}
+	if request.Policy != "" {
+		updated.Policy = request.Policy
+	}
+	if err = s.validatePolicy(&updated); err != nil {
+		return 0, nil, err
+	}
+	policy.CommonPolicy = updated
+	policy.PolicyHash = policyHash(updated.Policy)
+	policy.UpdatedAt = time.Now().UTC()
+	return http.StatusOK, policy, nil
+}
+
+func (s *Server) deletePolicy(r *http.Request) (int, interface{}, error) {
+	policy, err := s.pathPolicy(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, apiClient := range s.apiClients {
+		for _, policyId := range apiClient.PolicyIds {
+			if policyId == policy.PolicyId {
+				return 0, nil, newError(http.StatusConflict, "Policy %q is linked to api client %s", policy.PolicyName, apiClient.ID)
+			}
+		}
+	}
+	s.policies = remove(s.policies, policy)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) getTenantSettings(*http.Request) (int, interface{}, error) {
+	return http.StatusOK, s.tenantSettings, nil
+}
+
+func (s *Server) updateTenantSettings(r *http.Request) (int, interface{}, error) {
+	var request models.AttestationFailureEmail
+	if err := decode(r, &request); err != nil {
+		return 0, nil, err
+	}
+	s.tenantSettings = request
+	return http.StatusOK, s.tenantSettings, nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mockserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Seed is the initial state of the mock server. Its fields are named as in the payloads of the Trust Authority
+// management API, e.g. service_offer_id, so that the responses of a live tenant can be pasted in a seed file.
+type Seed struct {
+	ServiceOffers  []models.ServiceOffer           `json:"service_offers"`
+	Plans          []models.Plan                   `json:"plans"`
+	Products       []models.Product                `json:"products"`
+	Services       []models.Service                `json:"services"`
+	Policies       []models.PolicyResponse         `json:"policies"`
+	Tags           []models.Tag                    `json:"tags"`
+	Users          []models.TenantUser             `json:"users"`
+	ApiClients     []models.ApiClientDetail        `json:"api_clients"`
+	TenantSettings *models.AttestationFailureEmail `json:"tenant_settings"`
+}
+
+// LoadSeed reads a seed file, either YAML or JSON
+func LoadSeed(path string) (*Seed, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read seed file")
+	}
+
+	// the YAML document is converted to JSON so that the json tags of the models name the fields
+	var document interface{}
+	if err = yaml.Unmarshal(content, &document); err != nil {
+		return nil, errors.Wrap(err, "Invalid seed file")
+	}
+	jsonContent, err := json.Marshal(document)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid seed file")
+	}
+
+	seed := &Seed{}
+	dec := json.NewDecoder(bytes.NewReader(jsonContent))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(seed); err != nil {
+		return nil, errors.Wrap(err, "Invalid seed file")
+	}
+	return seed, nil
+}
+
+// DefaultSeed is the state of the mock server when no seed file is provided: a tenant subscribed to the Basic plan
+// of an attestation service offer, with a predefined Workload tag
+func DefaultSeed() *Seed {
+	serviceOfferId := uuid.MustParse("ae3d7720-08ab-421c-b8d4-1725c358f03e")
+	planId := uuid.MustParse("8f2a20fa-b08d-48a8-b2b4-2ebd1feb6f74")
+	workloadTagId := uuid.MustParse("f31aa1bc-99a1-4706-91ff-218e12c49e00")
+	createdAt := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	return &Seed{
+		ServiceOffers: []models.ServiceOffer{{ID: serviceOfferId, Name: "Attestation"}},
+		Plans: []models.Plan{{
+			ID:             planId,
+			ServiceOfferId: serviceOfferId,
+			Name:           "Basic",
+			MaxKey:         5,
+			MaxTenantAdmin: 2,
+			MaxTenantUser:  10,
+			MaxPolicy:      10,
+		}},
+		Products: []models.Product{{
+			ID:             uuid.MustParse("e169d34f-58ce-4717-9b3a-5c66abd33417"),
+			ServiceOfferId: serviceOfferId,
+			Name:           "Developer",
+			Policy: &models.ProductPolicy{
+				Limit:              40,
+				Quota:              2500000,
+				LimitRenewalInSecs: 60,
+				QuotaRenewalInSecs: 2592000,
+			},
+			PlanId:      planId,
+			ProductType: constants.Attestation,
+		}},
+		Services: []models.Service{{
+			ID:             uuid.MustParse("5cfb6af4-59ac-4a14-8b83-bd65b1e11777"),
+			ServiceOfferId: serviceOfferId,
+			Name:           "Attestation",
+			PlanId:         planId,
+			Active:         true,
+			CreatedAt:      createdAt,
+		}},
+		Tags: []models.Tag{{ID: &workloadTagId, Name: "Workload", Predefined: true}},
+	}
+}
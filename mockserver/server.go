@@ -0,0 +1,353 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// Package mockserver implements the Trust Authority management API used by the TMS and PMS clients with an in-memory
+// state, so that the CLI and the tools built with the SDK can be tested end-to-end without a live tenant. Created
+// resources persist until they are deleted or the server stops, and the limits of the plan of the tenant's services
+// are enforced.
+package mockserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tenantId is the ID of the tenant of the mock server, used for the services which do not provide one
+var tenantId = uuid.MustParse("89120415-6fbc-41c7-b9f2-3b4ba10e87c9")
+
+// roleIds are the IDs of the roles which can be granted to the users of the tenant
+var roleIds = map[string]uuid.UUID{
+	constants.TenantAdminRole: uuid.MustParse("66ec2e33-8cd3-42b1-8963-c7765205446e"),
+	constants.UserRole:        uuid.MustParse("2f5e8f5a-3c1d-4c8e-9a57-c3f3e0e4b6a1"),
+}
+
+var apiClientStatuses = map[models.ApiClientStatus]bool{
+	constants.ApiClientStatusActive:    true,
+	constants.ApiClientStatusInactive:  true,
+	constants.ApiClientStatusCancelled: true,
+}
+
+// Server is a stateful mock of the Trust Authority management API
+type Server struct {
+	mutex          sync.Mutex
+	serviceOffers  []models.ServiceOffer
+	plans          []models.Plan
+	products       []models.Product
+	services       []models.Service
+	policies       []*models.PolicyResponse
+	tags           []*models.Tag
+	users          []*models.TenantUser
+	apiClients     []*models.ApiClientDetail
+	tenantSettings models.AttestationFailureEmail
+}
+
+// apiError is an error answered to a request with its status code
+type apiError struct {
+	statusCode int
+	message    string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newError(statusCode int, format string, args ...interface{}) error {
+	return &apiError{statusCode: statusCode, message: fmt.Sprintf(format, args...)}
+}
+
+// handlerFunc handles a request and returns the status code and body of the response
+type handlerFunc func(r *http.Request) (int, interface{}, error)
+
+// New creates a mock server with the state of the seed, the default seed when it is nil. The seed is validated the
+// same way as the requests creating its resources, except for the plan limits.
+func New(seed *Seed) (*Server, error) {
+	if seed == nil {
+		seed = DefaultSeed()
+	}
+	s := &Server{
+		serviceOffers: []models.ServiceOffer{},
+		plans:         []models.Plan{},
+		products:      []models.Product{},
+		services:      []models.Service{},
+		policies:      []*models.PolicyResponse{},
+		tags:          []*models.Tag{},
+		users:         []*models.TenantUser{},
+		apiClients:    []*models.ApiClientDetail{},
+	}
+	if err := s.load(seed); err != nil {
+		return nil, errors.Wrap(err, "Invalid seed")
+	}
+	return s, nil
+}
+
+func (s *Server) load(seed *Seed) error {
+	for _, serviceOffer := range seed.ServiceOffers {
+		serviceOffer.ID = newIdIfNil(serviceOffer.ID)
+		s.serviceOffers = append(s.serviceOffers, serviceOffer)
+	}
+	for _, plan := range seed.Plans {
+		if s.serviceOffer(plan.ServiceOfferId) == nil {
+			return errors.Errorf("Service offer %s of plan %q not found", plan.ServiceOfferId, plan.Name)
+		}
+		plan.ID = newIdIfNil(plan.ID)
+		s.plans = append(s.plans, plan)
+	}
+	for _, product := range seed.Products {
+		if s.serviceOffer(product.ServiceOfferId) == nil {
+			return errors.Errorf("Service offer %s of product %q not found", product.ServiceOfferId, product.Name)
+		}
+		if s.plan(product.PlanId) == nil {
+			return errors.Errorf("Plan %s of product %q not found", product.PlanId, product.Name)
+		}
+		product.ID = newIdIfNil(product.ID)
+		s.products = append(s.products, product)
+	}
+	for _, service := range seed.Services {
+		if s.serviceOffer(service.ServiceOfferId) == nil {
+			return errors.Errorf("Service offer %s of service %q not found", service.ServiceOfferId, service.Name)
+		}
+		plan := s.plan(service.PlanId)
+		if plan == nil {
+			return errors.Errorf("Plan %s of service %q not found", service.PlanId, service.Name)
+		}
+		service.ID = newIdIfNil(service.ID)
+		if service.TenantId == uuid.Nil {
+			service.TenantId = tenantId
+		}
+		service.PlanName = plan.Name
+		service.CreatedAt = nowIfZero(service.CreatedAt)
+		s.services = append(s.services, service)
+	}
+	for i := range seed.Tags {
+		tag := seed.Tags[i]
+		if s.tagByName(tag.Name) != nil {
+			return errors.Errorf("Tag %q is defined more than once", tag.Name)
+		}
+		if tag.ID == nil {
+			id := uuid.New()
+			tag.ID = &id
+		}
+		s.tags = append(s.tags, &tag)
+	}
+	for i := range seed.Policies {
+		policy := seed.Policies[i]
+		if err := s.validatePolicy(&policy.CommonPolicy); err != nil {
+			return err
+		}
+		policy.PolicyId = newIdIfNil(policy.PolicyId)
+		policy.CreatedAt = nowIfZero(policy.CreatedAt)
+		policy.UpdatedAt = nowIfZero(policy.UpdatedAt)
+		policy.PolicyHash = policyHash(policy.Policy)
+		s.policies = append(s.policies, &policy)
+	}
+	for i := range seed.Users {
+		user := seed.Users[i]
+		roleId, ok := roleIds[user.Role.Name]
+		if !ok {
+			return errors.Errorf("Role %q of user %s not found", user.Role.Name, user.Email)
+		}
+		user.ID = newIdIfNil(user.ID)
+		user.Role = models.Role{ID: roleId, Name: user.Role.Name}
+		user.CreatedAt = nowIfZero(user.CreatedAt)
+		s.users = append(s.users, &user)
+	}
+	for i := range seed.ApiClients {
+		apiClient := seed.ApiClients[i]
+		service := s.service(apiClient.ServiceId)
+		if service == nil {
+			return errors.Errorf("Service %s of api client %q not found", apiClient.ServiceId, apiClient.Name)
+		}
+		var tagValues []models.ApiClientTagIdValue
+		for _, tagValue := range apiClient.TagsValues {
+			tagValues = append(tagValues, models.ApiClientTagIdValue{Key: tagValue.Name, Value: tagValue.Value})
+		}
+		apiClient.ID = newIdIfNil(apiClient.ID)
+		apiClient.CreatedAt = nowIfZero(apiClient.CreatedAt)
+		if err := s.setApiClient(service, &apiClient, apiClient.ProductId, apiClient.PolicyIds, tagValues); err != nil {
+			return err
+		}
+		if len(apiClient.Keys) == 0 {
+			apiClient.Keys = []string{newApiKey()}
+		}
+		s.apiClients = append(s.apiClients, &apiClient)
+	}
+	if seed.TenantSettings != nil {
+		s.tenantSettings = *seed.TenantSettings
+	}
+	return nil
+}
+
+// Handler returns the HTTP handler serving the management API under its base path
+func (s *Server) Handler() http.Handler {
+	r := mux.NewRouter()
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, newError(http.StatusNotFound, "Route %s %s not found", r.Method, r.URL.Path))
+	})
+	r.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, newError(http.StatusMethodNotAllowed, "Method %s not allowed for %s", r.Method, r.URL.Path))
+	})
+
+	api := r.PathPrefix(constants.TmsBaseUrl).Subrouter()
+	api.Use(responseIds, requireApiKey)
+
+	serviceOfferExpr := constants.ServiceOfferApiEndpoint + "/{serviceOfferId}"
+	api.HandleFunc(constants.ServiceOfferApiEndpoint, s.handle(s.getServiceOffers)).Methods(http.MethodGet)
+	api.HandleFunc(serviceOfferExpr+constants.ProductApiEndpoint, s.handle(s.getProducts)).Methods(http.MethodGet)
+	api.HandleFunc(serviceOfferExpr+constants.PlanApiEndpoint, s.handle(s.getPlans)).Methods(http.MethodGet)
+	api.HandleFunc(serviceOfferExpr+constants.PlanApiEndpoint+"/{planId}", s.handle(s.getPlan)).Methods(http.MethodGet)
+
+	serviceExpr := constants.ServiceApiEndpoint + "/{serviceId}"
+	apiClientExpr := serviceExpr + constants.ApiClientResourceEndpoint + "/{apiClientId}"
+	api.HandleFunc(constants.ServiceApiEndpoint, s.handle(s.getServices)).Methods(http.MethodGet)
+	api.HandleFunc(serviceExpr, s.handle(s.getService)).Methods(http.MethodGet)
+	api.HandleFunc(serviceExpr+constants.ApiClientResourceEndpoint, s.handle(s.createApiClient)).Methods(http.MethodPost)
+	api.HandleFunc(serviceExpr+constants.ApiClientResourceEndpoint, s.handle(s.getApiClients)).Methods(http.MethodGet)
+	api.HandleFunc(apiClientExpr, s.handle(s.getApiClient)).Methods(http.MethodGet)
+	api.HandleFunc(apiClientExpr, s.handle(s.updateApiClient)).Methods(http.MethodPut)
+	api.HandleFunc(apiClientExpr, s.handle(s.deleteApiClient)).Methods(http.MethodDelete)
+	api.HandleFunc(apiClientExpr+constants.PolicyApiEndpoint, s.handle(s.getApiClientPolicies)).Methods(http.MethodGet)
+	api.HandleFunc(apiClientExpr+constants.TagApiEndpoint, s.handle(s.getApiClientTags)).Methods(http.MethodGet)
+
+	userExpr := constants.UserApiEndpoint + "/{userId}"
+	api.HandleFunc(constants.UserApiEndpoint, s.handle(s.createUser)).Methods(http.MethodPost)
+	api.HandleFunc(constants.UserApiEndpoint, s.handle(s.getUsers)).Methods(http.MethodGet)
+	api.HandleFunc(userExpr, s.handle(s.updateUserRole)).Methods(http.MethodPut)
+	api.HandleFunc(userExpr, s.handle(s.deleteUser)).Methods(http.MethodDelete)
+
+	api.HandleFunc(constants.TagApiEndpoint, s.handle(s.createTag)).Methods(http.MethodPost)
+	api.HandleFunc(constants.TagApiEndpoint, s.handle(s.getTags)).Methods(http.MethodGet)
+	api.HandleFunc(constants.TagApiEndpoint+"/{tagId}", s.handle(s.deleteTag)).Methods(http.MethodDelete)
+
+	policyExpr := constants.PolicyApiEndpoint + "/{policyId}"
+	api.HandleFunc(constants.PolicyApiEndpoint, s.handle(s.createPolicy)).Methods(http.MethodPost)
+	api.HandleFunc(constants.PolicyApiEndpoint, s.handle(s.getPolicies)).Methods(http.MethodGet)
+	api.HandleFunc(policyExpr, s.handle(s.getPolicy)).Methods(http.MethodGet)
+	api.HandleFunc(policyExpr, s.handle(s.updatePolicy)).Methods(http.MethodPut)
+	api.HandleFunc(policyExpr, s.handle(s.deletePolicy)).Methods(http.MethodDelete)
+
+	settingsExpr := constants.TenantsApiEndpoint + constants.SettingsEndpoint
+	api.HandleFunc(settingsExpr, s.handle(s.getTenantSettings)).Methods(http.MethodGet)
+	api.HandleFunc(settingsExpr, s.handle(s.updateTenantSettings)).Methods(http.MethodPut)
+	return r
+}
+
+// handle serves the requests one at a time so that the state is consistent
+func (s *Server) handle(h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		statusCode, body, err := h(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, statusCode, body)
+	}
+}
+
+// responseIds answers with the request ID sent by the client and a trace ID, as Trust Authority does
+func responseIds(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestId := r.Header.Get(constants.HTTPHeaderKeyRequestId); requestId != "" {
+			w.Header().Set(constants.HTTPHeaderKeyRequestId, requestId)
+		}
+		traceId := strings.ReplaceAll(uuid.NewString(), "-", "")
+		if traceParent, err := client.ParseTraceParent(r.Header.Get(constants.HTTPHeaderKeyTraceParent)); err == nil {
+			traceId = traceParent.TraceIdString()
+		}
+		w.Header().Set(constants.HTTPHeaderKeyTraceId, traceId)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireApiKey rejects the requests without an API key, any API key is accepted
+func requireApiKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(constants.HTTPHeaderKeyApiKey) == "" {
+			writeError(w, newError(http.StatusUnauthorized, "API key is missing"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(statusCode)
+		return
+	}
+	content, err := json.Marshal(body)
+	if err != nil {
+		writeError(w, newError(http.StatusInternalServerError, "Failed to encode response: %s", err.Error()))
+		return
+	}
+	w.Header().Set(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(content)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{statusCode: http.StatusInternalServerError, message: err.Error()}
+	}
+	content, _ := json.Marshal(map[string]string{"message": apiErr.message})
+	w.Header().Set(constants.HTTPHeaderKeyContentType, constants.HTTPMediaTypeJson)
+	w.WriteHeader(apiErr.statusCode)
+	_, _ = w.Write(content)
+}
+
+// decode reads the JSON body of the request
+func decode(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return newError(http.StatusBadRequest, "Invalid request body: %s", err.Error())
+	}
+	return nil
+}
+
+// pathId returns the ID of the path variable of the request
+func pathId(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(mux.Vars(r)[name])
+	if err != nil {
+		return uuid.Nil, newError(http.StatusBadRequest, "Invalid %s %q", name, mux.Vars(r)[name])
+	}
+	return id, nil
+}
+
+func newIdIfNil(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return uuid.New()
+	}
+	return id
+}
+
+func nowIfZero(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now().UTC()
+	}
+	return t
+}
+
+// newApiKey generates the key of an api client
+func newApiKey() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
+func policyHash(policy string) string {
+	hash := sha256.Sum256([]byte(policy))
+	return hex.EncodeToString(hash[:])
+}
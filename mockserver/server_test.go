@@ -0,0 +1,216 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mockserver
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"intel/tac/v1/sdk"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const seedFile = `
+service_offers:
+  - id: 0a3d7720-08ab-421c-b8d4-1725c358f03e
+    name: Seeded Offer
+plans:
+  - id: 0f2a20fa-b08d-48a8-b2b4-2ebd1feb6f74
+    service_offer_id: 0a3d7720-08ab-421c-b8d4-1725c358f03e
+    name: Tiny
+    max_key: 1
+    max_policy: 1
+products:
+  - id: 0169d34f-58ce-4717-9b3a-5c66abd33417
+    service_offer_id: 0a3d7720-08ab-421c-b8d4-1725c358f03e
+    plan_id: 0f2a20fa-b08d-48a8-b2b4-2ebd1feb6f74
+    name: Developer
+    product_type: attestation
+services:
+  - id: 0cfb6af4-59ac-4a14-8b83-bd65b1e11777
+    service_offer_id: 0a3d7720-08ab-421c-b8d4-1725c358f03e
+    plan_id: 0f2a20fa-b08d-48a8-b2b4-2ebd1feb6f74
+    name: Seeded Service
+tags:
+  - name: Workload
+    predefined: true
+users:
+  - email: admin@example.com
+    role:
+      name: Tenant Admin
+tenant_settings:
+  attest_failure_email: admin@example.com
+`
+
+func newTestClient(t *testing.T, seed *Seed) *sdk.Client {
+	server, err := New(seed)
+	assert.NoError(t, err)
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	taClient, err := sdk.New(sdk.WithBaseUrl(httpServer.URL), sdk.WithApiKey("key"),
+		sdk.WithRetry(client.RetryOptions{Max: 0}))
+	assert.NoError(t, err)
+	return taClient
+}
+
+func TestMockServerState(t *testing.T) {
+	taClient := newTestClient(t, nil)
+	ctx := context.Background()
+	seed := DefaultSeed()
+	serviceId, productId := seed.Services[0].ID, seed.Products[0].ID
+
+	services, err := taClient.ListServices(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, services, 1)
+	plan, err := taClient.GetPlan(ctx, seed.ServiceOffers[0].ID, seed.Plans[0].ID)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Products, 1)
+
+	policy, err := taClient.CreatePolicy(ctx, &models.PolicyRequest{CommonPolicy: models.CommonPolicy{
+		Policy:          "default matches_sgx_policy = false",
+		PolicyName:      "Sample_Policy_SGX",
+		PolicyType:      constants.AppraisalPolicyType,
+		ServiceOfferId:  seed.ServiceOffers[0].ID,
+		AttestationType: constants.SgxAttestationType,
+	}})
+	assert.NoError(t, err)
+	_, err = taClient.CreateTag(ctx, &models.TagCreate{Name: "Power"})
+	assert.NoError(t, err)
+
+	apiClient, err := taClient.CreateApiClient(ctx, &models.CreateApiClient{
+		ProductId:    productId,
+		ServiceId:    serviceId,
+		PolicyIds:    []uuid.UUID{policy.PolicyId},
+		TagIdsValues: []models.ApiClientTagIdValue{{Key: "Power", Value: "high"}},
+		Name:         "ci-client",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, apiClient.Keys, 1)
+	assert.Equal(t, models.ApiClientStatus(constants.ApiClientStatusActive), apiClient.Status)
+
+	retrieved, err := taClient.GetApiClient(ctx, serviceId, apiClient.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{policy.PolicyId}, retrieved.PolicyIds)
+	assert.Equal(t, "Power", retrieved.TagsValues[0].Name)
+
+	err = taClient.DeletePolicy(ctx, policy.PolicyId)
+	assert.True(t, client.HasStatus(err, http.StatusConflict), "Test deleting a policy linked to an api client")
+
+	_, err = taClient.UpdateApiClient(ctx, apiClient.ID, &models.UpdateApiClient{ServiceId: serviceId, ProductId: productId})
+	assert.NoError(t, err)
+	assert.NoError(t, taClient.DeletePolicy(ctx, policy.PolicyId))
+	policies, err := taClient.ListPolicies(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+
+	assert.NoError(t, taClient.DeleteApiClient(ctx, serviceId, apiClient.ID))
+	_, err = taClient.GetApiClient(ctx, serviceId, apiClient.ID)
+	assert.True(t, client.HasStatus(err, http.StatusNotFound), "Test retrieving a deleted api client")
+
+	user, err := taClient.CreateUser(ctx, &models.CreateTenantUser{Email: "user@example.com", Role: constants.UserRole})
+	assert.NoError(t, err)
+	_, err = taClient.CreateUser(ctx, &models.CreateTenantUser{Email: "user@example.com", Role: constants.UserRole})
+	assert.True(t, client.HasStatus(err, http.StatusConflict), "Test creating a user twice")
+	updated, err := taClient.UpdateUserRole(ctx, &models.UpdateTenantUserRoles{UserId: user.ID, Role: constants.TenantAdminRole})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.TenantAdminRole, updated.Role.Name)
+	assert.NoError(t, taClient.DeleteUser(ctx, user.ID))
+	users, err := taClient.ListUsers(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	tags, err := taClient.ListTags(ctx)
+	assert.NoError(t, err)
+	err = taClient.DeleteTag(ctx, *tags.Tags[0].ID)
+	assert.True(t, client.HasStatus(err, http.StatusBadRequest), "Test deleting a predefined tag")
+}
+
+func TestMockServerSeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(seedFile), 0600))
+	seed, err := LoadSeed(path)
+	assert.NoError(t, err)
+	taClient := newTestClient(t, seed)
+	ctx := context.Background()
+	serviceId := seed.Services[0].ID
+
+	service, err := taClient.GetService(ctx, serviceId)
+	assert.NoError(t, err)
+	assert.Equal(t, "Seeded Offer", service.ServiceOfferName)
+	assert.Equal(t, "Tiny", service.PlanName)
+	settings, err := taClient.GetTenantSettings(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin@example.com", settings.AttestationFailureEmail)
+
+	// the plan allows a single api client and a single policy
+	request := &models.CreateApiClient{ProductId: seed.Products[0].ID, ServiceId: serviceId, Name: "first"}
+	_, err = taClient.CreateApiClient(ctx, request)
+	assert.NoError(t, err)
+	request.Name = "second"
+	_, err = taClient.CreateApiClient(ctx, request)
+	assert.True(t, client.HasStatus(err, http.StatusConflict), "Test exceeding the api clients of the plan")
+
+	policy := &models.PolicyRequest{CommonPolicy: models.CommonPolicy{Policy: "default allow = true", PolicyName: "first",
+		PolicyType: constants.AppraisalPolicyType, ServiceOfferId: seed.ServiceOffers[0].ID,
+		AttestationType: constants.SgxAttestationType}}
+	_, err = taClient.CreatePolicy(ctx, policy)
+	assert.NoError(t, err)
+	policy.PolicyName = "second"
+	_, err = taClient.CreatePolicy(ctx, policy)
+	assert.True(t, client.HasStatus(err, http.StatusConflict), "Test exceeding the policies of the plan")
+}
+
+func TestMockServerInvalidSeed(t *testing.T) {
+	tt := []struct {
+		seed        string
+		description string
+	}{
+		{
+			seed:        "services: [",
+			description: "Test a seed file which is not YAML",
+		},
+		{
+			seed:        "servics: []",
+			description: "Test a seed file with an unknown field",
+		},
+		{
+			seed: `services:
+  - name: Orphan
+    service_offer_id: 0a3d7720-08ab-421c-b8d4-1725c358f03e`,
+			description: "Test a seed file with a service of an unknown service offer",
+		},
+	}
+
+	for _, tc := range tt {
+		path := filepath.Join(t.TempDir(), "seed.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(tc.seed), 0600))
+		seed, err := LoadSeed(path)
+		if err == nil {
+			_, err = New(seed)
+		}
+		assert.Error(t, err, tc.description)
+	}
+}
+
+func TestMockServerApiKey(t *testing.T) {
+	server, err := New(nil)
+	assert.NoError(t, err)
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	resp, err := http.Get(httpServer.URL + constants.TmsBaseUrl + constants.ServiceApiEndpoint)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package mockserver
+
+import (
+	"github.com/google/uuid"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"net/http"
+)
+
+func (s *Server) serviceOffer(id uuid.UUID) *models.ServiceOffer {
+	for i := range s.serviceOffers {
+		if s.serviceOffers[i].ID == id {
+			return &s.serviceOffers[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) plan(id uuid.UUID) *models.Plan {
+	for i := range s.plans {
+		if s.plans[i].ID == id {
+			return &s.plans[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) product(id uuid.UUID) *models.Product {
+	for i := range s.products {
+		if s.products[i].ID == id {
+			return &s.products[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) service(id uuid.UUID) *models.Service {
+	for i := range s.services {
+		if s.services[i].ID == id {
+			return &s.services[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) tag(id uuid.UUID) *models.Tag {
+	for _, tag := range s.tags {
+		if *tag.ID == id {
+			return tag
+		}
+	}
+	return nil
+}
+
+func (s *Server) tagByName(name string) *models.Tag {
+	for _, tag := range s.tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+func (s *Server) policy(id uuid.UUID) *models.PolicyResponse {
+	for _, policy := range s.policies {
+		if policy.PolicyId == id {
+			return policy
+		}
+	}
+	return nil
+}
+
+// serviceOfferPlan returns the plan of the tenant's service subscribed to the service offer, nil if there is none
+func (s *Server) serviceOfferPlan(serviceOfferId uuid.UUID) *models.Plan {
+	for _, service := range s.services {
+		if service.ServiceOfferId == serviceOfferId {
+			return s.plan(service.PlanId)
+		}
+	}
+	return nil
+}
+
+func (s *Server) serviceOfferPolicies(serviceOfferId uuid.UUID) []*models.PolicyResponse {
+	var policies []*models.PolicyResponse
+	for _, policy := range s.policies {
+		if policy.ServiceOfferId == serviceOfferId {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+func (s *Server) serviceApiClients(serviceId uuid.UUID) []*models.ApiClientDetail {
+	var apiClients []*models.ApiClientDetail
+	for _, apiClient := range s.apiClients {
+		if apiClient.ServiceId == serviceId {
+			apiClients = append(apiClients, apiClient)
+		}
+	}
+	return apiClients
+}
+
+func (s *Server) pathServiceOffer(r *http.Request) (*models.ServiceOffer, error) {
+	id, err := pathId(r, "serviceOfferId")
+	if err != nil {
+		return nil, err
+	}
+	serviceOffer := s.serviceOffer(id)
+	if serviceOffer == nil {
+		return nil, newError(http.StatusNotFound, "Service offer %s not found", id)
+	}
+	return serviceOffer, nil
+}
+
+func (s *Server) pathService(r *http.Request) (*models.Service, error) {
+	id, err := pathId(r, "serviceId")
+	if err != nil {
+		return nil, err
+	}
+	service := s.service(id)
+	if service == nil {
+		return nil, newError(http.StatusNotFound, "Service %s not found", id)
+	}
+	return service, nil
+}
+
+func (s *Server) pathApiClient(r *http.Request) (*models.ApiClientDetail, error) {
+	service, err := s.pathService(r)
+	if err != nil {
+		return nil, err
+	}
+	id, err := pathId(r, "apiClientId")
+	if err != nil {
+		return nil, err
+	}
+	for _, apiClient := range s.serviceApiClients(service.ID) {
+		if apiClient.ID == id {
+			return apiClient, nil
+		}
+	}
+	return nil, newError(http.StatusNotFound, "Api client %s not found", id)
+}
+
+func (s *Server) pathUser(r *http.Request) (*models.TenantUser, error) {
+	id, err := pathId(r, "userId")
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range s.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, newError(http.StatusNotFound, "User %s not found", id)
+}
+
+func (s *Server) pathPolicy(r *http.Request) (*models.PolicyResponse, error) {
+	id, err := pathId(r, "policyId")
+	if err != nil {
+		return nil, err
+	}
+	policy := s.policy(id)
+	if policy == nil {
+		return nil, newError(http.StatusNotFound, "Policy %s not found", id)
+	}
+	return policy, nil
+}
+
+// setApiClient sets the product, policies and tags of the api client of the service after checking that they exist
+// and belong to the service offer of the service
+func (s *Server) setApiClient(service *models.Service, apiClient *models.ApiClientDetail, productId uuid.UUID,
+	policyIds []uuid.UUID, tagValues []models.ApiClientTagIdValue) error {
+	if apiClient.Name == "" {
+		return newError(http.StatusBadRequest, "Api client name is required")
+	}
+	for _, other := range s.serviceApiClients(service.ID) {
+		if other.ID != apiClient.ID && other.Name == apiClient.Name {
+			return newError(http.StatusConflict, "Api client %q already exists", apiClient.Name)
+		}
+	}
+	if apiClient.Status == "" {
+		apiClient.Status = constants.ApiClientStatusActive
+	}
+	if !apiClientStatuses[apiClient.Status] {
+		return newError(http.StatusBadRequest, "Invalid api client status %q", apiClient.Status)
+	}
+
+	product := s.product(productId)
+	if product == nil || product.ServiceOfferId != service.ServiceOfferId {
+		return newError(http.StatusBadRequest, "Product %s not found for service %s", productId, service.ID)
+	}
+	for _, policyId := range policyIds {
+		policy := s.policy(policyId)
+		if policy == nil || policy.ServiceOfferId != service.ServiceOfferId {
+			return newError(http.StatusBadRequest, "Policy %s not found for service %s", policyId, service.ID)
+		}
+	}
+	tagsValues := []models.ApiClientTagValue{}
+	for _, tagValue := range tagValues {
+		tag := s.tagByName(tagValue.Key)
+		if tagId, err := uuid.Parse(tagValue.Key); tag == nil && err == nil {
+			tag = s.tag(tagId)
+		}
+		if tag == nil {
+			return newError(http.StatusBadRequest, "Tag %q not found", tagValue.Key)
+		}
+		tagsValues = append(tagsValues, models.ApiClientTagValue{Name: tag.Name, Value: tagValue.Value, Predefined: tag.Predefined})
+	}
+
+	apiClient.ProductId = product.ID
+	apiClient.ProductName = product.Name
+	apiClient.ProductType = product.ProductType
+	apiClient.ServiceOfferName = s.serviceOffer(service.ServiceOfferId).Name
+	apiClient.PolicyIds = append([]uuid.UUID{}, policyIds...)
+	apiClient.TagsValues = tagsValues
+	return nil
+}
+
+// validatePolicy checks the fields of a policy created or updated
+func (s *Server) validatePolicy(policy *models.CommonPolicy) error {
+	if policy.PolicyName == "" || policy.Policy == "" {
+		return newError(http.StatusBadRequest, "Policy name and policy are required")
+	}
+	if s.serviceOffer(policy.ServiceOfferId) == nil {
+		return newError(http.StatusBadRequest, "Service offer %s of policy %q not found", policy.ServiceOfferId, policy.PolicyName)
+	}
+	for _, other := range s.policies {
+		if other.PolicyId != policy.PolicyId && other.PolicyName == policy.PolicyName {
+			return newError(http.StatusConflict, "Policy %q already exists", policy.PolicyName)
+		}
+	}
+	return nil
+}
+
+func apiClientSummary(apiClient *models.ApiClientDetail) models.ApiClient {
+	return models.ApiClient{
+		ID:          apiClient.ID,
+		ServiceId:   apiClient.ServiceId,
+		ProductId:   apiClient.ProductId,
+		ProductName: apiClient.ProductName,
+		Status:      apiClient.Status,
+		Name:        apiClient.Name,
+		CreatedAt:   apiClient.CreatedAt,
+		ProductType: apiClient.ProductType,
+	}
+}
+
+// remove returns the items without the one provided
+func remove[T any](items []*T, item *T) []*T {
+	for i := range items {
+		if items[i] == item {
+			return append(items[:i], items[i+1:]...)
+		}
+	}
+	return items
+}
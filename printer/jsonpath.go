@@ -0,0 +1,525 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a parsed JSONPath template in the format used by kubectl, e.g. {.[*].id}, {.[0:10:2].id} or
+// {range .[*]}{.name}{"\t"}{.id}{"\n"}{end}. The template is executed on the JSON representation of the response.
+type jsonPath struct {
+	nodes []templateNode
+}
+
+type nodeKind int
+
+const (
+	textNode nodeKind = iota
+	pathNode
+	rangeNode
+)
+
+type templateNode struct {
+	kind  nodeKind
+	text  string
+	steps []pathStep
+	body  []templateNode
+}
+
+type stepKind int
+
+const (
+	fieldStep stepKind = iota
+	recursiveStep
+	wildcardStep
+	indexStep
+	sliceStep
+	filterStep
+)
+
+type pathStep struct {
+	kind  stepKind
+	name  string
+	index int
+	start *int
+	end   *int
+	// stride is the step of a slice [start:end:step], every item is selected when it is zero
+	stride int
+
+	// filter expression [?(@.key == value)], a filter without operator matches when the key is present
+	filterPath  []pathStep
+	filterOp    string
+	filterValue string
+}
+
+func parseJSONPath(template string) (*jsonPath, error) {
+	var stack [][]templateNode
+	var rangeSteps [][]pathStep
+	var current []templateNode
+
+	for len(template) > 0 {
+		open := strings.Index(template, "{")
+		if open < 0 {
+			current = append(current, templateNode{kind: textNode, text: template})
+			break
+		}
+		if open > 0 {
+			current = append(current, templateNode{kind: textNode, text: template[:open]})
+		}
+
+		closing, err := findActionEnd(template, open)
+		if err != nil {
+			return nil, err
+		}
+		action := strings.TrimSpace(template[open+1 : closing])
+		template = template[closing+1:]
+
+		switch {
+		case action == "end":
+			if len(stack) == 0 {
+				return nil, errors.New("Invalid JSONPath template: {end} without matching {range}")
+			}
+			node := templateNode{kind: rangeNode, steps: rangeSteps[len(rangeSteps)-1], body: current}
+			current = append(stack[len(stack)-1], node)
+			stack = stack[:len(stack)-1]
+			rangeSteps = rangeSteps[:len(rangeSteps)-1]
+		case strings.HasPrefix(action, "range ") || strings.HasPrefix(action, "range\t"):
+			steps, err := parsePath(strings.TrimSpace(action[len("range"):]))
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, current)
+			rangeSteps = append(rangeSteps, steps)
+			current = nil
+		case strings.HasPrefix(action, `"`) || strings.HasPrefix(action, `'`):
+			text, err := unquote(action)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Invalid string literal %s in JSONPath template", action)
+			}
+			current = append(current, templateNode{kind: textNode, text: text})
+		default:
+			steps, err := parsePath(action)
+			if err != nil {
+				return nil, err
+			}
+			current = append(current, templateNode{kind: pathNode, steps: steps})
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, errors.New("Invalid JSONPath template: {range} without matching {end}")
+	}
+	return &jsonPath{nodes: current}, nil
+}
+
+// findActionEnd returns the index of the closing brace of the action starting at open, skipping quoted strings
+func findActionEnd(template string, open int) (int, error) {
+	var quote byte
+	for i := open + 1; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '}':
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("Invalid JSONPath template: unclosed action %q", template[open:])
+}
+
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", errors.New("unterminated string")
+		}
+		return s[1 : len(s)-1], nil
+	}
+	return strconv.Unquote(s)
+}
+
+// parsePath parses a path expression like .a.b[0].c, ..name or .[*].id into the list of steps
+func parsePath(expr string) ([]pathStep, error) {
+	original := expr
+	expr = strings.TrimPrefix(expr, "$")
+	expr = strings.TrimPrefix(expr, "@")
+
+	var steps []pathStep
+	for len(expr) > 0 {
+		switch {
+		case strings.HasPrefix(expr, ".."):
+			name, rest := readName(expr[2:])
+			if name == "" {
+				return nil, errors.Errorf("Invalid JSONPath expression %q: missing field name after ..", original)
+			}
+			steps = append(steps, pathStep{kind: recursiveStep, name: name})
+			expr = rest
+		case strings.HasPrefix(expr, "."):
+			name, rest := readName(expr[1:])
+			if name == "*" {
+				steps = append(steps, pathStep{kind: wildcardStep})
+			} else if name != "" {
+				steps = append(steps, pathStep{kind: fieldStep, name: name})
+			}
+			expr = rest
+		case strings.HasPrefix(expr, "["):
+			closing, err := findBracketEnd(expr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Invalid JSONPath expression %q", original)
+			}
+			step, err := parseBracket(strings.TrimSpace(expr[1:closing]))
+			if err != nil {
+				return nil, errors.Wrapf(err, "Invalid JSONPath expression %q", original)
+			}
+			steps = append(steps, step)
+			expr = expr[closing+1:]
+		default:
+			return nil, errors.Errorf("Invalid JSONPath expression %q: unexpected %q", original, expr)
+		}
+	}
+	return steps, nil
+}
+
+func readName(expr string) (string, string) {
+	end := strings.IndexAny(expr, ".[")
+	if end < 0 {
+		return expr, ""
+	}
+	return expr[:end], expr[end:]
+}
+
+func findBracketEnd(expr string) (int, error) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, errors.New("unclosed [")
+}
+
+func parseBracket(content string) (pathStep, error) {
+	switch {
+	case content == "*":
+		return pathStep{kind: wildcardStep}, nil
+	case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+		name, err := unquote(content)
+		if err != nil {
+			return pathStep{}, err
+		}
+		return pathStep{kind: fieldStep, name: name}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		return parseFilter(strings.TrimSpace(content[2 : len(content)-1]))
+	case strings.Contains(content, ":"):
+		parts := strings.Split(content, ":")
+		if len(parts) > 3 {
+			return pathStep{}, errors.Errorf("invalid slice %q", content)
+		}
+		step := pathStep{kind: sliceStep}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return pathStep{}, errors.Errorf("invalid slice bound %q", part)
+			}
+			switch i {
+			case 0:
+				step.start = &n
+			case 1:
+				step.end = &n
+			default:
+				if n <= 0 {
+					return pathStep{}, errors.Errorf("invalid slice step %d, should be positive", n)
+				}
+				step.stride = n
+			}
+		}
+		return step, nil
+	}
+	n, err := strconv.Atoi(content)
+	if err != nil {
+		return pathStep{}, errors.Errorf("invalid array index %q", content)
+	}
+	return pathStep{kind: indexStep, index: n}, nil
+}
+
+func parseFilter(expr string) (pathStep, error) {
+	step := pathStep{kind: filterStep}
+	left := expr
+	for _, op := range []string{"==", "!="} {
+		if i := strings.Index(expr, op); i >= 0 {
+			left = strings.TrimSpace(expr[:i])
+			step.filterOp = op
+			value := strings.TrimSpace(expr[i+len(op):])
+			if strings.HasPrefix(value, "'") || strings.HasPrefix(value, `"`) {
+				var err error
+				if value, err = unquote(value); err != nil {
+					return pathStep{}, err
+				}
+			}
+			step.filterValue = value
+			break
+		}
+	}
+	if !strings.HasPrefix(left, "@") {
+		return pathStep{}, errors.Errorf("filter %q should start with @", expr)
+	}
+	var err error
+	if step.filterPath, err = parsePath(left); err != nil {
+		return pathStep{}, err
+	}
+	return step, nil
+}
+
+// Execute renders the template for the JSON representation of v
+func (jp *jsonPath) Execute(w io.Writer, v interface{}) error {
+	data, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	return jp.render(w, jp.nodes, data)
+}
+
+func (jp *jsonPath) render(w io.Writer, nodes []templateNode, current interface{}) error {
+	for _, node := range nodes {
+		switch node.kind {
+		case textNode:
+			if _, err := io.WriteString(w, node.text); err != nil {
+				return err
+			}
+		case pathNode:
+			results, err := evaluate(node.steps, current)
+			if err != nil {
+				return err
+			}
+			var values []string
+			for _, result := range results {
+				values = append(values, formatResult(result))
+			}
+			if _, err = io.WriteString(w, strings.Join(values, " ")); err != nil {
+				return err
+			}
+		case rangeNode:
+			results, err := evaluate(node.steps, current)
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				if err = jp.render(w, node.body, result); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func evaluate(steps []pathStep, current interface{}) ([]interface{}, error) {
+	values := []interface{}{current}
+	for _, step := range steps {
+		var next []interface{}
+		for _, value := range values {
+			results, err := applyStep(step, value)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, results...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func applyStep(step pathStep, value interface{}) ([]interface{}, error) {
+	switch step.kind {
+	case fieldStep:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("%s is not found, value is not an object", step.name)
+		}
+		field, ok := obj[step.name]
+		if !ok {
+			return nil, errors.Errorf("%s is not found", step.name)
+		}
+		return []interface{}{field}, nil
+	case recursiveStep:
+		return collect(step.name, value), nil
+	case wildcardStep:
+		switch val := value.(type) {
+		case []interface{}:
+			return val, nil
+		case map[string]interface{}:
+			var results []interface{}
+			for _, key := range sortedKeys(val) {
+				results = append(results, val[key])
+			}
+			return results, nil
+		}
+		return nil, nil
+	case indexStep:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("index [%d] can only be applied to an array", step.index)
+		}
+		index := step.index
+		if index < 0 {
+			index += len(list)
+		}
+		if index < 0 || index >= len(list) {
+			return nil, errors.Errorf("array index [%d] is out of bounds", step.index)
+		}
+		return []interface{}{list[index]}, nil
+	case sliceStep:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.New("slice can only be applied to an array")
+		}
+		start, end := 0, len(list)
+		if step.start != nil {
+			start = clamp(*step.start, len(list))
+		}
+		if step.end != nil {
+			end = clamp(*step.end, len(list))
+		}
+		if start >= end {
+			return nil, nil
+		}
+		if step.stride <= 1 {
+			return list[start:end], nil
+		}
+		var results []interface{}
+		for i := start; i < end; i += step.stride {
+			results = append(results, list[i])
+		}
+		return results, nil
+	case filterStep:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.New("filter can only be applied to an array")
+		}
+		var results []interface{}
+		for _, item := range list {
+			matches, err := evaluate(step.filterPath, item)
+			if err != nil || len(matches) == 0 {
+				continue
+			}
+			switch step.filterOp {
+			case "":
+				results = append(results, item)
+			case "==":
+				if formatResult(matches[0]) == step.filterValue {
+					results = append(results, item)
+				}
+			case "!=":
+				if formatResult(matches[0]) != step.filterValue {
+					results = append(results, item)
+				}
+			}
+		}
+		return results, nil
+	}
+	return nil, nil
+}
+
+func collect(name string, value interface{}) []interface{} {
+	var results []interface{}
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(val) {
+			if key == name {
+				results = append(results, val[key])
+			}
+			results = append(results, collect(name, val[key])...)
+		}
+	case []interface{}:
+		for _, item := range val {
+			results = append(results, collect(name, item)...)
+		}
+	}
+	return results
+}
+
+func clamp(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatResult(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	}
+	valBytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(valBytes)
+}
+
+// toGeneric converts the response to the generic JSON representation which uses the field names of the json tags
+func toGeneric(v interface{}) (interface{}, error) {
+	responseBytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling response to JSON")
+	}
+	var data interface{}
+	dec := json.NewDecoder(bytes.NewReader(responseBytes))
+	dec.UseNumber()
+	if err = dec.Decode(&data); err != nil {
+		return nil, errors.Wrap(err, "Error unmarshalling response")
+	}
+	return data, nil
+}
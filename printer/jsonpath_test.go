@@ -0,0 +1,273 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var jsonPathTestData = json.RawMessage(`{
+	"items": [
+		{"name": "sgx-policy", "id": 1, "type": "sgx", "labels": {"team": "a"}},
+		{"name": "tdx-policy", "id": 2, "type": "tdx"},
+		{"name": "other-policy", "id": 3, "type": "sgx", "labels": {"team": "b"}},
+		{"name": "last-policy", "id": 4, "type": "tdx", "enabled": true}
+	],
+	"dotted.key": "dotted",
+	"count": 4
+}`)
+
+func TestJSONPath(t *testing.T) {
+	tt := []struct {
+		template    string
+		want        string
+		description string
+	}{
+		{
+			template:    "{.count}",
+			want:        "4",
+			description: "Test a field",
+		},
+		{
+			template:    "count: {$.count}",
+			want:        "count: 4",
+			description: "Test text around a path from the root",
+		},
+		{
+			template:    "{.items[0].name}",
+			want:        "sgx-policy",
+			description: "Test an array index",
+		},
+		{
+			template:    "{.items[-1].name}",
+			want:        "last-policy",
+			description: "Test a negative array index",
+		},
+		{
+			template:    "{.items[*].id}",
+			want:        "1 2 3 4",
+			description: "Test a wildcard",
+		},
+		{
+			template:    "{.items[0].labels}",
+			want:        `{"team":"a"}`,
+			description: "Test an object printed as JSON",
+		},
+		{
+			template:    "{.items[1:3].id}",
+			want:        "2 3",
+			description: "Test a slice",
+		},
+		{
+			template:    "{.items[-2:].id}",
+			want:        "3 4",
+			description: "Test a slice with a negative start",
+		},
+		{
+			template:    "{.items[:-3].id}",
+			want:        "1",
+			description: "Test a slice with a negative end",
+		},
+		{
+			template:    "{.items[0:4:2].id}",
+			want:        "1 3",
+			description: "Test a slice with a step",
+		},
+		{
+			template:    "{.items[::3].id}",
+			want:        "1 4",
+			description: "Test a slice with a step and no bounds",
+		},
+		{
+			template:    "{.items[5:].id}",
+			want:        "",
+			description: "Test a slice out of bounds",
+		},
+		{
+			template:    `{.items[?(@.type == "sgx")].name}`,
+			want:        "sgx-policy other-policy",
+			description: "Test a filter on a value",
+		},
+		{
+			template:    "{.items[?(@.type != 'sgx')].id}",
+			want:        "2 4",
+			description: "Test a filter excluding a value",
+		},
+		{
+			template:    "{.items[?(@.enabled == true)].name}",
+			want:        "last-policy",
+			description: "Test a filter on a boolean",
+		},
+		{
+			template:    "{.items[?(@.labels)].id}",
+			want:        "1 3",
+			description: "Test a filter on the presence of a field",
+		},
+		{
+			template:    "{..team}",
+			want:        "a b",
+			description: "Test a recursive descent",
+		},
+		{
+			template:    "{$..name}",
+			want:        "sgx-policy tdx-policy other-policy last-policy",
+			description: "Test a recursive descent from the root",
+		},
+		{
+			template:    "{['dotted.key']}",
+			want:        "dotted",
+			description: "Test a quoted key",
+		},
+		{
+			template:    `{.items[0]["name"]}`,
+			want:        "sgx-policy",
+			description: "Test a double quoted key",
+		},
+		{
+			template:    `{range .items[*]}{.name}{"\t"}{.id}{"\n"}{end}`,
+			want:        "sgx-policy\t1\ntdx-policy\t2\nother-policy\t3\nlast-policy\t4\n",
+			description: "Test a range",
+		},
+		{
+			template:    `{range .items[?(@.labels)]}{range .labels.*}{@}{","}{end}{end}`,
+			want:        "a,b,",
+			description: "Test nested ranges",
+		},
+		{
+			template:    `{"{"}{.count}{"}"}`,
+			want:        "{4}",
+			description: "Test braces in string literals",
+		},
+	}
+
+	for _, tc := range tt {
+		jp, err := parseJSONPath(tc.template)
+		if !assert.NoError(t, err, tc.description) {
+			continue
+		}
+		var buf bytes.Buffer
+		assert.NoError(t, jp.Execute(&buf, jsonPathTestData), tc.description)
+		assert.Equal(t, tc.want, buf.String(), tc.description)
+	}
+}
+
+func TestJSONPathErrors(t *testing.T) {
+	tt := []struct {
+		template     string
+		wantParseErr bool
+		wantErr      string
+		description  string
+	}{
+		{
+			template:     "{.count",
+			wantParseErr: true,
+			wantErr:      "unclosed action",
+			description:  "Test an unclosed action",
+		},
+		{
+			template:     "{.items[0}",
+			wantParseErr: true,
+			wantErr:      "unclosed [",
+			description:  "Test an unclosed bracket",
+		},
+		{
+			template:     "{range .items[*]}{.name}",
+			wantParseErr: true,
+			wantErr:      "{range} without matching {end}",
+			description:  "Test a range without end",
+		},
+		{
+			template:     "{.name}{end}",
+			wantParseErr: true,
+			wantErr:      "{end} without matching {range}",
+			description:  "Test an end without range",
+		},
+		{
+			template:     "{.items[a]}",
+			wantParseErr: true,
+			wantErr:      "invalid array index",
+			description:  "Test an invalid array index",
+		},
+		{
+			template:     "{.items[1:x]}",
+			wantParseErr: true,
+			wantErr:      "invalid slice bound",
+			description:  "Test an invalid slice bound",
+		},
+		{
+			template:     "{.items[0:4:0]}",
+			wantParseErr: true,
+			wantErr:      "invalid slice step",
+			description:  "Test a slice step which is not positive",
+		},
+		{
+			template:     "{.items[0:1:2:3]}",
+			wantParseErr: true,
+			wantErr:      "invalid slice",
+			description:  "Test a slice with too many bounds",
+		},
+		{
+			template:     `{.items[?(.type == "sgx")]}`,
+			wantParseErr: true,
+			wantErr:      "should start with @",
+			description:  "Test a filter which does not start with @",
+		},
+		{
+			template:     "{..}",
+			wantParseErr: true,
+			wantErr:      "missing field name",
+			description:  "Test a recursive descent without field name",
+		},
+		{
+			template:     "{count}",
+			wantParseErr: true,
+			wantErr:      "unexpected",
+			description:  "Test a path which does not start with a dot",
+		},
+		{
+			template:     `{"unterminated\"}`,
+			wantParseErr: true,
+			wantErr:      "unclosed action",
+			description:  "Test an unterminated string literal",
+		},
+		{
+			template:    "{.missing}",
+			wantErr:     "missing is not found",
+			description: "Test a missing field",
+		},
+		{
+			template:    "{.count.name}",
+			wantErr:     "value is not an object",
+			description: "Test a field of a value which is not an object",
+		},
+		{
+			template:    "{.count[0]}",
+			wantErr:     "can only be applied to an array",
+			description: "Test an index of a value which is not an array",
+		},
+		{
+			template:    "{.items[9]}",
+			wantErr:     "out of bounds",
+			description: "Test an array index out of bounds",
+		},
+	}
+
+	for _, tc := range tt {
+		jp, err := parseJSONPath(tc.template)
+		if tc.wantParseErr {
+			assert.ErrorContains(t, err, tc.wantErr, tc.description)
+			continue
+		}
+		if !assert.NoError(t, err, tc.description) {
+			continue
+		}
+		var buf bytes.Buffer
+		assert.ErrorContains(t, jp.Execute(&buf, jsonPathTestData), tc.wantErr, tc.description)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package printer
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io"
+	"strings"
+)
+
+// Supported output formats
+const (
+	JSON  = "json"
+	YAML  = "yaml"
+	Table = "table"
+	Wide  = "wide"
+
+	// Template formats take the template (or the path of a file containing it) after "=",
+	// e.g. jsonpath={.[*].policy_id}
+	JSONPath       = "jsonpath"
+	JSONPathFile   = "jsonpath-file"
+	GoTemplate     = "go-template"
+	GoTemplateFile = "go-template-file"
+)
+
+var formats = []string{JSON, YAML, Table, Wide, JSONPath + "=...", JSONPathFile + "=...", GoTemplate + "=...",
+	GoTemplateFile + "=..."}
+
+// Formats returns the list of output formats supported by the printer
+func Formats() []string {
+	return formats
+}
+
+// ValidateFormat checks if the provided output format is supported by the printer
+func ValidateFormat(format string) error {
+	name, arg := splitFormat(format)
+	switch name {
+	case JSON, YAML, Table, Wide:
+		return nil
+	case JSONPath, JSONPathFile, GoTemplate, GoTemplateFile:
+		_, err := newTemplatePrinter(name, arg)
+		return err
+	}
+	return errors.Errorf("Unsupported output format %q, should be one of %s", format, strings.Join(formats, ", "))
+}
+
+// Print writes the response object to the writer in the requested output format
+func Print(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case JSON:
+		return printJSON(w, v)
+	case YAML:
+		return printYAML(w, v)
+	case Table:
+		return printTable(w, v, false)
+	case Wide:
+		return printTable(w, v, true)
+	}
+
+	name, arg := splitFormat(format)
+	switch name {
+	case JSONPath, JSONPathFile, GoTemplate, GoTemplateFile:
+		tp, err := newTemplatePrinter(name, arg)
+		if err != nil {
+			return err
+		}
+		return tp.Execute(w, v)
+	}
+	return ValidateFormat(format)
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	responseBytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Error marshalling response to JSON")
+	}
+	_, err = w.Write(append(responseBytes, '\n'))
+	return err
+}
+
+// printYAML marshals the object to JSON first so that the field names match the json tags of the models,
+// then re-encodes the resulting document as YAML keeping the field order intact
+func printYAML(w io.Writer, v interface{}) error {
+	responseBytes, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "Error marshalling response to JSON")
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(responseBytes, &node); err != nil {
+		return errors.Wrap(err, "Error converting response to YAML")
+	}
+	resetStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err = enc.Encode(&node); err != nil {
+		return errors.Wrap(err, "Error encoding response to YAML")
+	}
+	return enc.Close()
+}
+
+// resetStyle drops the JSON flow and quoting styles so that the document is rendered in block style.
+// The encoder still quotes strings which would otherwise be read back as a different type.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
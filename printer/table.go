@@ -0,0 +1,343 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"intel/tac/v1/models"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// column describes a single column of the table output. Wide columns are printed only for the wide output format.
+type column struct {
+	header string
+	wide   bool
+	value  func(obj interface{}) string
+}
+
+// Tabular is implemented by the objects which define their own table layout, like the results of the manifest and
+// config commands, so that the printer does not depend on the packages returning them
+type Tabular interface {
+	// Columns returns the headers of the table
+	Columns() []string
+	// Row returns the values of the object in the order of the columns
+	Row() []string
+}
+
+// columns holds the table layout for the response models returned by the TMS and PMS clients.
+// Models which are not listed here, and do not implement Tabular, are printed with their top level scalar fields as
+// columns.
+var columns = map[reflect.Type][]column{
+	reflect.TypeOf(models.ApiClient{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.ApiClient).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.ApiClient).Name }},
+		{header: "STATUS", value: func(o interface{}) string { return string(o.(models.ApiClient).Status) }},
+		{header: "PRODUCT", value: func(o interface{}) string { return o.(models.ApiClient).ProductName }},
+		{header: "SERVICE ID", wide: true, value: func(o interface{}) string { return o.(models.ApiClient).ServiceId.String() }},
+		{header: "PRODUCT ID", wide: true, value: func(o interface{}) string { return o.(models.ApiClient).ProductId.String() }},
+		{header: "PRODUCT TYPE", wide: true, value: func(o interface{}) string { return string(o.(models.ApiClient).ProductType) }},
+		{header: "CREATED", wide: true, value: func(o interface{}) string { return formatTime(o.(models.ApiClient).CreatedAt) }},
+	},
+	reflect.TypeOf(models.ApiClientDetail{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.ApiClientDetail).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.ApiClientDetail).Name }},
+		{header: "STATUS", value: func(o interface{}) string { return string(o.(models.ApiClientDetail).Status) }},
+		{header: "PRODUCT", value: func(o interface{}) string { return o.(models.ApiClientDetail).ProductName }},
+		{header: "KEYS", value: func(o interface{}) string { return strings.Join(o.(models.ApiClientDetail).Keys, ",") }},
+		{header: "SERVICE ID", wide: true, value: func(o interface{}) string { return o.(models.ApiClientDetail).ServiceId.String() }},
+		{header: "SERVICE OFFER", wide: true, value: func(o interface{}) string { return o.(models.ApiClientDetail).ServiceOfferName }},
+		{header: "PRODUCT ID", wide: true, value: func(o interface{}) string { return o.(models.ApiClientDetail).ProductId.String() }},
+		{header: "POLICY IDS", wide: true, value: func(o interface{}) string { return joinIds(o.(models.ApiClientDetail).PolicyIds) }},
+		{header: "TAGS", wide: true, value: func(o interface{}) string { return joinTags(o.(models.ApiClientDetail).TagsValues) }},
+		{header: "CREATED", wide: true, value: func(o interface{}) string { return formatTime(o.(models.ApiClientDetail).CreatedAt) }},
+	},
+	reflect.TypeOf(models.ApiClientTagValue{}): {
+		{header: "KEY", value: func(o interface{}) string { return o.(models.ApiClientTagValue).Name }},
+		{header: "VALUE", value: func(o interface{}) string { return o.(models.ApiClientTagValue).Value }},
+		{header: "PREDEFINED", value: func(o interface{}) string { return fmt.Sprint(o.(models.ApiClientTagValue).Predefined) }},
+	},
+	reflect.TypeOf(uuid.UUID{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(uuid.UUID).String() }},
+	},
+	reflect.TypeOf(models.TenantUser{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.TenantUser).ID.String() }},
+		{header: "EMAIL", value: func(o interface{}) string { return o.(models.TenantUser).Email }},
+		{header: "ROLE", value: func(o interface{}) string { return o.(models.TenantUser).Role.Name }},
+		{header: "ACTIVE", value: func(o interface{}) string { return fmt.Sprint(o.(models.TenantUser).Active) }},
+		{header: "ROLE ID", wide: true, value: func(o interface{}) string { return o.(models.TenantUser).Role.ID.String() }},
+		{header: "PRIVACY ACKNOWLEDGED", wide: true, value: func(o interface{}) string {
+			return fmt.Sprint(o.(models.TenantUser).PrivacyAcknowledgement)
+		}},
+		{header: "CREATED", wide: true, value: func(o interface{}) string { return formatTime(o.(models.TenantUser).CreatedAt) }},
+	},
+	reflect.TypeOf(models.PolicyResponse{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.PolicyResponse).PolicyId.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.PolicyResponse).PolicyName }},
+		{header: "TYPE", value: func(o interface{}) string { return o.(models.PolicyResponse).PolicyType }},
+		{header: "ATTESTATION TYPE", value: func(o interface{}) string { return o.(models.PolicyResponse).AttestationType }},
+		{header: "SERVICE OFFER ID", wide: true, value: func(o interface{}) string { return o.(models.PolicyResponse).ServiceOfferId.String() }},
+		{header: "HASH", wide: true, value: func(o interface{}) string { return o.(models.PolicyResponse).PolicyHash }},
+		{header: "CREATED", wide: true, value: func(o interface{}) string { return formatTime(o.(models.PolicyResponse).CreatedAt) }},
+		{header: "MODIFIED", wide: true, value: func(o interface{}) string { return formatTime(o.(models.PolicyResponse).UpdatedAt) }},
+	},
+	reflect.TypeOf(models.Plan{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.Plan).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.Plan).Name }},
+		{header: "MAX KEYS", value: func(o interface{}) string { return fmt.Sprint(o.(models.Plan).MaxKey) }},
+		{header: "MAX POLICIES", value: func(o interface{}) string { return fmt.Sprint(o.(models.Plan).MaxPolicy) }},
+		{header: "SERVICE OFFER ID", wide: true, value: func(o interface{}) string { return o.(models.Plan).ServiceOfferId.String() }},
+		{header: "MAX TENANT ADMINS", wide: true, value: func(o interface{}) string { return fmt.Sprint(o.(models.Plan).MaxTenantAdmin) }},
+		{header: "MAX TENANT USERS", wide: true, value: func(o interface{}) string { return fmt.Sprint(o.(models.Plan).MaxTenantUser) }},
+		{header: "LEDGER", wide: true, value: func(o interface{}) string { return fmt.Sprint(o.(models.Plan).Ledger) }},
+	},
+	reflect.TypeOf(models.PlanProducts{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.PlanProducts).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.PlanProducts).Name }},
+		{header: "MAX KEYS", value: func(o interface{}) string { return fmt.Sprint(o.(models.PlanProducts).MaxKey) }},
+		{header: "MAX POLICIES", value: func(o interface{}) string { return fmt.Sprint(o.(models.PlanProducts).MaxPolicy) }},
+		{header: "PRODUCTS", value: func(o interface{}) string {
+			var names []string
+			for _, product := range o.(models.PlanProducts).Products {
+				names = append(names, product.Name)
+			}
+			return strings.Join(names, ",")
+		}},
+		{header: "SERVICE OFFER ID", wide: true, value: func(o interface{}) string { return o.(models.PlanProducts).ServiceOfferId.String() }},
+		{header: "MAX TENANT ADMINS", wide: true, value: func(o interface{}) string { return fmt.Sprint(o.(models.PlanProducts).MaxTenantAdmin) }},
+		{header: "MAX TENANT USERS", wide: true, value: func(o interface{}) string { return fmt.Sprint(o.(models.PlanProducts).MaxTenantUser) }},
+		{header: "LEDGER", wide: true, value: func(o interface{}) string { return fmt.Sprint(o.(models.PlanProducts).Ledger) }},
+	},
+	reflect.TypeOf(models.Tag{}): {
+		{header: "ID", value: func(o interface{}) string {
+			if id := o.(models.Tag).ID; id != nil {
+				return id.String()
+			}
+			return ""
+		}},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.Tag).Name }},
+		{header: "PREDEFINED", value: func(o interface{}) string { return fmt.Sprint(o.(models.Tag).Predefined) }},
+	},
+	reflect.TypeOf(models.Product{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.Product).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.Product).Name }},
+		{header: "TYPE", value: func(o interface{}) string { return string(o.(models.Product).ProductType) }},
+		{header: "SERVICE OFFER ID", wide: true, value: func(o interface{}) string { return o.(models.Product).ServiceOfferId.String() }},
+		{header: "PLAN ID", wide: true, value: func(o interface{}) string { return o.(models.Product).PlanId.String() }},
+		{header: "LIMIT", wide: true, value: func(o interface{}) string {
+			if policy := o.(models.Product).Policy; policy != nil {
+				return fmt.Sprint(policy.Limit)
+			}
+			return ""
+		}},
+		{header: "QUOTA", wide: true, value: func(o interface{}) string {
+			if policy := o.(models.Product).Policy; policy != nil {
+				return fmt.Sprint(policy.Quota)
+			}
+			return ""
+		}},
+	},
+	reflect.TypeOf(models.ServiceOffer{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.ServiceOffer).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.ServiceOffer).Name }},
+	},
+	reflect.TypeOf(models.Service{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.Service).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.Service).Name }},
+		{header: "PLAN", value: func(o interface{}) string { return o.(models.Service).PlanName }},
+		{header: "ACTIVE", value: func(o interface{}) string { return fmt.Sprint(o.(models.Service).Active) }},
+		{header: "SERVICE OFFER ID", wide: true, value: func(o interface{}) string { return o.(models.Service).ServiceOfferId.String() }},
+		{header: "PLAN ID", wide: true, value: func(o interface{}) string { return o.(models.Service).PlanId.String() }},
+		{header: "TENANT ID", wide: true, value: func(o interface{}) string { return o.(models.Service).TenantId.String() }},
+		{header: "CREATED", wide: true, value: func(o interface{}) string { return formatTime(o.(models.Service).CreatedAt) }},
+	},
+	reflect.TypeOf(models.ServiceDetail{}): {
+		{header: "ID", value: func(o interface{}) string { return o.(models.ServiceDetail).ID.String() }},
+		{header: "NAME", value: func(o interface{}) string { return o.(models.ServiceDetail).Name }},
+		{header: "SERVICE OFFER", value: func(o interface{}) string { return o.(models.ServiceDetail).ServiceOfferName }},
+		{header: "PLAN", value: func(o interface{}) string { return o.(models.ServiceDetail).PlanName }},
+		{header: "ACTIVE", value: func(o interface{}) string { return fmt.Sprint(o.(models.ServiceDetail).Active) }},
+		{header: "SERVICE OFFER ID", wide: true, value: func(o interface{}) string { return o.(models.ServiceDetail).ServiceOfferId.String() }},
+		{header: "PLAN ID", wide: true, value: func(o interface{}) string { return o.(models.ServiceDetail).PlanId.String() }},
+		{header: "CREATED", wide: true, value: func(o interface{}) string { return formatTime(o.(models.ServiceDetail).CreatedAt) }},
+	},
+	reflect.TypeOf(models.AttestationFailureEmail{}): {
+		{header: "ATTESTATION FAILURE EMAIL", value: func(o interface{}) string {
+			return o.(models.AttestationFailureEmail).AttestationFailureEmail
+		}},
+	},
+}
+
+func printTable(w io.Writer, v interface{}, wide bool) error {
+	rows := toRows(v)
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(w, "No resources found")
+		return err
+	}
+
+	cols, ok := columns[reflect.TypeOf(rows[0])]
+	if !ok {
+		if tabular, isTabular := rows[0].(Tabular); isTabular {
+			cols = tabularColumns(tabular)
+		} else {
+			var err error
+			if cols, err = genericColumns(rows[0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	var headers []string
+	for _, col := range cols {
+		if col.wide && !wide {
+			continue
+		}
+		headers = append(headers, col.header)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		var values []string
+		for _, col := range cols {
+			if col.wide && !wide {
+				continue
+			}
+			values = append(values, col.value(row))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// toRows dereferences the response and flattens it into the list of objects to be printed as table rows.
+// Wrapper models like models.Tags are unwrapped to the list they hold.
+func toRows(v interface{}) []interface{} {
+	switch r := v.(type) {
+	case *models.Tags:
+		return toRows(r.Tags)
+	case models.Tags:
+		return toRows(r.Tags)
+	case *models.ApiClientTags:
+		return toRows(r.TagsValues)
+	case models.ApiClientTags:
+		return toRows(r.TagsValues)
+	case *models.ApiClientPolicies:
+		return toRows(r.PolicyIds)
+	case models.ApiClientPolicies:
+		return toRows(r.PolicyIds)
+	}
+
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return []interface{}{val.Interface()}
+	}
+
+	rows := make([]interface{}, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		elem := val.Index(i)
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		rows = append(rows, elem.Interface())
+	}
+	return rows
+}
+
+// tabularColumns builds the columns from the headers of an object implementing Tabular
+func tabularColumns(obj Tabular) []column {
+	var cols []column
+	for i, header := range obj.Columns() {
+		i := i
+		cols = append(cols, column{header: header, value: func(o interface{}) string { return o.(Tabular).Row()[i] }})
+	}
+	return cols
+}
+
+// genericColumns builds the columns from the top level fields of any object which has no table layout registered
+func genericColumns(obj interface{}) ([]column, error) {
+	objBytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling response")
+	}
+
+	var fields map[string]interface{}
+	if err = json.Unmarshal(objBytes, &fields); err != nil {
+		// not an object, print the value as it is
+		return []column{{header: "VALUE", value: func(o interface{}) string { return fmt.Sprint(o) }}}, nil
+	}
+
+	var keys []string
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var cols []column
+	for _, key := range keys {
+		key := key
+		cols = append(cols, column{
+			header: strings.ToUpper(strings.ReplaceAll(key, "_", " ")),
+			value: func(o interface{}) string {
+				objBytes, _ := json.Marshal(o)
+				var fields map[string]interface{}
+				_ = json.Unmarshal(objBytes, &fields)
+				return formatValue(fields[key])
+			},
+		})
+	}
+	return cols, nil
+}
+
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []interface{}, map[string]interface{}:
+		valBytes, _ := json.Marshal(val)
+		return string(valBytes)
+	}
+	return fmt.Sprint(v)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func joinIds(ids []uuid.UUID) string {
+	var idStrings []string
+	for _, id := range ids {
+		idStrings = append(idStrings, id.String())
+	}
+	return strings.Join(idStrings, ",")
+}
+
+func joinTags(tags []models.ApiClientTagValue) string {
+	var tagStrings []string
+	for _, tag := range tags {
+		tagStrings = append(tagStrings, tag.Name+":"+tag.Value)
+	}
+	return strings.Join(tagStrings, ",")
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package printer
+
+import (
+	"bytes"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/models"
+	"testing"
+)
+
+type testResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func (r testResult) Columns() []string {
+	return []string{"NAME", "STATUS"}
+}
+
+func (r testResult) Row() []string {
+	return []string{r.Name, r.Status}
+}
+
+func TestPrintTable(t *testing.T) {
+	offerId := uuid.MustParse("3a3d4c88-4b1c-4d37-9ec4-4c8ba6bdcd6e")
+
+	tt := []struct {
+		response    interface{}
+		want        string
+		description string
+	}{
+		{
+			response:    []models.ServiceOffer{{ID: offerId, Name: "SGX Attestation"}},
+			want:        "ID                                     NAME\n" + offerId.String() + "   SGX Attestation\n",
+			description: "Test the columns registered for a model",
+		},
+		{
+			response:    &[]*testResult{{Name: "apply", Status: "OK"}, {Name: "import", Status: "FAILED"}},
+			want:        "NAME     STATUS\napply    OK\nimport   FAILED\n",
+			description: "Test the columns of a Tabular object",
+		},
+		{
+			response:    map[string]interface{}{"name": "other", "count": 2},
+			want:        "COUNT   NAME\n2       other\n",
+			description: "Test the columns of an object without table layout",
+		},
+		{
+			response:    []string{},
+			want:        "No resources found\n",
+			description: "Test an empty response",
+		},
+	}
+
+	for _, tc := range tt {
+		var buf bytes.Buffer
+		assert.NoError(t, printTable(&buf, tc.response, false), tc.description)
+		assert.Equal(t, tc.want, buf.String(), tc.description)
+	}
+}
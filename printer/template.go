@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package printer
+
+import (
+	"github.com/pkg/errors"
+	"intel/tac/v1/validation"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// templatePrinter executes a user provided template on the response
+type templatePrinter interface {
+	Execute(w io.Writer, v interface{}) error
+}
+
+// goTemplate executes a Go text/template on the JSON representation of the response, so fields are
+// referenced with their JSON names, e.g. {{range .}}{{.policy_id}}{{"\n"}}{{end}}
+type goTemplate struct {
+	tmpl *template.Template
+}
+
+func parseGoTemplate(text string) (*goTemplate, error) {
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid go-template")
+	}
+	return &goTemplate{tmpl: tmpl}, nil
+}
+
+func (gt *goTemplate) Execute(w io.Writer, v interface{}) error {
+	data, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	if err = gt.tmpl.Execute(w, data); err != nil {
+		return errors.Wrap(err, "Error executing go-template")
+	}
+	return nil
+}
+
+// newTemplatePrinter parses the template provided with the jsonpath, jsonpath-file, go-template and
+// go-template-file output formats
+func newTemplatePrinter(name, arg string) (templatePrinter, error) {
+	if arg == "" {
+		return nil, errors.Errorf("Template needs to be provided with the %s output format, e.g. %s=<template>", name, name)
+	}
+
+	if name == JSONPathFile || name == GoTemplateFile {
+		path, err := validation.ValidatePath(arg)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid template file path provided")
+		}
+		templateBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading template file")
+		}
+		arg = string(templateBytes)
+	}
+
+	switch name {
+	case JSONPath, JSONPathFile:
+		return parseJSONPath(arg)
+	case GoTemplate, GoTemplateFile:
+		return parseGoTemplate(arg)
+	}
+	return nil, errors.Errorf("Unsupported template format %q", name)
+}
+
+// splitFormat splits output formats like jsonpath={.id} into the format name and its argument
+func splitFormat(format string) (string, string) {
+	if i := strings.Index(format, "="); i >= 0 {
+		return format[:i], format[i+1:]
+	}
+	return format, ""
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package printer
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGoTemplate(t *testing.T) {
+	tt := []struct {
+		template    string
+		want        string
+		wantErr     string
+		description string
+	}{
+		{
+			template:    `{{range .items}}{{.name}}{{"\t"}}{{.id}}{{"\n"}}{{end}}`,
+			want:        "sgx-policy\t1\ntdx-policy\t2\nother-policy\t3\nlast-policy\t4\n",
+			description: "Test a range over the JSON fields",
+		},
+		{
+			template:    `{{index . "dotted.key"}} {{len .items}}`,
+			want:        "dotted 4",
+			description: "Test the template functions",
+		},
+		{
+			template:    "{{.missing}}",
+			wantErr:     "Error executing go-template",
+			description: "Test a missing key",
+		},
+		{
+			template:    "{{range .items}}",
+			wantErr:     "Invalid go-template",
+			description: "Test an invalid template",
+		},
+	}
+
+	for _, tc := range tt {
+		var buf bytes.Buffer
+		gt, err := parseGoTemplate(tc.template)
+		if err == nil {
+			err = gt.Execute(&buf, jsonPathTestData)
+		}
+		if tc.wantErr != "" {
+			assert.ErrorContains(t, err, tc.wantErr, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, tc.want, buf.String(), tc.description)
+		}
+	}
+}
+
+func TestTemplateFormats(t *testing.T) {
+	templateDir := t.TempDir()
+	jsonPathFile := filepath.Join(templateDir, "template.jsonpath")
+	assert.NoError(t, os.WriteFile(jsonPathFile, []byte("{.items[0].name}"), 0600))
+	goTemplateFile := filepath.Join(templateDir, "template.tmpl")
+	assert.NoError(t, os.WriteFile(goTemplateFile, []byte("{{(index .items 1).name}}"), 0600))
+
+	tt := []struct {
+		format      string
+		want        string
+		wantErr     string
+		description string
+	}{
+		{
+			format:      JSONPath + "={.items[-1].id}",
+			want:        "4",
+			description: "Test the jsonpath format",
+		},
+		{
+			format:      JSONPathFile + "=" + jsonPathFile,
+			want:        "sgx-policy",
+			description: "Test the jsonpath-file format",
+		},
+		{
+			format:      GoTemplate + "={{.count}}",
+			want:        "4",
+			description: "Test the numbers are printed as in the JSON representation",
+		},
+		{
+			format:      GoTemplate + `={{printf "%s=%s" "a" "b"}}`,
+			want:        "a=b",
+			description: "Test a go-template containing =",
+		},
+		{
+			format:      GoTemplateFile + "=" + goTemplateFile,
+			want:        "tdx-policy",
+			description: "Test the go-template-file format",
+		},
+		{
+			format:      JSONPath,
+			wantErr:     "Template needs to be provided",
+			description: "Test a template format without template",
+		},
+		{
+			format:      GoTemplateFile + "=" + filepath.Join(templateDir, "missing.tmpl"),
+			wantErr:     "Invalid template file path",
+			description: "Test a template file which does not exist",
+		},
+		{
+			format:      "template={{.count}}",
+			wantErr:     "Unsupported output format",
+			description: "Test an unsupported template format",
+		},
+	}
+
+	for _, tc := range tt {
+		var buf bytes.Buffer
+		err := Print(&buf, tc.format, jsonPathTestData)
+		if tc.wantErr != "" {
+			assert.ErrorContains(t, err, tc.wantErr, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+			assert.Equal(t, tc.want, buf.String(), tc.description)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+)
+
+// CreateApiClient creates an api client of the service, it is active unless another status is requested
+func (c *Client) CreateApiClient(ctx context.Context, request *models.CreateApiClient, opts ...CallOption) (*models.ApiClientDetail, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validation.ValidateApiClientName(request.Name); err != nil {
+		return nil, err
+	}
+	if err = validateTagValues(request.TagIdsValues); err != nil {
+		return nil, err
+	}
+	if request.Status == "" {
+		request.Status = constants.ApiClientStatusActive
+	} else if err = validation.ValidateApiClientStatus(string(request.Status)); err != nil {
+		return nil, err
+	}
+	return c.tms.CreateApiClientWithContext(ctx, request)
+}
+
+// UpdateApiClient updates the product, policies, tags and status of the api client
+func (c *Client) UpdateApiClient(ctx context.Context, apiClientId uuid.UUID, request *models.UpdateApiClient, opts ...CallOption) (*models.ApiClient, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		if err = validation.ValidateApiClientName(*request.Name); err != nil {
+			return nil, err
+		}
+	}
+	if err = validateTagValues(request.TagIdsValues); err != nil {
+		return nil, err
+	}
+	if request.Status != nil {
+		if err = validation.ValidateApiClientStatus(string(*request.Status)); err != nil {
+			return nil, err
+		}
+	}
+	return c.tms.UpdateApiClientWithContext(ctx, request, apiClientId)
+}
+
+// ListApiClients lists the api clients of the service
+func (c *Client) ListApiClients(ctx context.Context, serviceId uuid.UUID, opts ...CallOption) ([]models.ApiClient, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.GetApiClientWithContext(ctx, serviceId)
+}
+
+// GetApiClient retrieves the api client of the service along with its keys
+func (c *Client) GetApiClient(ctx context.Context, serviceId, apiClientId uuid.UUID, opts ...CallOption) (*models.ApiClientDetail, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.RetrieveApiClientWithContext(ctx, serviceId, apiClientId)
+}
+
+// GetApiClientPolicies lists the ids of the policies linked to the api client
+func (c *Client) GetApiClientPolicies(ctx context.Context, serviceId, apiClientId uuid.UUID, opts ...CallOption) (*models.ApiClientPolicies, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.GetApiClientPoliciesWithContext(ctx, serviceId, apiClientId)
+}
+
+// GetApiClientTags lists the tag values of the api client
+func (c *Client) GetApiClientTags(ctx context.Context, serviceId, apiClientId uuid.UUID, opts ...CallOption) (*models.ApiClientTags, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.GetApiClientTagValuesWithContext(ctx, serviceId, apiClientId)
+}
+
+// DeleteApiClient deletes the api client of the service
+func (c *Client) DeleteApiClient(ctx context.Context, serviceId, apiClientId uuid.UUID, opts ...CallOption) error {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.tms.DeleteApiClientWithContext(ctx, serviceId, apiClientId)
+}
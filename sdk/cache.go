@@ -0,0 +1,106 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"intel/tac/v1/cache"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Cached resources, they name the cache keys and the TTLs of CacheOptions
+const (
+	CachedServices      = "services"
+	CachedServiceOffers = "service-offers"
+	CachedProducts      = "products"
+	CachedPlans         = "plans"
+	CachedTags          = "tags"
+)
+
+// ErrOffline is returned in offline mode when a response is not cached, no request is sent to Trust Authority
+var ErrOffline = errors.New("No request can be sent to Trust Authority in offline mode")
+
+// CacheOptions configures the cache of the resources which seldom change: the services, service offers, products,
+// plans and tags
+type CacheOptions struct {
+	Cache *cache.Cache
+	// Prefix is prepended to the cache keys so that the resources of several tenants are kept apart, e.g. the name
+	// of the configuration profile
+	Prefix string
+	// TTLs are the times the resources are reused for, a resource without a positive TTL is not cached
+	TTLs map[string]time.Duration
+	// Refresh fetches the resources again and caches them, ignoring the cached ones
+	Refresh bool
+	// Offline serves the cached resources whatever their age and fails every request, so that the resources can be
+	// listed without reaching Trust Authority
+	Offline bool
+}
+
+// WithCache caches the lists of services, service offers, products, plans and tags. The tags are fetched again
+// once a tag is created or deleted.
+func WithCache(cacheOptions CacheOptions) Option {
+	return func(o *options) error {
+		if cacheOptions.Cache == nil {
+			return errors.New("Cache cannot be nil")
+		}
+		o.cache = &cacheOptions
+		return nil
+	}
+}
+
+// offlineTransport fails the requests sent in offline mode
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, ErrOffline
+}
+
+// cached returns the resource stored under the key when it is recent enough, otherwise the resource is fetched and
+// stored. The key is made of the resource name followed by the segments identifying it, e.g. products/<offer id>.
+func cached[T any](c *Client, resource string, fetch func() (T, error), segments ...string) (T, error) {
+	var value T
+	o := c.cache
+	if o == nil || (o.TTLs[resource] <= 0 && !o.Offline) {
+		return fetch()
+	}
+
+	key := o.Prefix + "/" + resource
+	for _, segment := range segments {
+		key += "/" + segment
+	}
+	if o.Offline {
+		if o.Cache.Get(key, 0, &value) {
+			return value, nil
+		}
+		return value, errors.Wrapf(ErrOffline, "The %s are not cached", strings.ReplaceAll(resource, "-", " "))
+	}
+	if !o.Refresh && o.Cache.Get(key, o.TTLs[resource], &value) {
+		log.Debugf("Using the cached %s", resource)
+		return value, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	if err = o.Cache.Set(key, value); err != nil {
+		log.WithError(err).Warnf("Failed to cache the %s", resource)
+	}
+	return value, nil
+}
+
+// invalidate removes the cached resource so that it is fetched again once it changed
+func (c *Client) invalidate(resource string) {
+	if c.cache == nil {
+		return
+	}
+	if err := c.cache.Cache.Delete(c.cache.Prefix + "/" + resource); err != nil {
+		log.WithError(err).Warnf("Failed to invalidate the cached %s", resource)
+	}
+}
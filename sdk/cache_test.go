@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/cache"
+	"intel/tac/v1/client"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	seed := mockserver.DefaultSeed()
+	serviceOfferId := seed.ServiceOffers[0].ID
+	server, err := mockserver.New(seed)
+	assert.NoError(t, err)
+	handler, requests := server.Handler(), 0
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	cacheOptions := CacheOptions{Cache: cache.New(t.TempDir()), Prefix: "default", TTLs: map[string]time.Duration{
+		CachedServices: time.Hour, CachedServiceOffers: time.Hour, CachedProducts: time.Hour, CachedPlans: time.Hour,
+		CachedTags: time.Hour}}
+	newClient := func(cacheOptions CacheOptions) *Client {
+		taClient, err := New(WithBaseUrl(httpServer.URL), WithApiKey("key"), WithRetry(client.RetryOptions{Max: 0}),
+			WithCache(cacheOptions))
+		assert.NoError(t, err)
+		return taClient
+	}
+	listAll := func(taClient *Client) error {
+		ctx := context.Background()
+		if _, err := taClient.ListServices(ctx); err != nil {
+			return err
+		}
+		if _, err := taClient.ListServiceOffers(ctx); err != nil {
+			return err
+		}
+		if _, err := taClient.ListProducts(ctx, serviceOfferId); err != nil {
+			return err
+		}
+		if _, err := taClient.ListPlans(ctx, serviceOfferId); err != nil {
+			return err
+		}
+		_, err := taClient.ListTags(ctx)
+		return err
+	}
+
+	assert.NoError(t, listAll(newClient(cacheOptions)))
+	assert.Equal(t, 5, requests, "Test listing the resources which are not cached")
+	assert.NoError(t, listAll(newClient(cacheOptions)))
+	assert.Equal(t, 5, requests, "Test listing the cached resources")
+
+	refreshOptions := cacheOptions
+	refreshOptions.Refresh = true
+	assert.NoError(t, listAll(newClient(refreshOptions)))
+	assert.Equal(t, 10, requests, "Test refreshing the cached resources")
+
+	taClient := newClient(cacheOptions)
+	_, err = taClient.CreateTag(context.Background(), &models.TagCreate{Name: "Owner"})
+	assert.NoError(t, err)
+	tags, err := taClient.ListTags(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, tags.Tags, len(seed.Tags)+1, "Test listing the tags once a tag is created")
+	assert.Equal(t, 12, requests)
+
+	noTTLOptions := cacheOptions
+	noTTLOptions.TTLs = map[string]time.Duration{CachedTags: time.Hour}
+	_, err = newClient(noTTLOptions).ListServices(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 13, requests, "Test listing a resource without TTL")
+
+	// the cached resources are listed whatever their age in offline mode
+	httpServer.Close()
+	offlineOptions := cacheOptions
+	offlineOptions.TTLs = nil
+	offlineOptions.Offline = true
+	taClient = newClient(offlineOptions)
+	assert.NoError(t, listAll(taClient), "Test listing the cached resources offline")
+	_, err = taClient.ListPlans(context.Background(), seed.Services[0].ID)
+	assert.True(t, errors.Is(err, ErrOffline), "Test listing a resource which is not cached offline")
+	_, err = taClient.ListPolicies(context.Background())
+	assert.True(t, errors.Is(err, ErrOffline), "Test listing a resource which is never cached offline")
+
+	offlineOptions.Prefix = "staging"
+	_, err = newClient(offlineOptions).ListServices(context.Background())
+	assert.True(t, errors.Is(err, ErrOffline), "Test listing the resources cached for another profile")
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"intel/tac/v1/client"
+	"intel/tac/v1/validation"
+)
+
+// CallOption configures a single call of the client, so that concurrent calls do not share any state
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	requestId   string
+	traceParent string
+	metadata    *client.RequestMetadata
+}
+
+// RequestId sets the request ID sent with the call, it can be used to correlate the call with the Trust Authority logs
+func RequestId(requestId string) CallOption {
+	return func(o *callOptions) {
+		o.requestId = requestId
+	}
+}
+
+// TraceParent sets the W3C traceparent sent with the call, so that the call is part of the trace of the caller, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func TraceParent(traceParent string) CallOption {
+	return func(o *callOptions) {
+		o.traceParent = traceParent
+	}
+}
+
+// Metadata stores the request and trace IDs returned by Trust Authority in md once the call completed. The request
+// ID and traceparent of md are sent with the call unless RequestId or TraceParent is provided.
+func Metadata(md *client.RequestMetadata) CallOption {
+	return func(o *callOptions) {
+		o.metadata = md
+	}
+}
+
+// callContext returns the context of the call carrying its request metadata
+func callContext(ctx context.Context, opts []CallOption) (context.Context, error) {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	md := o.metadata
+	if md == nil {
+		md = &client.RequestMetadata{}
+	}
+	if o.requestId != "" {
+		md.RequestId = o.requestId
+	}
+	if o.traceParent != "" {
+		md.TraceParent = o.traceParent
+	}
+	if err := validation.ValidateRequestId(md.RequestId); err != nil {
+		return nil, err
+	}
+	if md.TraceParent != "" {
+		if _, err := client.ParseTraceParent(md.TraceParent); err != nil {
+			return nil, validation.NewInputError(err)
+		}
+	}
+	return client.WithRequestMetadata(ctx, md), nil
+}
+
+// detachMetadata returns the call options sending the request ID and traceparent of the metadata given with the
+// Metadata option, each call storing the IDs returned by Trust Authority in a copy of the metadata. The calls made on
+// behalf of the caller, e.g. by a Resolver, do not overwrite the IDs of the caller's own call this way.
+func detachMetadata(opts []CallOption) []CallOption {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.metadata == nil {
+		return opts
+	}
+	md := o.metadata
+	return append(opts[:len(opts):len(opts)], func(o *callOptions) {
+		o.metadata = &client.RequestMetadata{RequestId: md.RequestId, TraceParent: md.TraceParent}
+	})
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// Package sdk is the Go client of the Trust Authority management APIs used by the trustauthorityctl commands. It
+// validates the requests before sending them so that invalid inputs are reported without reaching the server.
+//
+//	taClient, err := sdk.New(sdk.WithBaseUrl("https://api.trustauthority.intel.com"), sdk.WithApiKey(apiKey))
+//	if err != nil {
+//		return err
+//	}
+//	policies, err := taClient.ListPolicies(ctx)
+package sdk
+
+import (
+	"github.com/pkg/errors"
+	"intel/tac/v1/client"
+	"intel/tac/v1/client/pms"
+	"intel/tac/v1/client/tms"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/validation"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client sends the requests to the Trust Authority management APIs. It is created with New and can be shared by
+// goroutines.
+type Client struct {
+	tms   tms.TmsClient
+	pms   pms.PmsClient
+	cache *CacheOptions
+}
+
+type options struct {
+	baseUrl          string
+	apiKey           string
+	httpClient       *http.Client
+	transportOptions client.TransportOptions
+	cache            *CacheOptions
+}
+
+// Option configures the client created by New
+type Option func(*options) error
+
+// WithBaseUrl sets the Trust Authority base URL, e.g. https://api.trustauthority.intel.com. It is required.
+func WithBaseUrl(baseUrl string) Option {
+	return func(o *options) error {
+		o.baseUrl = baseUrl
+		return nil
+	}
+}
+
+// WithApiKey sets the Trust Authority API key sent with every request
+func WithApiKey(apiKey string) Option {
+	return func(o *options) error {
+		o.apiKey = apiKey
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests. The transport, timeout and retry options are
+// ignored when it is provided.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) error {
+		if httpClient == nil {
+			return errors.New("HTTP client cannot be nil")
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTransport sets the TLS, proxy, timeout and retry settings of the HTTP client
+func WithTransport(transportOptions client.TransportOptions) Option {
+	return func(o *options) error {
+		o.transportOptions = transportOptions
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of each attempt of a request
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) error {
+		o.transportOptions.Timeout = timeout
+		return nil
+	}
+}
+
+// WithRetry sets the retry policy of the requests
+func WithRetry(retry client.RetryOptions) Option {
+	return func(o *options) error {
+		if err := client.ValidateRetryOptions(&retry); err != nil {
+			return err
+		}
+		o.transportOptions.Retry = retry
+		return nil
+	}
+}
+
+// DefaultTransportOptions returns the transport settings used unless WithTransport, WithTimeout or WithRetry is
+// provided, they match the defaults of the CLI
+func DefaultTransportOptions() client.TransportOptions {
+	statusCodes, _ := client.ParseStatusCodes(constants.DefaultRetryStatusCodes)
+	return client.TransportOptions{
+		Timeout: constants.DefaultHttpClientTimeout * time.Second,
+		Retry: client.RetryOptions{
+			Max:         constants.DefaultRetryCount,
+			WaitMin:     constants.DefaultRetryWaitMin * time.Second,
+			WaitMax:     constants.DefaultRetryWaitMax * time.Second,
+			Jitter:      true,
+			StatusCodes: statusCodes,
+		},
+	}
+}
+
+// New creates a Trust Authority client with the provided options
+func New(opts ...Option) (*Client, error) {
+	o := &options{transportOptions: DefaultTransportOptions()}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	baseUrl, err := url.Parse(o.baseUrl)
+	if err != nil {
+		return nil, validation.NewInputError(errors.Wrap(err, "Invalid Trust Authority base URL"))
+	}
+	if baseUrl.Host == "" {
+		return nil, validation.NewInputError(errors.New("Invalid Trust Authority base URL, host is missing"))
+	}
+	tmsUrl, err := url.Parse(o.baseUrl + constants.TmsBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+	pmsUrl, err := url.Parse(o.baseUrl + constants.PmsBaseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := o.httpClient
+	if o.cache != nil && o.cache.Offline {
+		httpClient = &http.Client{Transport: offlineTransport{}}
+	} else if httpClient == nil {
+		if httpClient, err = client.NewHTTPClient(&o.transportOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{
+		tms:   tms.NewTmsClient(httpClient, tmsUrl, o.apiKey),
+		pms:   pms.NewPmsClient(httpClient, pmsUrl, o.apiKey),
+		cache: o.cache,
+	}, nil
+}
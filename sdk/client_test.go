@@ -0,0 +1,135 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"intel/tac/v1/test"
+	"intel/tac/v1/validation"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tt := []struct {
+		opts        []Option
+		wantErr     bool
+		description string
+	}{
+		{
+			opts:        []Option{WithBaseUrl("https://api.example.com"), WithApiKey("key")},
+			wantErr:     false,
+			description: "Test creating a client with the default transport",
+		},
+		{
+			opts:        []Option{WithBaseUrl("bogus\nbase\nURL")},
+			wantErr:     true,
+			description: "Test creating a client with an invalid URL",
+		},
+		{
+			opts:        []Option{},
+			wantErr:     true,
+			description: "Test creating a client without URL",
+		},
+		{
+			opts:        []Option{WithBaseUrl("https://api.example.com"), WithRetry(client.RetryOptions{Max: -1})},
+			wantErr:     true,
+			description: "Test creating a client with an invalid retry policy",
+		},
+		{
+			opts:        []Option{WithBaseUrl("https://api.example.com"), WithHTTPClient(nil)},
+			wantErr:     true,
+			description: "Test creating a client with a nil HTTP client",
+		},
+	}
+
+	for _, tc := range tt {
+		_, err := New(tc.opts...)
+		if tc.wantErr {
+			assert.Error(t, err, tc.description)
+		} else {
+			assert.NoError(t, err, tc.description)
+		}
+	}
+}
+
+func TestClientValidation(t *testing.T) {
+	server := test.MockServer(t)
+	defer server.Close()
+
+	taClient, err := New(WithBaseUrl(server.URL), WithApiKey("key"))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	policy := &models.PolicyRequest{CommonPolicy: models.CommonPolicy{
+		Policy:          "default matches_sgx_policy = false",
+		PolicyName:      "Sample_Policy_SGX",
+		PolicyType:      constants.AppraisalPolicyType,
+		ServiceOfferId:  uuid.New(),
+		AttestationType: constants.SgxAttestationType,
+	}}
+	_, err = taClient.CreatePolicy(ctx, policy)
+	assert.NoError(t, err)
+
+	policy.PolicyType = "Unknown policy"
+	_, err = taClient.CreatePolicy(ctx, policy)
+	assert.True(t, validation.IsInputError(err))
+
+	_, err = taClient.CreateUser(ctx, &models.CreateTenantUser{Email: "dummy@email.com", Role: "Owner"})
+	assert.True(t, validation.IsInputError(err))
+
+	tags, err := ParseTagValues([]string{"Workload:WorkloadAI", "Env:Prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ApiClientTagIdValue{{Key: "Workload", Value: "WorkloadAI"}, {Key: "Env", Value: "Prod"}}, tags)
+	_, err = ParseTagValues([]string{"Workload"})
+	assert.True(t, validation.IsInputError(err))
+
+	_, err = ParseId("service", "invalid-id")
+	assert.True(t, validation.IsInputError(err))
+	_, err = ParseIds("policy", []string{uuid.NewString(), "invalid-id"})
+	assert.True(t, validation.IsInputError(err))
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(constants.HTTPHeaderKeyRequestId)
+		w.Header().Set(constants.HTTPHeaderKeyRequestId, requestId)
+		w.Header().Set(constants.HTTPHeaderKeyTraceId, "trace-"+requestId)
+		_, _ = w.Write([]byte(`{"tags": []}`))
+	}))
+	defer server.Close()
+
+	taClient, err := New(WithBaseUrl(server.URL), WithApiKey("key"))
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	metadata := make([]client.RequestMetadata, 20)
+	for i := range metadata {
+		wg.Add(1)
+		go func(md *client.RequestMetadata, requestId string) {
+			defer wg.Done()
+			_, err := taClient.ListTags(context.Background(), RequestId(requestId), Metadata(md))
+			assert.NoError(t, err)
+		}(&metadata[i], "request-"+strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	for i, md := range metadata {
+		assert.Equal(t, "request-"+strconv.Itoa(i), md.RequestId)
+		assert.Equal(t, "trace-request-"+strconv.Itoa(i), md.TraceId)
+	}
+
+	_, err = taClient.ListTags(context.Background(), RequestId("@#$invalid-id"))
+	assert.True(t, validation.IsInputError(err))
+}
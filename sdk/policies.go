@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+)
+
+// CreatePolicy uploads a rego policy for the service offer
+func (c *Client) CreatePolicy(ctx context.Context, request *models.PolicyRequest, opts ...CallOption) (*models.PolicyResponse, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validation.ValidatePolicyName(request.PolicyName); err != nil {
+		return nil, err
+	}
+	if err = validation.ValidatePolicyType(request.PolicyType); err != nil {
+		return nil, err
+	}
+	if err = validation.ValidateAttestationType(request.AttestationType); err != nil {
+		return nil, err
+	}
+	if request.Policy == "" {
+		return nil, validation.NewInputError(errors.New("Policy cannot be empty"))
+	}
+	if err = validatePolicySize(request.Policy); err != nil {
+		return nil, err
+	}
+	return c.pms.CreatePolicyWithContext(ctx, request)
+}
+
+// UpdatePolicy updates the name and/or the rego policy of the policy, empty values leave the existing ones intact
+func (c *Client) UpdatePolicy(ctx context.Context, request *models.PolicyUpdateRequest, opts ...CallOption) (*models.PolicyResponse, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.PolicyName != "" {
+		if err = validation.ValidatePolicyName(request.PolicyName); err != nil {
+			return nil, err
+		}
+	}
+	if err = validatePolicySize(request.Policy); err != nil {
+		return nil, err
+	}
+	return c.pms.UpdatePolicyWithContext(ctx, request)
+}
+
+// ListPolicies lists the policies of the tenant
+func (c *Client) ListPolicies(ctx context.Context, opts ...CallOption) ([]models.PolicyResponse, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.pms.SearchPolicyWithContext(ctx)
+}
+
+// GetPolicy retrieves the policy
+func (c *Client) GetPolicy(ctx context.Context, policyId uuid.UUID, opts ...CallOption) (*models.PolicyResponse, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.pms.GetPolicyWithContext(ctx, policyId)
+}
+
+// DeletePolicy deletes the policy
+func (c *Client) DeletePolicy(ctx context.Context, policyId uuid.UUID, opts ...CallOption) error {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.pms.DeletePolicyWithContext(ctx, policyId)
+}
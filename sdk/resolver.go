@@ -0,0 +1,239 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+	"strings"
+	"sync"
+)
+
+// Resolver resolves the references of resources, given either as IDs or as names, to their IDs: services, products,
+// policies, tags and api clients are referenced by their names and users by their emails. The resources are listed
+// once and cached by the resolver, which is meant to be used for a single command.
+type Resolver struct {
+	c    *Client
+	opts []CallOption
+
+	mu         sync.Mutex
+	services   []models.Service
+	policies   []models.PolicyResponse
+	users      []models.TenantUser
+	tags       []models.Tag
+	products   map[uuid.UUID][]models.Product
+	apiClients map[uuid.UUID][]models.ApiClient
+}
+
+// NewResolver creates a resolver listing the resources with the client, the call options are used for every call. The
+// request and trace IDs returned by Trust Authority are not stored in the metadata given with the Metadata option, it
+// is left to the call the resources are resolved for.
+func NewResolver(c *Client, opts ...CallOption) *Resolver {
+	return &Resolver{
+		c:          c,
+		opts:       detachMetadata(opts),
+		products:   map[uuid.UUID][]models.Product{},
+		apiClients: map[uuid.UUID][]models.ApiClient{},
+	}
+}
+
+// ServiceId resolves the ID or the name of a service of the tenant
+func (r *Resolver) ServiceId(ctx context.Context, ref string) (uuid.UUID, error) {
+	if id, ok, err := parseRef("service", ref); ok || err != nil {
+		return id, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	services, err := r.listServices(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return resolve("service", ref, services, func(service models.Service) (string, uuid.UUID) {
+		return service.Name, service.ID
+	}, false)
+}
+
+// ProductId resolves the ID or the name of a product of the service offer of the service
+func (r *Resolver) ProductId(ctx context.Context, serviceId uuid.UUID, ref string) (uuid.UUID, error) {
+	if id, ok, err := parseRef("product", ref); ok || err != nil {
+		return id, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	services, err := r.listServices(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	serviceOfferId := uuid.Nil
+	for _, service := range services {
+		if service.ID == serviceId {
+			serviceOfferId = service.ServiceOfferId
+		}
+	}
+	if serviceOfferId == uuid.Nil {
+		return uuid.Nil, validation.NewInputError(errors.Errorf("No service with ID %s was found to resolve product %q",
+			serviceId, ref))
+	}
+	products, ok := r.products[serviceOfferId]
+	if !ok {
+		if products, err = r.c.ListProducts(ctx, serviceOfferId, r.opts...); err != nil {
+			return uuid.Nil, errors.Wrap(err, "Failed to list the products to resolve their names")
+		}
+		r.products[serviceOfferId] = products
+	}
+	return resolve("product", ref, products, func(product models.Product) (string, uuid.UUID) {
+		return product.Name, product.ID
+	}, false)
+}
+
+// PolicyId resolves the ID or the name of a policy
+func (r *Resolver) PolicyId(ctx context.Context, ref string) (uuid.UUID, error) {
+	if id, ok, err := parseRef("policy", ref); ok || err != nil {
+		return id, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.policies == nil {
+		policies, err := r.c.ListPolicies(ctx, r.opts...)
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, "Failed to list the policies to resolve their names")
+		}
+		r.policies = append([]models.PolicyResponse{}, policies...)
+	}
+	return resolve("policy", ref, r.policies, func(policy models.PolicyResponse) (string, uuid.UUID) {
+		return policy.PolicyName, policy.PolicyId
+	}, false)
+}
+
+// PolicyIds resolves the IDs or the names of a list of policies
+func (r *Resolver) PolicyIds(ctx context.Context, refs []string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, ref := range refs {
+		id, err := r.PolicyId(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// UserId resolves the ID or the email of a user of the tenant
+func (r *Resolver) UserId(ctx context.Context, ref string) (uuid.UUID, error) {
+	if id, ok, err := parseRef("user", ref); ok || err != nil {
+		return id, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.users == nil {
+		users, err := r.c.ListUsers(ctx, r.opts...)
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, "Failed to list the users to resolve their emails")
+		}
+		r.users = append([]models.TenantUser{}, users...)
+	}
+	return resolve("user", ref, r.users, func(user models.TenantUser) (string, uuid.UUID) {
+		return user.Email, user.ID
+	}, true)
+}
+
+// TagId resolves the ID or the name of a tag of the tenant
+func (r *Resolver) TagId(ctx context.Context, ref string) (uuid.UUID, error) {
+	if id, ok, err := parseRef("tag", ref); ok || err != nil {
+		return id, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tags == nil {
+		tags, err := r.c.ListTags(ctx, r.opts...)
+		if err != nil {
+			return uuid.Nil, errors.Wrap(err, "Failed to list the tags to resolve their names")
+		}
+		r.tags = []models.Tag{}
+		for _, tag := range tags.Tags {
+			// the predefined tags have no ID
+			if tag.ID != nil {
+				r.tags = append(r.tags, tag)
+			}
+		}
+	}
+	return resolve("tag", ref, r.tags, func(tag models.Tag) (string, uuid.UUID) {
+		return tag.Name, *tag.ID
+	}, false)
+}
+
+// ApiClientId resolves the ID or the name of an api client of the service
+func (r *Resolver) ApiClientId(ctx context.Context, serviceId uuid.UUID, ref string) (uuid.UUID, error) {
+	if id, ok, err := parseRef("api client", ref); ok || err != nil {
+		return id, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	apiClients, ok := r.apiClients[serviceId]
+	if !ok {
+		var err error
+		if apiClients, err = r.c.ListApiClients(ctx, serviceId, r.opts...); err != nil {
+			return uuid.Nil, errors.Wrap(err, "Failed to list the api clients to resolve their names")
+		}
+		r.apiClients[serviceId] = apiClients
+	}
+	return resolve("api client", ref, apiClients, func(apiClient models.ApiClient) (string, uuid.UUID) {
+		return apiClient.Name, apiClient.ID
+	}, false)
+}
+
+func (r *Resolver) listServices(ctx context.Context) ([]models.Service, error) {
+	if r.services == nil {
+		services, err := r.c.ListServices(ctx, r.opts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to list the services to resolve their names")
+		}
+		r.services = append([]models.Service{}, services...)
+	}
+	return r.services, nil
+}
+
+// parseRef tells whether the reference is an ID, the resource name is used in the error message
+func parseRef(resource, ref string) (uuid.UUID, bool, error) {
+	if strings.TrimSpace(ref) == "" {
+		return uuid.Nil, false, validation.NewInputError(errors.Errorf("The %s name or ID cannot be empty", resource))
+	}
+	id, err := uuid.Parse(ref)
+	return id, err == nil, nil
+}
+
+// resolve returns the ID of the only resource of the list whose name matches the reference, ignoring the case with
+// foldCase
+func resolve[T any](resource, ref string, items []T, key func(T) (string, uuid.UUID), foldCase bool) (uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, item := range items {
+		if name, id := key(item); name == ref || foldCase && strings.EqualFold(name, ref) {
+			ids = append(ids, id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return uuid.Nil, validation.NewInputError(errors.Errorf("No %s named %q was found, should be the name or "+
+			"the UUID of the %s", resource, ref, resource))
+	case 1:
+		return ids[0], nil
+	}
+	idStrings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, id.String())
+	}
+	return uuid.Nil, validation.NewInputError(errors.Errorf("The %s name %q is ambiguous, it matches the IDs %s, use "+
+		"one of them instead", resource, ref, strings.Join(idStrings, ", ")))
+}
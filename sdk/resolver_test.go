@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/client"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/mockserver"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	seed := mockserver.DefaultSeed()
+	serviceId, productId := seed.Services[0].ID, seed.Products[0].ID
+	policyId, userId := uuid.New(), uuid.New()
+	for i := 0; i < 2; i++ {
+		service := seed.Services[0]
+		service.ID, service.Name = uuid.New(), "Staging"
+		seed.Services = append(seed.Services, service)
+	}
+	seed.Policies = []models.PolicyResponse{{CommonPolicy: models.CommonPolicy{PolicyId: policyId, PolicyName: "sgx-policy",
+		Policy: "default matches_sgx_policy = false", PolicyType: constants.AppraisalPolicyType,
+		ServiceOfferId: seed.ServiceOffers[0].ID, AttestationType: constants.SgxAttestationType}}}
+	seed.Users = []models.TenantUser{{ID: userId, Email: "admin@example.com", Role: models.Role{Name: constants.TenantAdminRole}}}
+	server, err := mockserver.New(seed)
+	assert.NoError(t, err)
+	handler, requests := server.Handler(), 0
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	taClient, err := New(WithBaseUrl(httpServer.URL), WithApiKey("key"), WithRetry(client.RetryOptions{Max: 0}))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	apiClient, err := taClient.CreateApiClient(ctx, &models.CreateApiClient{ServiceId: serviceId, ProductId: productId,
+		Name: "ci-client", Status: constants.ApiClientStatusActive})
+	assert.NoError(t, err)
+	requests = 0
+
+	resolver := NewResolver(taClient)
+	tests := []struct {
+		name     string
+		resolve  func() (uuid.UUID, error)
+		expected uuid.UUID
+	}{
+		{"Test resolving a service by name", func() (uuid.UUID, error) { return resolver.ServiceId(ctx, "Attestation") }, serviceId},
+		{"Test resolving a product by name", func() (uuid.UUID, error) { return resolver.ProductId(ctx, serviceId, "Developer") }, productId},
+		{"Test resolving a policy by name", func() (uuid.UUID, error) { return resolver.PolicyId(ctx, "sgx-policy") }, policyId},
+		{"Test resolving a user by email", func() (uuid.UUID, error) { return resolver.UserId(ctx, "Admin@example.com") }, userId},
+		{"Test resolving a tag by name", func() (uuid.UUID, error) { return resolver.TagId(ctx, "Workload") }, *seed.Tags[0].ID},
+		{"Test resolving an api client by name", func() (uuid.UUID, error) { return resolver.ApiClientId(ctx, serviceId, "ci-client") }, apiClient.ID},
+		{"Test resolving an ID", func() (uuid.UUID, error) { return resolver.PolicyId(ctx, policyId.String()) }, policyId},
+	}
+	for _, tt := range tests {
+		id, err := tt.resolve()
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, id, tt.name)
+	}
+	// services, products, policies, users, tags and api clients
+	assert.Equal(t, 6, requests, "Test listing each resource once")
+
+	ids, err := resolver.PolicyIds(ctx, []string{"sgx-policy", policyId.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{policyId, policyId}, ids)
+	assert.Equal(t, 6, requests, "Test caching the resolved resources")
+
+	_, err = resolver.ServiceId(ctx, "Staging")
+	assert.True(t, validation.IsInputError(err), "Test an ambiguous name")
+	assert.Contains(t, err.Error(), "ambiguous")
+	_, err = resolver.PolicyId(ctx, "SGX-policy")
+	assert.True(t, validation.IsInputError(err), "Test a name which is not found")
+	_, err = resolver.ServiceId(ctx, "")
+	assert.True(t, validation.IsInputError(err), "Test an empty reference")
+	_, err = resolver.ProductId(ctx, uuid.New(), "Developer")
+	assert.True(t, validation.IsInputError(err), "Test a product of an unknown service")
+}
+
+func TestResolverMetadata(t *testing.T) {
+	server, err := mockserver.New(mockserver.DefaultSeed())
+	assert.NoError(t, err)
+	handler, requestIds := server.Handler(), []string{}
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIds = append(requestIds, r.Header.Get(constants.HTTPHeaderKeyRequestId))
+		handler.ServeHTTP(w, r)
+	}))
+	defer httpServer.Close()
+
+	taClient, err := New(WithBaseUrl(httpServer.URL), WithApiKey("key"), WithRetry(client.RetryOptions{Max: 0}))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	md := &client.RequestMetadata{RequestId: "resolver-test"}
+	resolver := NewResolver(taClient, Metadata(md))
+
+	_, err = resolver.ServiceId(ctx, "Attestation")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"resolver-test"}, requestIds, "Test sending the request ID of the metadata")
+	assert.False(t, md.Received, "Test the metadata is left to the caller's call")
+
+	_, err = taClient.ListTags(ctx, Metadata(md))
+	assert.NoError(t, err)
+	assert.True(t, md.Received)
+	assert.Equal(t, "resolver-test", md.RequestId)
+
+	_, err = resolver.TagId(ctx, "Workload")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"resolver-test", "resolver-test", "resolver-test"}, requestIds)
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"intel/tac/v1/models"
+)
+
+// ListServices lists the services the tenant subscribed to
+func (c *Client) ListServices(ctx context.Context, opts ...CallOption) ([]models.Service, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cached(c, CachedServices, func() ([]models.Service, error) {
+		return c.tms.GetServicesWithContext(ctx)
+	})
+}
+
+// GetService retrieves the service
+func (c *Client) GetService(ctx context.Context, serviceId uuid.UUID, opts ...CallOption) (*models.ServiceDetail, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.RetrieveServiceWithContext(ctx, serviceId)
+}
+
+// ListServiceOffers lists the service offers of Trust Authority
+func (c *Client) ListServiceOffers(ctx context.Context, opts ...CallOption) ([]models.ServiceOffer, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cached(c, CachedServiceOffers, func() ([]models.ServiceOffer, error) {
+		return c.tms.GetServiceOffersWithContext(ctx)
+	})
+}
+
+// ListProducts lists the products of the service offer
+func (c *Client) ListProducts(ctx context.Context, serviceOfferId uuid.UUID, opts ...CallOption) ([]models.Product, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cached(c, CachedProducts, func() ([]models.Product, error) {
+		return c.tms.GetProductsWithContext(ctx, serviceOfferId)
+	}, serviceOfferId.String())
+}
+
+// ListPlans lists the plans of the service offer
+func (c *Client) ListPlans(ctx context.Context, serviceOfferId uuid.UUID, opts ...CallOption) ([]models.Plan, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cached(c, CachedPlans, func() ([]models.Plan, error) {
+		return c.tms.GetPlansWithContext(ctx, serviceOfferId)
+	}, serviceOfferId.String())
+}
+
+// GetPlan retrieves the plan of the service offer along with its products
+func (c *Client) GetPlan(ctx context.Context, serviceOfferId, planId uuid.UUID, opts ...CallOption) (*models.PlanProducts, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.RetrievePlanWithContext(ctx, serviceOfferId, planId)
+}
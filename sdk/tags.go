@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+)
+
+// CreateTag creates a tag of the tenant, its values are then set on the api clients
+func (c *Client) CreateTag(ctx context.Context, request *models.TagCreate, opts ...CallOption) (*models.Tag, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validation.ValidateTagName(request.Name); err != nil {
+		return nil, err
+	}
+	defer c.invalidate(CachedTags)
+	return c.tms.CreateTenantTagWithContext(ctx, request)
+}
+
+// ListTags lists the predefined and user defined tags of the tenant
+func (c *Client) ListTags(ctx context.Context, opts ...CallOption) (*models.Tags, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cached(c, CachedTags, func() (*models.Tags, error) {
+		return c.tms.GetTenantTagsWithContext(ctx)
+	})
+}
+
+// DeleteTag deletes the user defined tag
+func (c *Client) DeleteTag(ctx context.Context, tagId uuid.UUID, opts ...CallOption) error {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer c.invalidate(CachedTags)
+	return c.tms.DeleteTenantTagWithContext(ctx, tagId)
+}
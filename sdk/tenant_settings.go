@@ -0,0 +1,37 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+)
+
+// GetTenantSettings retrieves the email id to which the attestation failures are notified
+func (c *Client) GetTenantSettings(ctx context.Context, opts ...CallOption) (*models.AttestationFailureEmail, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.GetTenantSettingsWithContext(ctx)
+}
+
+// UpdateTenantSettings sets the email id to which the attestation failures are notified, an empty email id disables
+// the notifications
+func (c *Client) UpdateTenantSettings(ctx context.Context, settings *models.AttestationFailureEmail, opts ...CallOption) (*models.AttestationFailureEmail, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.AttestationFailureEmail != "" {
+		if err = validation.ValidateEmailAddress(settings.AttestationFailureEmail); err != nil {
+			return nil, err
+		}
+	}
+	return c.tms.UpdateTenantSettingsWithContext(ctx, settings)
+}
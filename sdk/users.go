@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+)
+
+// CreateUser creates a user of the tenant with the Tenant Admin or User role
+func (c *Client) CreateUser(ctx context.Context, request *models.CreateTenantUser, opts ...CallOption) (*models.TenantUser, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validation.ValidateEmailAddress(request.Email); err != nil {
+		return nil, err
+	}
+	if err = validation.ValidateUserRole(request.Role); err != nil {
+		return nil, err
+	}
+	return c.tms.CreateUserWithContext(ctx, request)
+}
+
+// UpdateUserRole updates the role of the user
+func (c *Client) UpdateUserRole(ctx context.Context, request *models.UpdateTenantUserRoles, opts ...CallOption) (*models.TenantUser, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validation.ValidateUserRole(request.Role); err != nil {
+		return nil, err
+	}
+	return c.tms.UpdateTenantUserRoleWithContext(ctx, request)
+}
+
+// ListUsers lists the users of the tenant
+func (c *Client) ListUsers(ctx context.Context, opts ...CallOption) ([]models.TenantUser, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.tms.GetUsersWithContext(ctx)
+}
+
+// FindUserByEmail retrieves the user of the tenant with the email id
+func (c *Client) FindUserByEmail(ctx context.Context, email string, opts ...CallOption) (*models.TenantUser, error) {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validation.ValidateEmailAddress(email); err != nil {
+		return nil, err
+	}
+	users, err := c.tms.GetUsersWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if users[i].Email == email {
+			return &users[i], nil
+		}
+	}
+	return nil, errors.New("User associated with the email Id provided in input was not found")
+}
+
+// DeleteUser deletes the user from the tenant
+func (c *Client) DeleteUser(ctx context.Context, userId uuid.UUID, opts ...CallOption) error {
+	ctx, err := callContext(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.tms.DeleteUserWithContext(ctx, userId)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package sdk
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/models"
+	"intel/tac/v1/validation"
+	"os"
+	"strings"
+)
+
+// ParseId parses the id of a resource, the resource name is used in the error message, e.g. "service"
+func ParseId(resource, id string) (uuid.UUID, error) {
+	parsedId, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, validation.NewInputError(errors.Wrapf(err, "Invalid %s id provided, should be in UUID format", resource))
+	}
+	return parsedId, nil
+}
+
+// ParseIds parses a list of resource ids, the resource name is used in the error message, e.g. "policy"
+func ParseIds(resource string, ids []string) ([]uuid.UUID, error) {
+	var parsedIds []uuid.UUID
+	for _, id := range ids {
+		parsedId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, validation.NewInputError(errors.Wrapf(err, "Invalid %s ID found %s. Should be UUID.", resource, id))
+		}
+		parsedIds = append(parsedIds, parsedId)
+	}
+	return parsedIds, nil
+}
+
+// ParseTagValues parses the tag name and value pairs of an api client, provided in the name:value format
+func ParseTagValues(tagValues []string) ([]models.ApiClientTagIdValue, error) {
+	var tags []models.ApiClientTagIdValue
+	for _, tagValue := range tagValues {
+		splitTag := strings.Split(tagValue, ":")
+		if len(splitTag) != 2 {
+			return nil, validation.NewInputError(errors.New("Tag Id value pairs are not provided in proper format, please check help section for more details"))
+		}
+		tags = append(tags, models.ApiClientTagIdValue{Key: splitTag[0], Value: splitTag[1]})
+	}
+	return tags, validateTagValues(tags)
+}
+
+// ReadPolicyFile reads the rego policy from the file, checking its path and size
+func ReadPolicyFile(policyFilePath string) (string, error) {
+	if policyFilePath == "" {
+		return "", validation.NewInputError(errors.New("Policy file path cannot be empty"))
+	}
+	path, err := validation.ValidatePath(policyFilePath)
+	if err != nil {
+		return "", err
+	}
+	if err = validation.ValidateSize(path); err != nil {
+		return "", err
+	}
+	policyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading policy file")
+	}
+	return string(policyBytes), nil
+}
+
+func validateTagValues(tags []models.ApiClientTagIdValue) error {
+	for _, tag := range tags {
+		if err := validation.ValidateTagName(tag.Key); err != nil {
+			return err
+		}
+		if err := validation.ValidateTagValue(tag.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePolicySize(policy string) error {
+	if len(policy) > constants.MaxPolicyFileSize {
+		return validation.NewInputError(errors.Errorf("%s: %d", constants.ErrorInvalidSize, len(policy)))
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"github.com/pkg/errors"
+	"intel/tac/v1/constants"
+	"os"
+)
+
+// envStore does not persist the API key, it has to be provided with the TRUSTAUTHORITY_API_KEY env variable
+// whenever the CLI is used
+type envStore struct{}
+
+func (es *envStore) Name() string {
+	return Env
+}
+
+func (es *envStore) Get(profile string) (string, error) {
+	apiKey := os.Getenv(constants.ApiKeyEnvVar)
+	if apiKey == "" {
+		return "", errors.Errorf("Profile %q reads the API key from the environment, %s env variable needs to be set",
+			profile, constants.ApiKeyEnvVar)
+	}
+	return apiKey, nil
+}
+
+// Set fails since the API key cannot be kept in the environment of the user, it has to be exported instead
+func (es *envStore) Set(string, string) error {
+	return errors.Errorf("The %s secret store does not keep the API key, export it with the %s env variable instead",
+		Env, constants.ApiKeyEnvVar)
+}
+
+func (es *envStore) Delete(string) error {
+	return nil
+}
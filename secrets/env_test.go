@@ -0,0 +1,29 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"testing"
+)
+
+func TestEnvStore(t *testing.T) {
+	store := &envStore{}
+
+	t.Setenv(constants.ApiKeyEnvVar, "")
+	_, err := store.Get("ci")
+	assert.ErrorContains(t, err, constants.ApiKeyEnvVar, "Test reading the key when the env variable is not set")
+
+	t.Setenv(constants.ApiKeyEnvVar, testApiKey)
+	apiKey, err := store.Get("ci")
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, apiKey, "Test reading the key from the env variable")
+
+	assert.ErrorContains(t, store.Set("ci", testApiKey), "export it with the "+constants.ApiKeyEnvVar+" env variable",
+		"Test storing a key, which is not kept")
+	assert.NoError(t, store.Delete("ci"))
+}
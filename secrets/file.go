@@ -0,0 +1,155 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"bytes"
+	"filippo.io/age"
+	"fmt"
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+	"intel/tac/v1/constants"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fileStore keeps the API key of each profile in an age file encrypted with a passphrase. The passphrase is read
+// from the TRUSTAUTHORITY_SECRET_PASSPHRASE env variable or prompted for when running in a terminal.
+type fileStore struct{}
+
+func (fs *fileStore) Name() string {
+	return File
+}
+
+func (fs *fileStore) Get(profile string) (string, error) {
+	path, err := secretFilePath(profile)
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Errorf("API key of profile %q not found in the encrypted secret store", profile)
+		}
+		return "", errors.Wrap(err, "Error reading encrypted API key file")
+	}
+
+	passphrase, err := readPassphrase(fmt.Sprintf("Passphrase for the API key of profile %q: ", profile), false)
+	if err != nil {
+		return "", err
+	}
+
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid passphrase")
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(encrypted), identity)
+	if err != nil {
+		return "", errors.Wrap(err, "Error decrypting API key, check the passphrase")
+	}
+
+	apiKey, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errors.Wrap(err, "Error decrypting API key")
+	}
+	return string(apiKey), nil
+}
+
+func (fs *fileStore) Set(profile, apiKey string) error {
+	path, err := secretFilePath(profile)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := readPassphrase(fmt.Sprintf("New passphrase for the API key of profile %q: ", profile), true)
+	if err != nil {
+		return err
+	}
+
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return errors.Wrap(err, "Invalid passphrase")
+	}
+
+	var encrypted bytes.Buffer
+	writer, err := age.Encrypt(&encrypted, recipient)
+	if err != nil {
+		return errors.Wrap(err, "Error encrypting API key")
+	}
+	if _, err = io.WriteString(writer, apiKey); err != nil {
+		return errors.Wrap(err, "Error encrypting API key")
+	}
+	if err = writer.Close(); err != nil {
+		return errors.Wrap(err, "Error encrypting API key")
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "Error creating secrets directory")
+	}
+	if err = os.WriteFile(path, encrypted.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "Error writing encrypted API key file")
+	}
+	return nil
+}
+
+func (fs *fileStore) Delete(profile string) error {
+	path, err := secretFilePath(profile)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "Error deleting encrypted API key file")
+	}
+	return nil
+}
+
+func secretFilePath(profile string) (string, error) {
+	userHomeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "Error fetching user home directory path")
+	}
+	return filepath.Clean(userHomeDir + constants.SecretsDir + profile + constants.SecretFileExtension), nil
+}
+
+// readPassphrase returns the passphrase set in the env or prompts for it on the terminal, asking for a
+// confirmation when a new passphrase is set
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	if passphrase := os.Getenv(constants.SecretPassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.Errorf("Passphrase of the encrypted secret store needs to be provided with the %s env variable",
+			constants.SecretPassphraseEnvVar)
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", errors.Wrap(err, "Error reading passphrase")
+	}
+	if len(passphrase) == 0 {
+		return "", errors.New("Passphrase cannot be empty")
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Confirm passphrase: ")
+		confirmation, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", errors.Wrap(err, "Error reading passphrase")
+		}
+		if !bytes.Equal(passphrase, confirmation) {
+			return "", errors.New("Passphrases do not match")
+		}
+	}
+	return string(passphrase), nil
+}
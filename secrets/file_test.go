@@ -0,0 +1,56 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"github.com/stretchr/testify/assert"
+	"intel/tac/v1/constants"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(constants.SecretPassphraseEnvVar, "test passphrase")
+	store := &fileStore{}
+
+	_, err := store.Get("staging")
+	assert.ErrorContains(t, err, "not found in the encrypted secret store", "Test reading a key which is not stored")
+
+	assert.NoError(t, store.Set("staging", testApiKey))
+	path := filepath.Join(homeDir, constants.SecretsDir, "staging"+constants.SecretFileExtension)
+	info, err := os.Stat(path)
+	if assert.NoError(t, err, "Test the key is stored in a file per profile") {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "Test the file is only readable by the user")
+	}
+	encrypted, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(encrypted), "age-encryption.org/v1"), "Test the file is age encrypted")
+	assert.NotContains(t, string(encrypted), testApiKey, "Test the key is not stored in clear")
+
+	apiKey, err := store.Get("staging")
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, apiKey, "Test reading the key stored")
+
+	t.Setenv(constants.SecretPassphraseEnvVar, "wrong passphrase")
+	_, err = store.Get("staging")
+	assert.ErrorContains(t, err, "check the passphrase", "Test reading the key with another passphrase")
+
+	// the passphrase is prompted for when it is not provided, which cannot be done without terminal
+	t.Setenv(constants.SecretPassphraseEnvVar, "")
+	_, err = store.Get("staging")
+	assert.ErrorContains(t, err, constants.SecretPassphraseEnvVar, "Test reading the key without passphrase")
+	assert.ErrorContains(t, store.Set("production", testApiKey), constants.SecretPassphraseEnvVar,
+		"Test storing a key without passphrase")
+
+	assert.NoError(t, store.Delete("staging"))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "Test the file is deleted")
+	assert.NoError(t, store.Delete("staging"), "Test deleting a key which is not stored")
+}
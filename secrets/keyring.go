@@ -0,0 +1,52 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"github.com/pkg/errors"
+	"github.com/zalando/go-keyring"
+	"intel/tac/v1/constants"
+)
+
+// keyringStore keeps the API key in the OS keyring under the CLI service name, with the profile name as user
+type keyringStore struct{}
+
+func (ks *keyringStore) Name() string {
+	return Keyring
+}
+
+func (ks *keyringStore) Get(profile string) (string, error) {
+	apiKey, err := keyring.Get(constants.SecretServiceName, profile)
+	if err != nil {
+		if err == keyring.ErrNotFound {
+			return "", errors.Errorf("API key of profile %q not found in the OS keyring", profile)
+		}
+		return "", errors.Wrap(err, "Error reading API key from the OS keyring. Make sure the Secret Service is available or "+
+			"select another secret store")
+	}
+	return apiKey, nil
+}
+
+func (ks *keyringStore) Set(profile, apiKey string) error {
+	if err := keyring.Set(constants.SecretServiceName, profile, apiKey); err != nil {
+		return errors.Wrap(err, "Error storing API key in the OS keyring. Make sure the Secret Service is available or "+
+			"select another secret store")
+	}
+	return nil
+}
+
+func (ks *keyringStore) Delete(profile string) error {
+	if err := keyring.Delete(constants.SecretServiceName, profile); err != nil && err != keyring.ErrNotFound {
+		return errors.Wrap(err, "Error deleting API key from the OS keyring")
+	}
+	return nil
+}
+
+// keyringAvailable checks if the OS keyring can be reached, looking up an entry which does not exist
+func keyringAvailable() bool {
+	_, err := keyring.Get(constants.SecretServiceName, constants.SecretServiceProbeUser)
+	return err == nil || err == keyring.ErrNotFound
+}
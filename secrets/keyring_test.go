@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+	"intel/tac/v1/constants"
+	"testing"
+)
+
+const testApiKey = "dGVzdGFwaWtleWZvcnRoZXNlY3JldHN0b3JldGVzdHM"
+
+func TestKeyringStore(t *testing.T) {
+	keyring.MockInit()
+	store := &keyringStore{}
+
+	_, err := store.Get("staging")
+	assert.ErrorContains(t, err, "not found in the OS keyring", "Test reading a key which is not stored")
+
+	assert.NoError(t, store.Set("staging", testApiKey))
+	apiKey, err := store.Get("staging")
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, apiKey, "Test reading the key stored")
+	apiKey, err = keyring.Get(constants.SecretServiceName, "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, testApiKey, apiKey, "Test the key is stored under the service name of the CLI")
+
+	assert.NoError(t, store.Delete("staging"))
+	_, err = store.Get("staging")
+	assert.Error(t, err, "Test reading a deleted key")
+	assert.NoError(t, store.Delete("staging"), "Test deleting a key which is not stored")
+}
+
+func TestKeyringStoreUnavailable(t *testing.T) {
+	keyring.MockInitWithError(errors.New("The name org.freedesktop.secrets was not provided by any .service files"))
+	t.Cleanup(keyring.MockInit)
+	store := &keyringStore{}
+
+	assert.False(t, keyringAvailable())
+	assert.ErrorContains(t, store.Set("staging", testApiKey), "Make sure the Secret Service is available")
+	_, err := store.Get("staging")
+	assert.ErrorContains(t, err, "Make sure the Secret Service is available")
+	assert.Error(t, store.Delete("staging"))
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// Secret store names
+const (
+	// Plaintext keeps the API key in the configuration file, as done by previous versions of the CLI
+	Plaintext = "plaintext"
+	// Keyring stores the API key in the OS keyring, i.e. the Secret Service over D-Bus on Linux
+	Keyring = "keyring"
+	// File stores the API key in a passphrase encrypted (age) file under the configuration directory
+	File = "file"
+	// Env does not store the API key, it is read from the TRUSTAUTHORITY_API_KEY env variable
+	Env = "env"
+	// Auto selects the keyring when it is available, else the encrypted file
+	Auto = "auto"
+)
+
+var storeNames = []string{Plaintext, Keyring, File, Env, Auto}
+
+// Store persists the Trust Authority API key of a configuration profile outside of the configuration file
+type Store interface {
+	// Name returns the name of the store to be recorded in the profile
+	Name() string
+	Get(profile string) (string, error)
+	Set(profile, apiKey string) error
+	Delete(profile string) error
+}
+
+// Names returns the list of supported secret stores
+func Names() []string {
+	return storeNames
+}
+
+// IsExternal checks if the API key of a profile using the store is kept outside of the configuration file
+func IsExternal(name string) bool {
+	return name != "" && name != Plaintext
+}
+
+// Validate checks if the secret store name is supported
+func Validate(name string) error {
+	for _, storeName := range storeNames {
+		if name == storeName {
+			return nil
+		}
+	}
+	return errors.Errorf("Unsupported secret store %q, should be one of %s", name, strings.Join(storeNames, ", "))
+}
+
+// New returns the store for the provided name. The plaintext store is handled by the configuration itself and
+// has no Store implementation.
+func New(name string) (Store, error) {
+	switch name {
+	case Keyring:
+		return &keyringStore{}, nil
+	case File:
+		return &fileStore{}, nil
+	case Env:
+		return &envStore{}, nil
+	case Auto:
+		if keyringAvailable() {
+			return &keyringStore{}, nil
+		}
+		return &fileStore{}, nil
+	}
+	if err := Validate(name); err != nil {
+		return nil, err
+	}
+	return nil, errors.Errorf("Secret store %q does not keep the API key outside of the configuration file", name)
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package secrets
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	t.Cleanup(keyring.MockInit)
+
+	tt := []struct {
+		name             string
+		keyringAvailable bool
+		wantStore        string
+		wantErr          bool
+		description      string
+	}{
+		{
+			name:             Keyring,
+			keyringAvailable: true,
+			wantStore:        Keyring,
+			description:      "Test the keyring store",
+		},
+		{
+			name:        File,
+			wantStore:   File,
+			description: "Test the encrypted file store",
+		},
+		{
+			name:        Env,
+			wantStore:   Env,
+			description: "Test the env store",
+		},
+		{
+			name:             Auto,
+			keyringAvailable: true,
+			wantStore:        Keyring,
+			description:      "Test the auto store selects the keyring when it is available",
+		},
+		{
+			name:             Auto,
+			keyringAvailable: false,
+			wantStore:        File,
+			description:      "Test the auto store falls back to the encrypted file",
+		},
+		{
+			name:        Plaintext,
+			wantErr:     true,
+			description: "Test the plaintext store has no implementation",
+		},
+		{
+			name:        "vault",
+			wantErr:     true,
+			description: "Test an unsupported store",
+		},
+	}
+
+	for _, tc := range tt {
+		if tc.keyringAvailable {
+			keyring.MockInit()
+		} else {
+			keyring.MockInitWithError(errors.New("The name org.freedesktop.secrets was not provided by any .service files"))
+		}
+
+		store, err := New(tc.name)
+		if tc.wantErr {
+			assert.Error(t, err, tc.description)
+			continue
+		}
+		assert.NoError(t, err, tc.description)
+		assert.Equal(t, tc.wantStore, store.Name(), tc.description)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, name := range Names() {
+		assert.NoError(t, Validate(name), name)
+	}
+	assert.ErrorContains(t, Validate("vault"), "Unsupported secret store \"vault\"")
+	assert.ErrorContains(t, Validate(""), "Unsupported secret store")
+
+	assert.False(t, IsExternal(""), "Test no store keeps the API key in the configuration file")
+	assert.False(t, IsExternal(Plaintext), "Test the plaintext store keeps the API key in the configuration file")
+	assert.True(t, IsExternal(Keyring), "Test the keyring store keeps the API key outside of the configuration file")
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+// Package telemetry exports the OpenTelemetry spans and metrics recorded by the CLI and the client, either to an
+// OTLP/HTTP endpoint or to a local file. Nothing is recorded unless one of them is configured.
+package telemetry
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"intel/tac/v1/constants"
+	"intel/tac/v1/validation"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Options selects where the telemetry is exported
+type Options struct {
+	// Endpoint is the base URL of the OTLP/HTTP collector, e.g. http://localhost:4318. The spans and metrics are
+	// sent to its /v1/traces and /v1/metrics paths.
+	Endpoint string
+	// File is the path of the file the spans and metrics are appended to as JSON, for offline use
+	File string
+	// ServiceVersion is the version of the CLI reported with the telemetry
+	ServiceVersion string
+}
+
+// ShutdownFunc flushes the telemetry recorded and stops the exporters
+type ShutdownFunc func(ctx context.Context) error
+
+// Enabled tells if the telemetry is exported
+func (o *Options) Enabled() bool {
+	return o.Endpoint != "" || o.File != ""
+}
+
+// Setup installs the global tracer and meter providers exporting to the endpoint and file of the options. The
+// returned function has to be called before exiting so that the telemetry recorded is exported. Nothing is installed
+// when the options do not enable the telemetry.
+func Setup(ctx context.Context, opts *Options) (ShutdownFunc, error) {
+	if !opts.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	if err := validation.ValidateOtlpEndpoint(opts.Endpoint); err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(constants.RootCmd), semconv.ServiceVersion(opts.ServiceVersion)))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create telemetry resource")
+	}
+	tracerOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	meterOpts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	var closers []func() error
+
+	if opts.Endpoint != "" {
+		endpointUrl, _ := url.Parse(opts.Endpoint)
+		basePath := strings.TrimSuffix(endpointUrl.Path, "/")
+		traceOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpointUrl.Host),
+			otlptracehttp.WithURLPath(basePath + constants.OtlpTracesPath)}
+		metricOpts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(endpointUrl.Host),
+			otlpmetrichttp.WithURLPath(basePath + constants.OtlpMetricsPath)}
+		if endpointUrl.Scheme == "http" {
+			traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+			metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+		}
+		traceExporter, err := otlptracehttp.New(ctx, traceOpts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to create OTLP trace exporter")
+		}
+		metricExporter, err := otlpmetrichttp.New(ctx, metricOpts...)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to create OTLP metric exporter")
+		}
+		tracerOpts = append(tracerOpts, sdktrace.WithBatcher(traceExporter))
+		meterOpts = append(meterOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)))
+	}
+
+	if opts.File != "" {
+		path := filepath.Clean(opts.File)
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, constants.DefaultFilePermission)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to open telemetry file")
+		}
+		closers = append(closers, file.Close)
+		traceExporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, errors.Wrap(err, "Failed to create file trace exporter")
+		}
+		metricExporter, err := stdoutmetric.New(stdoutmetric.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, errors.Wrap(err, "Failed to create file metric exporter")
+		}
+		tracerOpts = append(tracerOpts, sdktrace.WithBatcher(traceExporter))
+		meterOpts = append(meterOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)))
+	}
+
+	previousTracerProvider, previousMeterProvider := otel.GetTracerProvider(), otel.GetMeterProvider()
+	tracerProvider := sdktrace.NewTracerProvider(tracerOpts...)
+	meterProvider := sdkmetric.NewMeterProvider(meterOpts...)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return func(ctx context.Context) error {
+		otel.SetTracerProvider(previousTracerProvider)
+		otel.SetMeterProvider(previousMeterProvider)
+		// the providers are flushed before the file they write to is closed
+		err := tracerProvider.Shutdown(ctx)
+		if metricErr := meterProvider.Shutdown(ctx); err == nil {
+			err = metricErr
+		}
+		for _, closeFile := range closers {
+			if closeErr := closeFile(); err == nil {
+				err = closeErr
+			}
+		}
+		return errors.Wrap(err, "Failed to export telemetry")
+	}, nil
+}
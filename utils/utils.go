@@ -17,7 +17,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"intel/tac/v1/constants"
-	models2 "intel/tac/v1/internal/models"
 	"intel/tac/v1/validation"
 	"io"
 	"os"
@@ -63,8 +62,12 @@ func ReadAnswerFileToEnv(filename string) error {
 }
 func isValidEnvVariable(lookup string) bool {
 	envMap := map[string]bool{
-		"TRUSTAUTHORITY_URL":     true,
-		"TRUSTAUTHORITY_API_KEY": true,
+		constants.UrlEnvVar:               true,
+		constants.ApiKeyEnvVar:            true,
+		constants.LogLevelEnvVar:          true,
+		constants.HttpClientTimeoutEnvVar: true,
+		constants.LegacyLogLevelEnvVar:    true,
+		constants.LegacyTimeoutEnvVar:     true,
 	}
 	if _, ok := envMap[lookup]; ok {
 		return true
@@ -161,15 +164,6 @@ func GenerateOutputFileName(inputFile string) (string, error) {
 	return filename + ".signed." + date + ".txt", nil
 }
 
-func PrintRequestAndTraceId() {
-	if models2.RespHeaderFields.RequestId != "" {
-		fmt.Println(constants.HTTPHeaderKeyRequestId+": ", models2.RespHeaderFields.RequestId)
-	}
-	if models2.RespHeaderFields.TraceId != "" {
-		fmt.Println(constants.HTTPHeaderKeyTraceId+": ", models2.RespHeaderFields.TraceId)
-	}
-}
-
 // publicKeyToBytes public key to bytes
 func publicKeyToBytes(pub *rsa.PublicKey) []byte {
 	pubASN1, err := x509.MarshalPKIXPublicKey(pub)
@@ -0,0 +1,37 @@
+/*
+ * Copyright (C) 2023 Intel Corporation
+ * SPDX-License-Identifier: BSD-3-Clause
+ */
+
+package validation
+
+import (
+	"github.com/pkg/errors"
+)
+
+// InputError is returned when a flag, an argument or a configuration value provided by the user is invalid
+type InputError struct {
+	err error
+}
+
+func (e *InputError) Error() string {
+	return e.err.Error()
+}
+
+func (e *InputError) Unwrap() error {
+	return e.err
+}
+
+// NewInputError marks the error as caused by an invalid input
+func NewInputError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &InputError{err: err}
+}
+
+// IsInputError checks if the error or one of the errors it wraps is caused by an invalid input
+func IsInputError(err error) bool {
+	var inputErr *InputError
+	return errors.As(err, &inputErr)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"intel/tac/v1/constants"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -33,7 +34,7 @@ var (
 func ValidateStrings(strings []string) error {
 	for _, stringValue := range strings {
 		if !stringReg.MatchString(stringValue) {
-			return errors.New("Invalid string formatted input")
+			return NewInputError(errors.New("Invalid string formatted input"))
 		}
 	}
 	return nil
@@ -42,7 +43,7 @@ func ValidateStrings(strings []string) error {
 func ValidateEmailAddress(email string) error {
 	if !emailReg.Match([]byte(email)) {
 		logrus.Error("Invalid email id provided")
-		return errors.New("Invalid email id provided")
+		return NewInputError(errors.New("Invalid email id provided"))
 	}
 
 	return nil
@@ -52,7 +53,7 @@ func ValidatePath(path string) (string, error) {
 	c := filepath.Clean(path)
 	r, err := filepath.EvalSymlinks(c)
 	if err != nil {
-		return c, fmt.Errorf("%s: %s", constants.ErrorInvalidPath, path)
+		return c, NewInputError(fmt.Errorf("%s: %s", constants.ErrorInvalidPath, path))
 	}
 	return r, nil
 }
@@ -63,69 +64,160 @@ func ValidateSize(path string) error {
 		return err
 	}
 	if fi.Size() > constants.MaxPolicyFileSize {
-		return fmt.Errorf("%s: %d", constants.ErrorInvalidSize, fi.Size())
+		return NewInputError(fmt.Errorf("%s: %d", constants.ErrorInvalidSize, fi.Size()))
 	}
 	return nil
 }
 
 func ValidateTrustAuthorityAPIKey(apiKey string) error {
 	if strings.TrimSpace(apiKey) == "" {
-		return errors.Errorf("%s config variable needs to be set with a proper API Key before using CLI", constants.TrustAuthApiKeyEnvVar)
+		return NewInputError(errors.Errorf("%s config variable needs to be set with a proper API Key before using CLI", constants.TrustAuthApiKeyEnvVar))
 	}
 	if !apiKeyRegex.MatchString(apiKey) {
-		return errors.New("Invalid API key found in configuration file. Please update it with a valid API key.")
+		return NewInputError(errors.New("Invalid API key found in configuration file. Please update it with a valid API key."))
 	}
 	return nil
 }
 
 func ValidateApiClientName(name string) error {
 	if strings.TrimSpace(name) == "" {
-		return errors.New("ApiClient name cannot be empty")
+		return NewInputError(errors.New("ApiClient name cannot be empty"))
 	}
 	if !subscriptionNameRegex.Match([]byte(name)) {
-		return errors.New("ApiClient name should be alphanumeric and start with an alphanumeric character with " +
-			"_ or - as separator and should be at most 64 characters long")
+		return NewInputError(errors.New("ApiClient name should be alphanumeric and start with an alphanumeric character with " +
+			"_ or - as separator and should be at most 64 characters long"))
 	}
 	return nil
 }
 
 func ValidateTagName(name string) error {
 	if strings.TrimSpace(name) == "" {
-		return errors.New("Tag name cannot be empty")
+		return NewInputError(errors.New("Tag name cannot be empty"))
 	}
 	if !tagReg.Match([]byte(name)) {
-		return errors.New("Tag name should be alphanumeric and start with an alphanumeric character with " +
-			"_ or - as separator and should be at most 64 characters long")
+		return NewInputError(errors.New("Tag name should be alphanumeric and start with an alphanumeric character with " +
+			"_ or - as separator and should be at most 64 characters long"))
 	}
 	return nil
 }
 
 func ValidateTagValue(value string) error {
 	if strings.TrimSpace(value) == "" {
-		return errors.New("Tag value cannot be empty")
+		return NewInputError(errors.New("Tag value cannot be empty"))
 	}
 	if !tagValueReg.Match([]byte(value)) {
-		return errors.New("Tag value should be alphanumeric and start with an alphanumeric character with " +
-			"_ or - as separator and should be at most 64 characters long")
+		return NewInputError(errors.New("Tag value should be alphanumeric and start with an alphanumeric character with " +
+			"_ or - as separator and should be at most 64 characters long"))
 	}
 	return nil
 }
 
 func ValidatePolicyName(policyName string) error {
 	if strings.TrimSpace(policyName) == "" {
-		return errors.New("Policy name cannot be empty")
+		return NewInputError(errors.New("Policy name cannot be empty"))
 	}
 	if !policyNameRegex.Match([]byte(policyName)) {
-		return errors.New("Policy name is invalid. Policy name should be alpha numeric and have minimum 3 characters with no spaces between words (" +
-			"use \"_\" or \"-\" as separators) and should not be more than 64 characters")
+		return NewInputError(errors.New("Policy name is invalid. Policy name should be alpha numeric and have minimum 3 characters with no spaces between words (" +
+			"use \"_\" or \"-\" as separators) and should not be more than 64 characters"))
 	}
 	return nil
 }
 
 func ValidateRequestId(requestId string) error {
 	if strings.TrimSpace(requestId) != "" && !requestIdRegex.Match([]byte(requestId)) {
-		return errors.New("Request ID should be at most 128 characters long and should contain only " +
-			"alphanumeric characters, _, space, - or \\")
+		return NewInputError(errors.New("Request ID should be at most 128 characters long and should contain only " +
+			"alphanumeric characters, _, space, - or \\"))
+	}
+	return nil
+}
+
+// ValidateRequestIdPrefix checks that the request IDs generated with the prefix are valid request IDs
+func ValidateRequestIdPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if len(prefix) > constants.MaxRequestIdPrefixLength || !requestIdRegex.Match([]byte(prefix)) {
+		return NewInputError(errors.Errorf("Request ID prefix should be at most %d characters long and should contain "+
+			"only alphanumeric characters, _, space, - or \\", constants.MaxRequestIdPrefixLength))
+	}
+	return nil
+}
+
+// ValidateOtlpEndpoint checks that the OTLP endpoint the telemetry is exported to is an http or https URL
+func ValidateOtlpEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return NewInputError(errors.Wrap(err, "Invalid OTLP endpoint"))
+	}
+	if (endpointUrl.Scheme != "http" && endpointUrl.Scheme != "https") || endpointUrl.Host == "" {
+		return NewInputError(errors.Errorf("Invalid OTLP endpoint %q, should be an http or https URL", endpoint))
+	}
+	return nil
+}
+
+func ValidateTrustAuthorityUrl(baseUrl string) error {
+	if strings.TrimSpace(baseUrl) == "" {
+		return NewInputError(errors.Errorf("%s config variable needs to be set with the Trust Authority base URL", constants.TrustAuthBaseUrl))
+	}
+	parsedUrl, err := url.ParseRequestURI(baseUrl)
+	if err != nil {
+		return NewInputError(errors.Wrap(err, "Invalid Trust Authority base URL"))
+	}
+	if parsedUrl.Scheme != "https" && parsedUrl.Scheme != "http" {
+		return NewInputError(errors.Errorf("Invalid Trust Authority base URL scheme %q, should be https", parsedUrl.Scheme))
+	}
+	if parsedUrl.Host == "" {
+		return NewInputError(errors.New("Invalid Trust Authority base URL, host is missing"))
+	}
+	return nil
+}
+
+func ValidateLogLevel(logLevel string) error {
+	if _, err := logrus.ParseLevel(logLevel); err != nil {
+		return NewInputError(errors.Wrap(err, "Invalid log level provided"))
+	}
+	return nil
+}
+
+func ValidateHttpClientTimeout(timeout int) error {
+	if timeout <= 0 {
+		return NewInputError(errors.Errorf("Invalid HTTP client timeout %d, should be a positive number of seconds", timeout))
+	}
+	return nil
+}
+
+func ValidatePolicyType(policyType string) error {
+	if policyType != constants.AppraisalPolicyType && policyType != constants.TokenCustomizationPolicyType {
+		return NewInputError(errors.Errorf("Invalid policy type provided in request, should be one of %s or %s",
+			constants.AppraisalPolicyType, constants.TokenCustomizationPolicyType))
+	}
+	return nil
+}
+
+func ValidateAttestationType(attestationType string) error {
+	if attestationType != constants.TdxAttestationType && attestationType != constants.SgxAttestationType {
+		return NewInputError(errors.Errorf("Invalid attestation type provided in request, should be one of %s or %s",
+			constants.TdxAttestationType, constants.SgxAttestationType))
+	}
+	return nil
+}
+
+func ValidateUserRole(userRole string) error {
+	if userRole != constants.TenantAdminRole && userRole != constants.UserRole {
+		return NewInputError(errors.Errorf("%s is not a valid user role. Roles should be either %s or %s", userRole,
+			constants.TenantAdminRole, constants.UserRole))
+	}
+	return nil
+}
+
+func ValidateApiClientStatus(status string) error {
+	if status != constants.ApiClientStatusActive && status != constants.ApiClientStatusInactive &&
+		status != constants.ApiClientStatusCancelled {
+		return NewInputError(errors.Errorf("Activation status should be one of %s, %s or %s", constants.ApiClientStatusActive,
+			constants.ApiClientStatusInactive, constants.ApiClientStatusCancelled))
 	}
 	return nil
 }